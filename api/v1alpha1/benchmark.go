@@ -0,0 +1,323 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ExclusivityScope defines the set of resources which are
+// used exclusively by a benchmark
+// +kubebuilder:validation:Enum=Node;StorageClass;Cluster
+type ExclusivityScope string
+
+const (
+	// NodeExclusivity serializes the benchmarks running on the same node
+	NodeExclusivity ExclusivityScope = "Node"
+	// StorageClassExclusivity serializes the benchmarks using the same storage class
+	StorageClassExclusivity ExclusivityScope = "StorageClass"
+	// ClusterExclusivity serializes the benchmarks of the whole cluster
+	ClusterExclusivity ExclusivityScope = "Cluster"
+)
+
+// ExclusivitySpec describes the resources which must not be shared
+// with other benchmarks during the execution of the benchmark.
+// Benchmarks with the same scope and key are executed one after the
+// other in the order they were queued.
+type ExclusivitySpec struct {
+	// Scope is the kind of resource used exclusively by the benchmark
+	Scope ExclusivityScope `json:"scope"`
+
+	// Key is the name of the node or the storage class when the scope
+	// is Node or StorageClass and it cannot be derived from the spec.
+	// The nodes are derived from the podScheduling settings, the storage
+	// classes from the volumes of the benchmark. It is ignored for
+	// Cluster scope.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// Validate checks that the scope is known
+func (e *ExclusivitySpec) Validate() error {
+	switch e.Scope {
+	case NodeExclusivity, StorageClassExclusivity, ClusterExclusivity:
+		return nil
+	default:
+		return errors.New("Unknown exclusivity scope: " + string(e.Scope))
+	}
+}
+
+// RunPolicySpec contains the settings which are shared by every benchmark
+// kind and control how the operator handles the lifecycle of the benchmark.
+type RunPolicySpec struct {
+	// TTLSecondsAfterFinished limits the lifetime of a benchmark that has
+	// finished execution. Once the TTL expires the benchmark CR is deleted
+	// together with the objects (jobs, pods, PVCs, ...) created for it.
+	// When unset, the default of the manager is used. If neither is set,
+	// the benchmark is kept until it is deleted manually.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// TimeoutSeconds limits the run time of the jobs of the benchmark
+	// (their activeDeadlineSeconds). Jobs running longer are terminated
+	// and the benchmark fails.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+
+	// Suspend postpones the start of the benchmark while set to true.
+	// It has no effect on benchmarks which are already running, those
+	// can be stopped with Cancel.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Cancel aborts the benchmark: the objects created for it are deleted
+	// and the benchmark is moved to the Cancelled terminal state. The
+	// logs of the interrupted benchmark pods are kept as partial results
+	// in a ConfigMap.
+	// +optional
+	Cancel bool `json:"cancel,omitempty"`
+
+	// Exclusivity makes the benchmark wait until the earlier benchmarks
+	// with the same exclusivity scope are finished, so that concurrent
+	// runs do not interfere with each other.
+	// +optional
+	Exclusivity *ExclusivitySpec `json:"exclusivity,omitempty"`
+
+	// Iterations runs the benchmark pod the given number of times, one
+	// after the other. The results of the iterations are aggregated into
+	// statistics. Overrides the completions of the benchmarks having them.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Iterations int32 `json:"iterations,omitempty"`
+
+	// WarmupIterations are run before the Iterations and their results
+	// are discarded. Ignored when Iterations is not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WarmupIterations int32 `json:"warmupIterations,omitempty"`
+
+	// Archive uploads the raw output of the finished benchmark to an
+	// S3 compatible bucket
+	// +optional
+	Archive *ArchiveSpec `json:"archive,omitempty"`
+
+	// Webhooks are notified about the lifecycle events of the benchmark.
+	// When set, they replace the global webhooks of the manager. Their
+	// hosts must be allowed by the --benchmark-webhook-hosts of the manager.
+	// +optional
+	Webhooks []WebhookSpec `json:"webhooks,omitempty"`
+
+	// Regression compares the results of the benchmark to a baseline and
+	// sets the Passed or the Regressed condition accordingly
+	// +optional
+	Regression *RegressionSpec `json:"regression,omitempty"`
+
+	// Profile is the name of the BenchmarkProfile providing the defaults
+	// of the spec. The fields set in the spec override the profile.
+	// +optional
+	Profile string `json:"profile,omitempty"`
+
+	// Variables provide the values of the {{ .Vars.<name> }} references
+	// in the string fields of the spec. The templates are resolved once,
+	// before the benchmark is started, see status.resolvedSpec.
+	// +optional
+	Variables *VariablesSpec `json:"variables,omitempty"`
+
+	// DryRun builds the objects of the benchmark and submits them with
+	// server-side dry-run instead of running the benchmark. The outcome is
+	// shown in status.dryRun, rejected objects mark the benchmark Failed.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// VariablesSpec describes the sources of the variables of a templated
+// benchmark. The values given inline override the ones of the ConfigMap.
+// The annotations vars.kubestone.xridge.io/<name> of the benchmark
+// override both. There is no suite or matrix resource, the annotations
+// are set by the tools generating benchmarks.
+type VariablesSpec struct {
+	// ConfigMap is the name of a ConfigMap in the namespace of the
+	// benchmark. Its data is available as variables.
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+
+	// Values are the variables given inline
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// ArchiveSpec describes the S3 compatible bucket where the pod logs, the
+// report files (see LogSpec) and the spec of the benchmark are uploaded
+// once the benchmark is finished. The objects are stored under
+// <prefix>/<namespace>/<name>/ in the bucket.
+type ArchiveSpec struct {
+	// Endpoint is the URL of the S3 compatible storage, e.g.
+	// http://minio.minio.svc:9000. Defaults to AWS S3.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Region of the bucket
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Bucket is the name of the bucket
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the keys of the uploaded objects
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// CredentialsSecret is the name of the Secret holding the
+	// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY of the bucket
+	CredentialsSecret string `json:"credentialsSecret"`
+
+	// Image of the uploader, which must provide the aws command line
+	// interface. Defaults to amazon/aws-cli.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// Validate checks that the bucket and the credentials are provided
+func (a *ArchiveSpec) Validate() error {
+	if a.Bucket == "" {
+		return errors.New("Archive bucket must be set")
+	}
+	if a.CredentialsSecret == "" {
+		return errors.New("Archive credentialsSecret must be set")
+	}
+	return nil
+}
+
+// WebhookEvent is a lifecycle event of a benchmark
+// +kubebuilder:validation:Enum=Started;Succeeded;Failed;Regressed
+type WebhookEvent string
+
+const (
+	// StartedEvent is sent when the benchmark is started
+	StartedEvent WebhookEvent = "Started"
+	// SucceededEvent is sent when every job of the benchmark succeeded
+	SucceededEvent WebhookEvent = "Succeeded"
+	// FailedEvent is sent when a job of the benchmark failed
+	FailedEvent WebhookEvent = "Failed"
+	// RegressedEvent is sent when a result of the benchmark is outside
+	// of its regression threshold
+	RegressedEvent WebhookEvent = "Regressed"
+)
+
+// WebhookSpec describes an HTTP endpoint receiving the lifecycle
+// events of benchmarks as JSON payload via POST requests
+type WebhookSpec struct {
+	// URL of the endpoint
+	URL string `json:"url"`
+
+	// Events are the events sent to the endpoint. Every event
+	// is sent when empty.
+	// +optional
+	Events []WebhookEvent `json:"events,omitempty"`
+
+	// SigningSecret selects the key of a Secret used to sign the payload
+	// with HMAC-SHA256. The hex encoded signature is sent in the
+	// X-Kubestone-Signature header as sha256=<signature>.
+	// +optional
+	SigningSecret *corev1.SecretKeySelector `json:"signingSecret,omitempty"`
+}
+
+// ThresholdDirection tells which deviation from the baseline is a regression
+// +kubebuilder:validation:Enum=HigherIsBetter;LowerIsBetter;Both
+type ThresholdDirection string
+
+const (
+	// HigherIsBetter treats values below the baseline as regression, e.g. IOPS
+	HigherIsBetter ThresholdDirection = "HigherIsBetter"
+	// LowerIsBetter treats values above the baseline as regression, e.g. latency
+	LowerIsBetter ThresholdDirection = "LowerIsBetter"
+	// Both treats deviations in both directions as regression
+	Both ThresholdDirection = "Both"
+)
+
+// MetricThreshold describes the accepted values of a metric. Numbers are
+// given as decimal strings, e.g. "9e9" or "10.5".
+type MetricThreshold struct {
+	// Metric is the name of the metric, e.g. kubestone_fio_iops
+	Metric string `json:"metric"`
+
+	// Labels select the values of the metric, e.g. rw: read. Every
+	// value of the metric with matching labels is checked.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Baseline is the expected value of the metric. When unset, the value
+	// of the metric (with the same labels) in the BaselineResult is used.
+	// +optional
+	Baseline string `json:"baseline,omitempty"`
+
+	// TolerancePercent is the accepted deviation from the baseline in percent
+	// +optional
+	TolerancePercent string `json:"tolerancePercent,omitempty"`
+
+	// Direction tells which deviation from the baseline is a regression.
+	// Defaults to HigherIsBetter.
+	// +optional
+	Direction ThresholdDirection `json:"direction,omitempty"`
+
+	// Min is the lowest accepted value of the metric
+	// +optional
+	Min string `json:"min,omitempty"`
+
+	// Max is the highest accepted value of the metric
+	// +optional
+	Max string `json:"max,omitempty"`
+}
+
+// RegressionSpec describes the thresholds of the benchmark results
+type RegressionSpec struct {
+	// BaselineResult is the name of a BenchmarkResult in the namespace
+	// of the benchmark, which provides the baseline values of the metrics
+	// +optional
+	BaselineResult string `json:"baselineResult,omitempty"`
+
+	// Thresholds are the checks of the metrics
+	Thresholds []MetricThreshold `json:"thresholds"`
+}
+
+// Benchmark is implemented by every benchmark custom resource, so that
+// the kind independent parts of the lifecycle can be handled uniformly.
+// +kubebuilder:object:generate=false
+type Benchmark interface {
+	metav1.Object
+	runtime.Object
+
+	// GetRunPolicy returns the lifecycle settings of the benchmark
+	GetRunPolicy() *RunPolicySpec
+
+	// GetBenchmarkStatus returns the status of the benchmark
+	GetBenchmarkStatus() *BenchmarkStatus
+}
+
+// ReportWriter is implemented by the benchmarks which are able to write
+// the reports of the benchmark tool to a HostPath volume
+// +kubebuilder:object:generate=false
+type ReportWriter interface {
+	// GetLogSpec returns the settings of the report files
+	GetLogSpec() *LogSpec
+}
@@ -16,10 +16,288 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // BenchmarkStatus describes the current state of the benchmark
 type BenchmarkStatus struct {
 	// Running shows the state of execution
 	Running bool `json:"running"`
 	// Completed shows the state of completion
 	Completed bool `json:"completed"`
+	// Failed shows that at least one job of the benchmark has failed
+	// +optional
+	Failed bool `json:"failed,omitempty"`
+	// Cancelled shows that the benchmark was aborted via spec.cancel
+	// +optional
+	Cancelled bool `json:"cancelled,omitempty"`
+	// QueuePosition is the position of the benchmark in the queue of
+	// its exclusivity scope while it waits for the earlier benchmarks
+	// +optional
+	QueuePosition int32 `json:"queuePosition,omitempty"`
+	// CompletionTime is the time when the benchmark has finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// NodeNames are the nodes where the benchmark pods were running
+	// +optional
+	NodeNames []string `json:"nodeNames,omitempty"`
+	// Metrics are the results of the benchmark. The mean of the
+	// iterations is shown when the benchmark has iterations.
+	// +optional
+	Metrics []BenchmarkMetric `json:"metrics,omitempty"`
+	// Aggregates are the statistics of the results of the iterations
+	// +optional
+	Aggregates []MetricAggregate `json:"aggregates,omitempty"`
+	// Export shows the state of pushing the results to the result sink
+	// +optional
+	Export *ExportStatus `json:"export,omitempty"`
+	// Archive shows the state of uploading the raw output to the bucket
+	// +optional
+	Archive *ArchiveStatus `json:"archive,omitempty"`
+	// Conditions are the observations of the state of the benchmark
+	// +optional
+	Conditions []BenchmarkCondition `json:"conditions,omitempty"`
+	// Comparisons are the results of the regression checks
+	// +optional
+	Comparisons []MetricComparison `json:"comparisons,omitempty"`
+	// Profile is the BenchmarkProfile merged into the spec
+	// +optional
+	Profile *ProfileReference `json:"profile,omitempty"`
+	// ResolvedSpec is the JSON encoded spec of the benchmark with its
+	// templates resolved. It is used for the rest of the run, so later
+	// changes of the variables do not affect the running benchmark.
+	// +optional
+	ResolvedSpec string `json:"resolvedSpec,omitempty"`
+	// DryRun shows the objects submitted with server-side dry-run
+	// when spec.dryRun is set
+	// +optional
+	DryRun *DryRunStatus `json:"dryRun,omitempty"`
+	// ProvisionDeleted shows that the server provisioned for the
+	// benchmark (spec.provision) was torn down after it finished
+	// +optional
+	ProvisionDeleted bool `json:"provisionDeleted,omitempty"`
+}
+
+// ProfileReference identifies a version of a BenchmarkProfile
+type ProfileReference struct {
+	// Name of the BenchmarkProfile
+	Name string `json:"name"`
+	// Version of the profile
+	Version string `json:"version"`
+}
+
+// DryRunStatus is the outcome of submitting the objects of the benchmark
+// with server-side dry-run
+type DryRunStatus struct {
+	// Objects are the objects the benchmark would create, in the order
+	// of their creation. The pods of the jobs, the deployments and the
+	// statefulsets are submitted separately, as admission and quota are
+	// enforced on pods.
+	// +optional
+	Objects []DryRunObject `json:"objects,omitempty"`
+}
+
+// DryRunObject is an object submitted with server-side dry-run
+type DryRunObject struct {
+	// Kind of the object
+	Kind string `json:"kind"`
+	// Name of the object. The names of the pods are generated.
+	Name string `json:"name"`
+	// Accepted shows that the API server accepted the object
+	Accepted bool `json:"accepted"`
+	// Reason is the machine readable reason of the rejection,
+	// e.g. Forbidden or Invalid
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message explains the rejection, e.g. the exceeded quota or
+	// the violated pod security policy
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// BenchmarkConditionType is the type of a benchmark condition
+type BenchmarkConditionType string
+
+const (
+	// PassedCondition is true when the results of the benchmark
+	// are within the regression thresholds
+	PassedCondition BenchmarkConditionType = "Passed"
+	// RegressedCondition is true when at least one result of the
+	// benchmark is outside of the regression thresholds
+	RegressedCondition BenchmarkConditionType = "Regressed"
+)
+
+// BenchmarkCondition is an observation of the state of the benchmark
+type BenchmarkCondition struct {
+	// Type of the condition
+	Type BenchmarkConditionType `json:"type"`
+	// Status of the condition: True, False or Unknown
+	Status corev1.ConditionStatus `json:"status"`
+	// Reason is a machine readable explanation of the status
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable explanation of the status
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the time when the status changed
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// SetCondition adds the given condition to the status or replaces the
+// condition of the same type. The transition time is kept when the
+// status of the condition does not change.
+func (s *BenchmarkStatus) SetCondition(condition BenchmarkCondition) {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type != condition.Type {
+			continue
+		}
+		if s.Conditions[i].Status == condition.Status {
+			condition.LastTransitionTime = s.Conditions[i].LastTransitionTime
+		}
+		s.Conditions[i] = condition
+		return
+	}
+	s.Conditions = append(s.Conditions, condition)
+}
+
+// GetCondition returns the condition of the given type or nil if it is not set
+func (s *BenchmarkStatus) GetCondition(conditionType BenchmarkConditionType) *BenchmarkCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == conditionType {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// MetricComparison is the result of checking a metric against its threshold
+type MetricComparison struct {
+	// Metric is the name of the metric
+	Metric string `json:"metric"`
+	// Labels of the checked value
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Value is the measured value of the metric
+	// +optional
+	Value string `json:"value,omitempty"`
+	// Baseline is the expected value of the metric
+	// +optional
+	Baseline string `json:"baseline,omitempty"`
+	// DeviationPercent is the difference of the value and the baseline
+	// in percent of the baseline
+	// +optional
+	DeviationPercent string `json:"deviationPercent,omitempty"`
+	// Passed shows that the value is within the threshold
+	Passed bool `json:"passed"`
+	// Message explains the failed check
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// BenchmarkMetric is a single value measured by the benchmark
+type BenchmarkMetric struct {
+	// Name of the metric in Prometheus format, e.g. kubestone_fio_iops
+	Name string `json:"name"`
+	// Labels distinguish the values of the same metric
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Value is the measured value as a decimal floating point number
+	Value string `json:"value"`
+}
+
+// MetricAggregate contains the statistics of a metric measured by
+// the iterations of the benchmark. Numbers are decimal strings.
+type MetricAggregate struct {
+	// Name of the metric
+	Name string `json:"name"`
+	// Labels distinguish the values of the same metric
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Values are the results of the iterations in the order of execution
+	Values []string `json:"values"`
+	// Mean of the values
+	Mean string `json:"mean"`
+	// Median of the values
+	Median string `json:"median"`
+	// StdDev is the sample standard deviation of the values
+	StdDev string `json:"stdDev"`
+	// Min is the lowest value
+	Min string `json:"min"`
+	// Max is the highest value
+	Max string `json:"max"`
+	// ConfidenceIntervalLower is the lower bound of the 95% confidence
+	// interval of the mean. Requires at least two values.
+	// +optional
+	ConfidenceIntervalLower string `json:"confidenceIntervalLower,omitempty"`
+	// ConfidenceIntervalUpper is the upper bound of the 95% confidence
+	// interval of the mean. Requires at least two values.
+	// +optional
+	ConfidenceIntervalUpper string `json:"confidenceIntervalUpper,omitempty"`
+	// Outliers are the 1-based numbers of the iterations whose values are
+	// more than 1.5 interquartile ranges away from the quartiles. The
+	// iterations without results are counted too.
+	// +optional
+	Outliers []int32 `json:"outliers,omitempty"`
+}
+
+// ExportPhase is the state of exporting the results to an external system
+type ExportPhase string
+
+const (
+	// ExportPending means that the results are not yet exported
+	ExportPending ExportPhase = "Pending"
+	// ExportSucceeded means that the results are exported
+	ExportSucceeded ExportPhase = "Succeeded"
+	// ExportFailed means that exporting the results failed and it is not retried
+	ExportFailed ExportPhase = "Failed"
+)
+
+// ExportStatus describes the state of pushing the results to the result sink
+type ExportStatus struct {
+	// Phase is the state of the export
+	Phase ExportPhase `json:"phase"`
+	// Attempts is the number of failed attempts
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+	// LastAttemptTime is the time of the last export attempt
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+	// Message contains the error of the last failed attempt
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ArchiveStatus describes the state of uploading the raw output
+// of the benchmark to the bucket given in ArchiveSpec
+type ArchiveStatus struct {
+	// Phase is the state of the upload
+	Phase ExportPhase `json:"phase"`
+	// Objects are the URLs of the uploaded objects
+	// +optional
+	Objects []string `json:"objects,omitempty"`
+	// Message contains the reason of the failed upload
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// Phase summarizes the status of the benchmark in a single word:
+// Pending, Queued, Running, Succeeded, Failed or Cancelled
+func (s *BenchmarkStatus) Phase() string {
+	switch {
+	case s.Cancelled:
+		return "Cancelled"
+	case s.Completed && s.Failed:
+		return "Failed"
+	case s.Completed:
+		return "Succeeded"
+	case s.Running:
+		return "Running"
+	case s.QueuePosition > 0:
+		return "Queued"
+	default:
+		return "Pending"
+	}
 }
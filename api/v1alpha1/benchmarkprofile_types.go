@@ -0,0 +1,69 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// BenchmarkProfileSpec defines a preset of a benchmark kind
+type BenchmarkProfileSpec struct {
+	// Kind is the benchmark kind the profile applies to, e.g. Fio
+	Kind string `json:"kind"`
+
+	// Version of the profile. It is recorded in the status of the
+	// benchmarks using the profile, so that runs of different versions
+	// can be told apart.
+	Version string `json:"version"`
+
+	// Description tells what the profile measures
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Spec holds the fields of the benchmark spec set by the profile.
+	// The fields set in the spec of the benchmark override them.
+	Spec runtime.RawExtension `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=bp
+// +kubebuilder:printcolumn:name="Kind",type="string",JSONPath=".spec.kind"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version"
+// +kubebuilder:printcolumn:name="Description",type="string",JSONPath=".spec.description"
+
+// BenchmarkProfile is a named preset of a benchmark kind, which is
+// referenced by the benchmarks via their profile field
+type BenchmarkProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BenchmarkProfileSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BenchmarkProfileList contains a list of BenchmarkProfile
+type BenchmarkProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BenchmarkProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BenchmarkProfile{}, &BenchmarkProfileList{})
+}
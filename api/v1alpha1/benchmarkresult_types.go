@@ -0,0 +1,186 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// BenchmarkOutcome is the result of a finished benchmark
+// +kubebuilder:validation:Enum=Succeeded;Failed
+type BenchmarkOutcome string
+
+const (
+	// BenchmarkSucceeded means that every job of the benchmark succeeded
+	BenchmarkSucceeded BenchmarkOutcome = "Succeeded"
+	// BenchmarkFailed means that at least one job of the benchmark failed
+	BenchmarkFailed BenchmarkOutcome = "Failed"
+)
+
+// BenchmarkReference identifies the benchmark which produced a result
+type BenchmarkReference struct {
+	// Kind of the benchmark, e.g. Fio
+	Kind string `json:"kind"`
+	// Name of the benchmark
+	Name string `json:"name"`
+	// UID of the benchmark, which distinguishes the runs of
+	// benchmarks re-created with the same name
+	UID string `json:"uid"`
+}
+
+// NodeEnvironment describes a node where the benchmark pods were running
+type NodeEnvironment struct {
+	// Name of the node
+	Name string `json:"name"`
+	// Labels of the node, e.g. its instance type and zone
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Allocatable resources of the node
+	// +optional
+	Allocatable corev1.ResourceList `json:"allocatable,omitempty"`
+	// KernelVersion reported by the node
+	// +optional
+	KernelVersion string `json:"kernelVersion,omitempty"`
+	// OSImage reported by the node
+	// +optional
+	OSImage string `json:"osImage,omitempty"`
+	// Architecture of the node
+	// +optional
+	Architecture string `json:"architecture,omitempty"`
+	// ContainerRuntimeVersion reported by the node
+	// +optional
+	ContainerRuntimeVersion string `json:"containerRuntimeVersion,omitempty"`
+	// KubeletVersion reported by the node
+	// +optional
+	KubeletVersion string `json:"kubeletVersion,omitempty"`
+}
+
+// ImageEnvironment describes an image used by the benchmark pods
+type ImageEnvironment struct {
+	// Image as given in the container spec
+	Image string `json:"image"`
+	// ImageID is the resolved image, including its digest
+	// +optional
+	ImageID string `json:"imageID,omitempty"`
+}
+
+// VolumeEnvironment describes a persistent volume used by the benchmark pods
+type VolumeEnvironment struct {
+	// ClaimName is the name of the PersistentVolumeClaim
+	ClaimName string `json:"claimName"`
+	// StorageClassName is the storage class of the claim
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// VolumeName is the name of the bound PersistentVolume
+	// +optional
+	VolumeName string `json:"volumeName,omitempty"`
+	// Driver is the CSI driver or the provisioner of the volume
+	// +optional
+	Driver string `json:"driver,omitempty"`
+}
+
+// EnvironmentSpec describes the environment where the benchmark was running
+type EnvironmentSpec struct {
+	// NodeNames are the nodes where the benchmark pods were running
+	// +optional
+	NodeNames []string `json:"nodeNames,omitempty"`
+
+	// Nodes describe the nodes where the benchmark pods were running
+	// +optional
+	Nodes []NodeEnvironment `json:"nodes,omitempty"`
+
+	// Images are the images of the benchmark pods
+	// +optional
+	Images []ImageEnvironment `json:"images,omitempty"`
+
+	// Volumes are the persistent volumes of the benchmark pods
+	// +optional
+	Volumes []VolumeEnvironment `json:"volumes,omitempty"`
+
+	// KubernetesVersion is the version of the API server
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// KubestoneVersion is the version of the operator
+	// +optional
+	KubestoneVersion string `json:"kubestoneVersion,omitempty"`
+}
+
+// BenchmarkResultSpec is the record of a finished benchmark run
+type BenchmarkResultSpec struct {
+	// Benchmark identifies the benchmark of the run
+	Benchmark BenchmarkReference `json:"benchmark"`
+
+	// BenchmarkSpec is the spec of the benchmark at the time of the run
+	// +optional
+	BenchmarkSpec runtime.RawExtension `json:"benchmarkSpec,omitempty"`
+
+	// Outcome shows if the benchmark succeeded or failed
+	Outcome BenchmarkOutcome `json:"outcome"`
+
+	// StartTime is the time when the first job of the benchmark started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the benchmark has finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Metrics are the results of the benchmark
+	// +optional
+	Metrics []BenchmarkMetric `json:"metrics,omitempty"`
+
+	// Aggregates are the statistics of the results of the iterations
+	// +optional
+	Aggregates []MetricAggregate `json:"aggregates,omitempty"`
+
+	// Environment describes where the benchmark was running
+	// +optional
+	Environment EnvironmentSpec `json:"environment,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=br
+// +kubebuilder:printcolumn:name="Kind",type="string",JSONPath=".spec.benchmark.kind"
+// +kubebuilder:printcolumn:name="Benchmark",type="string",JSONPath=".spec.benchmark.name"
+// +kubebuilder:printcolumn:name="Outcome",type="string",JSONPath=".spec.outcome"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".spec.completionTime"
+
+// BenchmarkResult is the durable record of a finished benchmark run. It
+// is not owned by the benchmark, so it is kept after the benchmark is
+// deleted.
+type BenchmarkResult struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BenchmarkResultSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BenchmarkResultList contains a list of BenchmarkResult
+type BenchmarkResultList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BenchmarkResult `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BenchmarkResult{}, &BenchmarkResultList{})
+}
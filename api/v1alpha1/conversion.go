@@ -0,0 +1,72 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// The kinds of this version are the hub of the conversions between the
+// versions of the API, as this is the storage version.
+
+// ConvertedFromAnnotation is set on the objects converted to this version,
+// its value is the version they were converted from. The spec of these
+// objects is stored with the fields unset in the other version at their
+// zero value, so the unset fields cannot be told apart from the ones set
+// to false or 0.
+const ConvertedFromAnnotation = "perf.kubestone.xridge.io/converted-from"
+
+// Hub marks Drill as the hub of the conversions
+func (*Drill) Hub() {}
+
+// Hub marks Ethr as the hub of the conversions
+func (*Ethr) Hub() {}
+
+// Hub marks Fio as the hub of the conversions
+func (*Fio) Hub() {}
+
+// Hub marks Ioping as the hub of the conversions
+func (*Ioping) Hub() {}
+
+// Hub marks Iperf2 as the hub of the conversions
+func (*Iperf2) Hub() {}
+
+// Hub marks Iperf3 as the hub of the conversions
+func (*Iperf3) Hub() {}
+
+// Hub marks KafkaBench as the hub of the conversions
+func (*KafkaBench) Hub() {}
+
+// Hub marks Ntttcp as the hub of the conversions
+func (*Ntttcp) Hub() {}
+
+// Hub marks OcpLogtest as the hub of the conversions
+func (*OcpLogtest) Hub() {}
+
+// Hub marks Pgbench as the hub of the conversions
+func (*Pgbench) Hub() {}
+
+// Hub marks Ping as the hub of the conversions
+func (*Ping) Hub() {}
+
+// Hub marks Qperf as the hub of the conversions
+func (*Qperf) Hub() {}
+
+// Hub marks S3Bench as the hub of the conversions
+func (*S3Bench) Hub() {}
+
+// Hub marks Sysbench as the hub of the conversions
+func (*Sysbench) Hub() {}
+
+// Hub marks YcsbBench as the hub of the conversions
+func (*YcsbBench) Hub() {}
@@ -25,16 +25,19 @@ import (
 // drill [OPTIONS] --benchmark <benchmarkFile>
 type DrillSpec struct {
 	// Image defines the drill docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// BenchmarksVolume holds the content of benchmark files.
 	// The key of the map specifies the filename and the value is the content
 	// of the file. ConfigMap is created from the map which is mounted as
 	// benchmarks directory to the benchmark pod.
-	BenchmarksVolume map[string]string `json:"benchmarksVolume"`
+	// +optional
+	BenchmarksVolume map[string]string `json:"benchmarksVolume,omitempty"`
 
 	// BenchmarkFile is the entry point file (passed to --benchmark) specified to drill.
-	BenchmarkFile string `json:"benchmarkFile"`
+	// +optional
+	BenchmarkFile string `json:"benchmarkFile,omitempty"`
 
 	// Options are appended to the options parameter set of drill
 	// +optional
@@ -46,21 +49,28 @@ type DrillSpec struct {
 	PodConfig PodConfigurationSpec `json:"podConfig,omitempty"`
 
 	// The Command for the pod to be run on
-	Command []string `json:"command"`
+	// +optional
+	Command []string `json:"command,omitempty"`
 
 	// The args to be passed
-	Args []string `json:"args"`
+	// +optional
+	Args []string `json:"args,omitempty"`
 
 	// If enabled the controller will create a volume and send the log file to the host node.
 	// +optional
 	Log LogSpec `json:"log,omitempty"`
 
 	// Number of times in a row to run the test
-	Completions int32 `json:"completions"`
+	// +optional
+	Completions int32 `json:"completions,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -82,6 +92,21 @@ type DrillList struct {
 	Items           []Drill `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *Drill) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Drill) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetLogSpec returns the settings of the report files
+func (cr *Drill) GetLogSpec() *LogSpec {
+	return &cr.Spec.Log
+}
+
 func init() {
 	SchemeBuilder.Register(&Drill{}, &DrillList{})
 }
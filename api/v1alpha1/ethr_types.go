@@ -47,7 +47,8 @@ type EthrConfigurationSpec struct {
 // and client pod.
 type EthrSpec struct {
 	// Image defines the ethr docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// ServerConfiguration contains the configuration of the ethr server
 	// +optional
@@ -62,11 +63,16 @@ type EthrSpec struct {
 	Log LogSpec `json:"log,omitempty"`
 
 	// Number of times in a row to run the test
-	Completions int32 `json:"completions"`
+	// +optional
+	Completions int32 `json:"completions,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -88,6 +94,21 @@ type EthrList struct {
 	Items           []Ethr `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *Ethr) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Ethr) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetLogSpec returns the settings of the report files
+func (cr *Ethr) GetLogSpec() *LogSpec {
+	return &cr.Spec.Log
+}
+
 func init() {
 	SchemeBuilder.Register(&Ethr{}, &EthrList{})
 }
@@ -23,7 +23,8 @@ import (
 // FioSpec defines the desired state of Fio
 type FioSpec struct {
 	// Image defines the fio docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// BuiltinJobFiles contains a list of fio job files that are already present
 	// in the docker image
@@ -48,11 +49,16 @@ type FioSpec struct {
 
 	// Volume contains the configuration for the volume that the fio job should
 	// run on.
-	Volume VolumeSpec `json:"volume"`
+	// +optional
+	Volume VolumeSpec `json:"volume,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -74,6 +80,16 @@ type FioList struct {
 	Items           []Fio `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *Fio) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Fio) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
 func init() {
 	SchemeBuilder.Register(&Fio{}, &FioList{})
 }
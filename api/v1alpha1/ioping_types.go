@@ -23,7 +23,8 @@ import (
 // IopingSpec defines the ioping benchmark run
 type IopingSpec struct {
 	// Image defines the ioping docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// Args are appended to the predefined ioping parameters
 	// +optional
@@ -36,11 +37,16 @@ type IopingSpec struct {
 
 	// Volume contains the configuration for the volume that the ioping job should
 	// run on.
-	Volume VolumeSpec `json:"volume"`
+	// +optional
+	Volume VolumeSpec `json:"volume,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -62,6 +68,16 @@ type IopingList struct {
 	Items           []Ioping `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *Ioping) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Ioping) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
 func init() {
 	SchemeBuilder.Register(&Ioping{}, &IopingList{})
 }
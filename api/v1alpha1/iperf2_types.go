@@ -41,7 +41,8 @@ type Iperf2ConfigurationSpec struct {
 // and client pod.
 type Iperf2Spec struct {
 	// Image defines the iperf2 docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// ServerConfiguration contains the configuration of the iperf2 server
 	// +optional
@@ -61,11 +62,16 @@ type Iperf2Spec struct {
 	Log LogSpec `json:"log,omitempty"`
 
 	// Number of times in a row to run the test
-	Completions int32 `json:"completions"`
+	// +optional
+	Completions int32 `json:"completions,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -87,6 +93,21 @@ type Iperf2List struct {
 	Items           []Iperf2 `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *Iperf2) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Iperf2) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetLogSpec returns the settings of the report files
+func (cr *Iperf2) GetLogSpec() *LogSpec {
+	return &cr.Spec.Log
+}
+
 func init() {
 	SchemeBuilder.Register(&Iperf2{}, &Iperf2List{})
 }
@@ -41,7 +41,8 @@ type Iperf3ConfigurationSpec struct {
 // and client pod.
 type Iperf3Spec struct {
 	// Image defines the iperf3 docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// ServerConfiguration contains the configuration of the iperf3 server
 	// +optional
@@ -61,11 +62,16 @@ type Iperf3Spec struct {
 	Log LogSpec `json:"log,omitempty"`
 
 	// Number of times in a row to run the test
-	Completions int32 `json:"completions"`
+	// +optional
+	Completions int32 `json:"completions,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -87,6 +93,21 @@ type Iperf3List struct {
 	Items           []Iperf3 `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *Iperf3) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Iperf3) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetLogSpec returns the settings of the report files
+func (cr *Iperf3) GetLogSpec() *LogSpec {
+	return &cr.Spec.Log
+}
+
 func init() {
 	SchemeBuilder.Register(&Iperf3{}, &Iperf3List{})
 }
@@ -23,7 +23,8 @@ import (
 // KafkaBenchSpec defines the desired state of KafkaBench
 type KafkaBenchSpec struct {
 	// Image defines the kafka docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// PodConfig contains the configuration for the benchmark pod, including
 	// pod labels and scheduling policies (affinity, toleration, node selector...)
@@ -33,16 +34,22 @@ type KafkaBenchSpec struct {
 	KafkaClusterInfo `json:",inline"`
 
 	// Tests defines the tests with which to create
-	Tests []KafkaTestSpec `json:"tests"`
+	// +optional
+	Tests []KafkaTestSpec `json:"tests,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
 }
 
 // ClusterInfo to be used by the benchmark for ZooKeeper and Kafka Brokers
 type KafkaClusterInfo struct {
 	// List of ZooKeeper instances we to connect to
-	ZooKeepers []string `json:"zookeepers"`
+	// +optional
+	ZooKeepers []string `json:"zookeepers,omitempty"`
 
 	// List of Kafka Broker instances we to connect to
-	Brokers []string `json:"brokers"`
+	// +optional
+	Brokers []string `json:"brokers,omitempty"`
 }
 
 // TestSpec defines the specifications for the kafka tests
@@ -71,6 +78,7 @@ type KafkaTestSpec struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -92,6 +100,16 @@ type KafkaBenchList struct {
 	Items           []KafkaBench `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *KafkaBench) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *KafkaBench) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
 func init() {
 	SchemeBuilder.Register(&KafkaBench{}, &KafkaBenchList{})
 }
@@ -41,7 +41,8 @@ type NtttcpConfigurationSpec struct {
 // and client pod.
 type NtttcpSpec struct {
 	// Image defines the ntttcp docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// ServerConfiguration contains the configuration of the ntttcp server
 	// +optional
@@ -56,16 +57,23 @@ type NtttcpSpec struct {
 	Log LogSpec `json:"log,omitempty"`
 
 	// The port used for both the server and client
-	Port int32 `json:"port"`
+	// +optional
+	Port int32 `json:"port,omitempty"`
 
 	// The command used to check pod readiness
-	ReadinessCmd []string `json:"readinesscmd"`
+	// +optional
+	ReadinessCmd []string `json:"readinesscmd,omitempty"`
 
 	// The -m arg used to pass in session count, processor number, address
-	Mapping MappingSpec `json:"mapping"`
+	// +optional
+	Mapping MappingSpec `json:"mapping,omitempty"`
 
 	// Number of times in a row to run the test
-	Completions int `json:"completions"`
+	// +optional
+	Completions int `json:"completions,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
 }
 
 type MappingSpec struct {
@@ -74,6 +82,7 @@ type MappingSpec struct {
 }
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -95,6 +104,21 @@ type NtttcpList struct {
 	Items           []Ntttcp `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *Ntttcp) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Ntttcp) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetLogSpec returns the settings of the report files
+func (cr *Ntttcp) GetLogSpec() *LogSpec {
+	return &cr.Spec.Log
+}
+
 func init() {
 	SchemeBuilder.Register(&Ntttcp{}, &NtttcpList{})
 }
@@ -23,7 +23,8 @@ import (
 // OcpLogtestSpec defines the desired state of OcpLogtest
 type OcpLogtestSpec struct {
 	// Image defines the docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// length of each line
 	LineLength int `json:"lineLength,omitempty"`
@@ -41,10 +42,14 @@ type OcpLogtestSpec struct {
 	// pod labels and scheduling policies (affinity, toleration, node selector...)
 	// +optional
 	PodConfig PodConfigurationSpec `json:"podConfig,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -66,6 +71,16 @@ type OcpLogtestList struct {
 	Items           []OcpLogtest `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *OcpLogtest) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *OcpLogtest) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
 func init() {
 	SchemeBuilder.Register(&OcpLogtest{}, &OcpLogtestList{})
 }
@@ -17,6 +17,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,20 +36,74 @@ type PostgresSpec struct {
 	User string `json:"user"`
 
 	// Password is to be used if the server demands password authentication
-	Password string `json:"password"`
+	// +optional
+	Password string `json:"password,omitempty"`
+
+	// PasswordFrom selects the key of a Secret holding the password, so
+	// that it does not appear in the CR and in the job.
+	// Mutually exclusive with Password.
+	// +optional
+	PasswordFrom *corev1.SecretKeySelector `json:"passwordFrom,omitempty"`
 
 	// Database is name of the database
 	Database string `json:"database"`
 }
 
+// PostgresProvisionSpec describes the temporary PostgreSQL server deployed
+// as a StatefulSet for the benchmark. The credentials of the server are
+// generated and stored in a Secret.
+type PostgresProvisionSpec struct {
+	// Image defines the docker image of PostgreSQL.
+	// Defaults to postgres:11.5-alpine
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
+
+	// Config overrides the settings of postgresql.conf,
+	// e.g. shared_buffers: 1GB
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+
+	// Resources required by the PostgreSQL container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PodScheduling determines the node of the PostgreSQL server
+	// +optional
+	PodScheduling PodSchedulingSpec `json:"podScheduling,omitempty"`
+
+	// StorageClassName of the data volume. The default storage class
+	// is used if not set.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Size of the data volume
+	Size resource.Quantity `json:"size"`
+}
+
+// Validate checks that the password is given at most one way
+func (p *PostgresSpec) Validate() error {
+	if p.Password != "" && p.PasswordFrom != nil {
+		return errors.New("postgres.password and postgres.passwordFrom are mutually exclusive")
+	}
+	return nil
+}
+
 // PgbenchSpec describes a pgbench benchmark job
 type PgbenchSpec struct {
 	// Image defines the docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// Postgres contains the configuration parameters for the PostgreSQL database
-	// that will run the benchmark
-	Postgres PostgresSpec `json:"postgres"`
+	// that will run the benchmark. Required unless Provision is set.
+	// +optional
+	Postgres PostgresSpec `json:"postgres,omitempty"`
+
+	// Provision deploys a temporary PostgreSQL server for the benchmark
+	// instead of connecting to the database given in Postgres. The server
+	// is removed once the benchmark is finished.
+	// +optional
+	Provision *PostgresProvisionSpec `json:"provision,omitempty"`
 
 	// InitArgs contains the command line arguments passed to the init container
 	// +optional
@@ -59,10 +117,14 @@ type PgbenchSpec struct {
 	// pod labels and scheduling policies (affinity, toleration, node selector...)
 	// +optional
 	PodConfig PodConfigurationSpec `json:"podConfig,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -84,6 +146,16 @@ type PgbenchList struct {
 	Items           []Pgbench `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *Pgbench) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Pgbench) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
 func init() {
 	SchemeBuilder.Register(&Pgbench{}, &PgbenchList{})
 }
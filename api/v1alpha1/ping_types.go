@@ -40,7 +40,8 @@ type PingConfigurationSpec struct {
 // and client pod.
 type PingSpec struct {
 	// Image defines the qperf docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// Options are options for the ping binary
 	// +optional
@@ -53,10 +54,14 @@ type PingSpec struct {
 	// ClientConfiguration contains the configuration of the ping client
 	// +optional
 	ClientConfiguration PingConfigurationSpec `json:"clientConfiguration,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -78,6 +83,16 @@ type PingList struct {
 	Items           []Ping `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *Ping) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Ping) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
 func init() {
 	SchemeBuilder.Register(&Ping{}, &PingList{})
 }
@@ -40,14 +40,16 @@ type QperfConfigurationSpec struct {
 // and client pod.
 type QperfSpec struct {
 	// Image defines the qperf docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// Options are options for the qperf binary
 	// +optional
 	Options string `json:"options,omitempty"`
 
 	// Tests are the tests that we would like to run
-	Tests []string `json:"tests"`
+	// +optional
+	Tests []string `json:"tests,omitempty"`
 
 	// ServerConfiguration contains the configuration of the qperf server
 	// +optional
@@ -56,10 +58,14 @@ type QperfSpec struct {
 	// ClientConfiguration contains the configuration of the qperf client
 	// +optional
 	ClientConfiguration QperfConfigurationSpec `json:"clientConfiguration,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -81,6 +87,16 @@ type QperfList struct {
 	Items           []Qperf `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *Qperf) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Qperf) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
 func init() {
 	SchemeBuilder.Register(&Qperf{}, &QperfList{})
 }
@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -33,11 +36,20 @@ type S3BenchSpec struct {
 
 	// Mode defines the operating mode of the benchmark test. See https://github.com/minio/warp#mixed for option definition.
 	// Currently accepted values are: get, put, delete, mixed
-	Mode string `json:"mode"`
+	// +optional
+	Mode string `json:"mode,omitempty"`
 
 	// Host defines the host to benchmark against.
 	// Multiple hosts can be specified as a comma separated list. (default: "127.0.0.1:9000")
-	Host string `json:"host"`
+	// Required unless Provision is set.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Provision deploys a temporary MinIO server for the benchmark instead
+	// of connecting to the one given in Host. The server is removed once
+	// the benchmark is finished.
+	// +optional
+	Provision *MinioProvisionSpec `json:"provision,omitempty"`
 
 	// S3BenchOptions defines the runtime arguments for the benchmark test
 	S3BenchOptions `json:",inline"`
@@ -58,6 +70,52 @@ type S3BenchSpec struct {
 	// Will only be used in "mixed" mode.
 	// +optional
 	MixedDistributionOptions MixedDistributionOptions `json:"mixedDist,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
+}
+
+// MinioProvisionSpec describes the temporary MinIO server deployed as a
+// StatefulSet for the benchmark. The credentials of the server are
+// generated and stored in a Secret, and the bucket is created before
+// the benchmark.
+type MinioProvisionSpec struct {
+	// Image defines the docker image of MinIO.
+	// Defaults to minio/minio:RELEASE.2019-10-12T01-39-57Z
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
+
+	// ClientImage defines the docker image of the MinIO client
+	// creating the bucket. Defaults to minio/mc:RELEASE.2019-10-09T22-54-57Z
+	// +optional
+	ClientImage ImageSpec `json:"clientImage,omitempty"`
+
+	// Servers is the number of MinIO servers, each with a single drive.
+	// A single server is started by default. Distributed MinIO with
+	// erasure coding requires 4 to 16 servers.
+	// +optional
+	Servers int32 `json:"servers,omitempty"`
+
+	// Resources required by the MinIO containers
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PodScheduling determines the nodes of the MinIO servers
+	// +optional
+	PodScheduling PodSchedulingSpec `json:"podScheduling,omitempty"`
+
+	// PersistentVolumeClaimSpec describes the data volume of each server.
+	// The data is stored in an emptyDir on the node if not set.
+	// +optional
+	PersistentVolumeClaimSpec *corev1.PersistentVolumeClaimSpec `json:"persistentVolumeClaimSpec,omitempty"`
+}
+
+// Validate checks the number of the servers
+func (p *MinioProvisionSpec) Validate() error {
+	if p.Servers < 0 || (p.Servers > 1 && p.Servers < 4) || p.Servers > 16 {
+		return errors.New("provision.servers must be 1 or between 4 and 16")
+	}
+	return nil
 }
 
 // S3BenchOptions defines the runtime arguments for the Warp cli
@@ -74,12 +132,24 @@ type S3BenchOptions struct {
 	// +optional
 	Insecure bool `json:"insecure,omitempty"`
 
+	// AccessKey is the access key of the S3 service
 	// +optional
 	AccessKey string `json:"accessKey,omitempty"`
 
+	// AccessKeyFrom selects the key of a Secret holding the access key.
+	// Mutually exclusive with AccessKey.
+	// +optional
+	AccessKeyFrom *corev1.SecretKeySelector `json:"accessKeyFrom,omitempty"`
+
+	// SecretKey is the secret key of the S3 service
 	// +optional
 	SecretKey string `json:"secretKey,omitempty"`
 
+	// SecretKeyFrom selects the key of a Secret holding the secret key.
+	// Mutually exclusive with SecretKey.
+	// +optional
+	SecretKeyFrom *corev1.SecretKeySelector `json:"secretKeyFrom,omitempty"`
+
 	// Tls defines if to use TLS (HTTPS) for transport (default: false)
 	// +optional
 	Tls bool `json:"tls,omitempty"`
@@ -133,6 +203,17 @@ type S3BenchOptions struct {
 	Requests bool `json:"requests,omitempty"`
 }
 
+// Validate checks that the credentials are given at most one way
+func (o *S3BenchOptions) Validate() error {
+	if o.AccessKey != "" && o.AccessKeyFrom != nil {
+		return errors.New("accessKey and accessKeyFrom are mutually exclusive")
+	}
+	if o.SecretKey != "" && o.SecretKeyFrom != nil {
+		return errors.New("secretKey and secretKeyFrom are mutually exclusive")
+	}
+	return nil
+}
+
 // S3ObjectOptions defines options for the objects generated by the benchmark
 type S3ObjectOptions struct {
 	// Count defines the number of objects to upload. (default: 2500)
@@ -213,6 +294,7 @@ type S3AutoTermOptions struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -234,6 +316,16 @@ type S3BenchList struct {
 	Items           []S3Bench `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *S3Bench) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *S3Bench) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
 func init() {
 	SchemeBuilder.Register(&S3Bench{}, &S3BenchList{})
 }
@@ -26,7 +26,8 @@ import (
 // to the sysbench benchmarking application.
 type SysbenchSpec struct {
 	// Image defines the sysbench docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// PodConfig contains the configuration for the benchmark pod, including
 	// pod labels and scheduling policies (affinity, toleration, node selector...)
@@ -39,17 +40,22 @@ type SysbenchSpec struct {
 
 	// TestName is the name of a built-in test (e.g. `fileio`, `memory`, `cpu`, etc.), or a name of one of the bundled
 	// Lua scripts (e.g. `oltp_read_only`), or a path to a custom Lua script.
-	TestName string `json:"testName"`
+	// +optional
+	TestName string `json:"testName,omitempty"`
 
 	// Command is an optional argument that will be passed by sysbench to the built-in test or script specified with
 	// TestName. Command defines the action that must be performed by the test. The list of available commands depends
 	// on a particular test. Some tests also implement their own custom commands.
 	// +optional
 	Command string `json:"command,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -71,6 +77,16 @@ type SysbenchList struct {
 	Items           []Sysbench `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *Sysbench) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Sysbench) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
 func init() {
 	SchemeBuilder.Register(&Sysbench{}, &SysbenchList{})
 }
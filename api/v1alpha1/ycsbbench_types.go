@@ -17,24 +17,52 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // YcsbBenchSpec defines the desired state of YcsbBench
 type YcsbBenchSpec struct {
 	// Image defines the docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
+
+	// +optional
+	Database string `json:"database,omitempty"`
+	// +optional
+	Workload string `json:"workload,omitempty"`
+	// +optional
+	Options YcsbBenchOptions `json:"options,omitempty"`
+
+	// Properties are passed to YCSB as -p name=value
+	// +optional
+	Properties map[string]string `json:"properties,omitempty"`
 
-	Database string `json:"database"`
-	Workload string `json:"workload"`
+	// PropertiesFrom are passed to YCSB like the Properties, with their
+	// values read from Secrets, e.g. the password of the database. A
+	// property can not be given in both Properties and PropertiesFrom.
 	// +optional
-	Options    YcsbBenchOptions  `json:"options,omitempty"`
-	Properties map[string]string `json:"properties"`
+	PropertiesFrom map[string]corev1.SecretKeySelector `json:"propertiesFrom,omitempty"`
 
 	// PodConfig contains the configuration for the benchmark pod, including
 	// pod labels and scheduling policies (affinity, toleration, node selector...)
 	// +optional
 	PodConfig PodConfigurationSpec `json:"podConfig,omitempty"`
+
+	// RunPolicySpec contains the lifecycle settings of the benchmark
+	RunPolicySpec `json:",inline"`
+}
+
+// ValidateProperties checks that every property is given at most one way
+func (s *YcsbBenchSpec) ValidateProperties() error {
+	for name := range s.PropertiesFrom {
+		if _, ok := s.Properties[name]; ok {
+			return fmt.Errorf("property %v is set in both properties and propertiesFrom", name)
+		}
+	}
+	return nil
 }
 
 type YcsbBenchOptions struct {
@@ -44,6 +72,7 @@ type YcsbBenchOptions struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
@@ -65,6 +94,16 @@ type YcsbBenchList struct {
 	Items           []YcsbBench `json:"items"`
 }
 
+// GetRunPolicy returns the lifecycle settings of the benchmark
+func (cr *YcsbBench) GetRunPolicy() *RunPolicySpec {
+	return &cr.Spec.RunPolicySpec
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *YcsbBench) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
 func init() {
 	SchemeBuilder.Register(&YcsbBench{}, &YcsbBenchList{})
 }
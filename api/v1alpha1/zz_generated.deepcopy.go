@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 /*
@@ -22,12 +23,329 @@ package v1alpha1
 
 import (
 	"k8s.io/api/core/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArchiveSpec) DeepCopyInto(out *ArchiveSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArchiveSpec.
+func (in *ArchiveSpec) DeepCopy() *ArchiveSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArchiveSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArchiveStatus) DeepCopyInto(out *ArchiveStatus) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArchiveStatus.
+func (in *ArchiveStatus) DeepCopy() *ArchiveStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArchiveStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkCondition) DeepCopyInto(out *BenchmarkCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkCondition.
+func (in *BenchmarkCondition) DeepCopy() *BenchmarkCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkMetric) DeepCopyInto(out *BenchmarkMetric) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkMetric.
+func (in *BenchmarkMetric) DeepCopy() *BenchmarkMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkProfile) DeepCopyInto(out *BenchmarkProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkProfile.
+func (in *BenchmarkProfile) DeepCopy() *BenchmarkProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BenchmarkProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkProfileList) DeepCopyInto(out *BenchmarkProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BenchmarkProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkProfileList.
+func (in *BenchmarkProfileList) DeepCopy() *BenchmarkProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BenchmarkProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkProfileSpec) DeepCopyInto(out *BenchmarkProfileSpec) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkProfileSpec.
+func (in *BenchmarkProfileSpec) DeepCopy() *BenchmarkProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkReference) DeepCopyInto(out *BenchmarkReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkReference.
+func (in *BenchmarkReference) DeepCopy() *BenchmarkReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkResult) DeepCopyInto(out *BenchmarkResult) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkResult.
+func (in *BenchmarkResult) DeepCopy() *BenchmarkResult {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BenchmarkResult) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkResultList) DeepCopyInto(out *BenchmarkResultList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BenchmarkResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkResultList.
+func (in *BenchmarkResultList) DeepCopy() *BenchmarkResultList {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkResultList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BenchmarkResultList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkResultSpec) DeepCopyInto(out *BenchmarkResultSpec) {
+	*out = *in
+	out.Benchmark = in.Benchmark
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]BenchmarkMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Aggregates != nil {
+		in, out := &in.Aggregates, &out.Aggregates
+		*out = make([]MetricAggregate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Environment.DeepCopyInto(&out.Environment)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkResultSpec.
+func (in *BenchmarkResultSpec) DeepCopy() *BenchmarkResultSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkResultSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BenchmarkStatus) DeepCopyInto(out *BenchmarkStatus) {
 	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NodeNames != nil {
+		in, out := &in.NodeNames, &out.NodeNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]BenchmarkMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Aggregates != nil {
+		in, out := &in.Aggregates, &out.Aggregates
+		*out = make([]MetricAggregate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Export != nil {
+		in, out := &in.Export, &out.Export
+		*out = new(ExportStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(ArchiveStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]BenchmarkCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Comparisons != nil {
+		in, out := &in.Comparisons, &out.Comparisons
+		*out = make([]MetricComparison, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(ProfileReference)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(DryRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkStatus.
@@ -46,7 +364,7 @@ func (in *Drill) DeepCopyInto(out *Drill) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Drill.
@@ -60,7 +378,174 @@ func (in *Drill) DeepCopy() *Drill {
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Drill) DeepCopyObject() runtime.Object {
+func (in *Drill) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrillList) DeepCopyInto(out *DrillList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Drill, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrillList.
+func (in *DrillList) DeepCopy() *DrillList {
+	if in == nil {
+		return nil
+	}
+	out := new(DrillList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DrillList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrillSpec) DeepCopyInto(out *DrillSpec) {
+	*out = *in
+	out.Image = in.Image
+	if in.BenchmarksVolume != nil {
+		in, out := &in.BenchmarksVolume, &out.BenchmarksVolume
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.PodConfig.DeepCopyInto(&out.PodConfig)
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Log = in.Log
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrillSpec.
+func (in *DrillSpec) DeepCopy() *DrillSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DrillSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunObject) DeepCopyInto(out *DryRunObject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunObject.
+func (in *DryRunObject) DeepCopy() *DryRunObject {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunStatus) DeepCopyInto(out *DryRunStatus) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]DryRunObject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunStatus.
+func (in *DryRunStatus) DeepCopy() *DryRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentSpec) DeepCopyInto(out *EnvironmentSpec) {
+	*out = *in
+	if in.NodeNames != nil {
+		in, out := &in.NodeNames, &out.NodeNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeEnvironment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageEnvironment, len(*in))
+		copy(*out, *in)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]VolumeEnvironment, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentSpec.
+func (in *EnvironmentSpec) DeepCopy() *EnvironmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ethr) DeepCopyInto(out *Ethr) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ethr.
+func (in *Ethr) DeepCopy() *Ethr {
+	if in == nil {
+		return nil
+	}
+	out := new(Ethr)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Ethr) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -68,31 +553,47 @@ func (in *Drill) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DrillList) DeepCopyInto(out *DrillList) {
+func (in *EthrConfigurationSpec) DeepCopyInto(out *EthrConfigurationSpec) {
+	*out = *in
+	in.PodConfigurationSpec.DeepCopyInto(&out.PodConfigurationSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EthrConfigurationSpec.
+func (in *EthrConfigurationSpec) DeepCopy() *EthrConfigurationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EthrConfigurationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EthrList) DeepCopyInto(out *EthrList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Drill, len(*in))
+		*out = make([]Ethr, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrillList.
-func (in *DrillList) DeepCopy() *DrillList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EthrList.
+func (in *EthrList) DeepCopy() *EthrList {
 	if in == nil {
 		return nil
 	}
-	out := new(DrillList)
+	out := new(EthrList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DrillList) DeepCopyObject() runtime.Object {
+func (in *EthrList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -100,25 +601,55 @@ func (in *DrillList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DrillSpec) DeepCopyInto(out *DrillSpec) {
+func (in *EthrSpec) DeepCopyInto(out *EthrSpec) {
 	*out = *in
 	out.Image = in.Image
-	if in.BenchmarksVolume != nil {
-		in, out := &in.BenchmarksVolume, &out.BenchmarksVolume
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	in.ServerConfiguration.DeepCopyInto(&out.ServerConfiguration)
+	in.ClientConfiguration.DeepCopyInto(&out.ClientConfiguration)
+	out.Log = in.Log
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EthrSpec.
+func (in *EthrSpec) DeepCopy() *EthrSpec {
+	if in == nil {
+		return nil
 	}
-	in.PodConfig.DeepCopyInto(&out.PodConfig)
+	out := new(EthrSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrillSpec.
-func (in *DrillSpec) DeepCopy() *DrillSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExclusivitySpec) DeepCopyInto(out *ExclusivitySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExclusivitySpec.
+func (in *ExclusivitySpec) DeepCopy() *ExclusivitySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DrillSpec)
+	out := new(ExclusivitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportStatus) DeepCopyInto(out *ExportStatus) {
+	*out = *in
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportStatus.
+func (in *ExportStatus) DeepCopy() *ExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -129,7 +660,7 @@ func (in *Fio) DeepCopyInto(out *Fio) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fio.
@@ -198,6 +729,7 @@ func (in *FioSpec) DeepCopyInto(out *FioSpec) {
 	}
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
 	in.Volume.DeepCopyInto(&out.Volume)
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioSpec.
@@ -210,6 +742,21 @@ func (in *FioSpec) DeepCopy() *FioSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageEnvironment) DeepCopyInto(out *ImageEnvironment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageEnvironment.
+func (in *ImageEnvironment) DeepCopy() *ImageEnvironment {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageEnvironment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 	*out = *in
@@ -231,7 +778,7 @@ func (in *Ioping) DeepCopyInto(out *Ioping) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ioping.
@@ -290,6 +837,7 @@ func (in *IopingSpec) DeepCopyInto(out *IopingSpec) {
 	out.Image = in.Image
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
 	in.Volume.DeepCopyInto(&out.Volume)
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IopingSpec.
@@ -308,7 +856,7 @@ func (in *Iperf2) DeepCopyInto(out *Iperf2) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iperf2.
@@ -383,6 +931,8 @@ func (in *Iperf2Spec) DeepCopyInto(out *Iperf2Spec) {
 	out.Image = in.Image
 	in.ServerConfiguration.DeepCopyInto(&out.ServerConfiguration)
 	in.ClientConfiguration.DeepCopyInto(&out.ClientConfiguration)
+	out.Log = in.Log
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iperf2Spec.
@@ -401,7 +951,7 @@ func (in *Iperf3) DeepCopyInto(out *Iperf3) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iperf3.
@@ -476,6 +1026,8 @@ func (in *Iperf3Spec) DeepCopyInto(out *Iperf3Spec) {
 	out.Image = in.Image
 	in.ServerConfiguration.DeepCopyInto(&out.ServerConfiguration)
 	in.ClientConfiguration.DeepCopyInto(&out.ClientConfiguration)
+	out.Log = in.Log
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iperf3Spec.
@@ -494,7 +1046,7 @@ func (in *KafkaBench) DeepCopyInto(out *KafkaBench) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBench.
@@ -560,6 +1112,7 @@ func (in *KafkaBenchSpec) DeepCopyInto(out *KafkaBenchSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBenchSpec.
@@ -598,46 +1151,308 @@ func (in *KafkaClusterInfo) DeepCopy() *KafkaClusterInfo {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KafkaTestSpec) DeepCopyInto(out *KafkaTestSpec) {
+func (in *KafkaTestSpec) DeepCopyInto(out *KafkaTestSpec) {
+	*out = *in
+	if in.ConsumerSleep != nil {
+		in, out := &in.ConsumerSleep, &out.ConsumerSleep
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.ExtraProducerOpts != nil {
+		in, out := &in.ExtraProducerOpts, &out.ExtraProducerOpts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTestSpec.
+func (in *KafkaTestSpec) DeepCopy() *KafkaTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSpec) DeepCopyInto(out *LogSpec) {
+	*out = *in
+	out.Volume = in.Volume
+	out.VolumeMount = in.VolumeMount
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSpec.
+func (in *LogSpec) DeepCopy() *LogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MappingSpec) DeepCopyInto(out *MappingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MappingSpec.
+func (in *MappingSpec) DeepCopy() *MappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricAggregate) DeepCopyInto(out *MetricAggregate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Outliers != nil {
+		in, out := &in.Outliers, &out.Outliers
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricAggregate.
+func (in *MetricAggregate) DeepCopy() *MetricAggregate {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricAggregate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricComparison) DeepCopyInto(out *MetricComparison) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricComparison.
+func (in *MetricComparison) DeepCopy() *MetricComparison {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricComparison)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricThreshold) DeepCopyInto(out *MetricThreshold) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricThreshold.
+func (in *MetricThreshold) DeepCopy() *MetricThreshold {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricThreshold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MinioProvisionSpec) DeepCopyInto(out *MinioProvisionSpec) {
+	*out = *in
+	out.Image = in.Image
+	out.ClientImage = in.ClientImage
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.PodScheduling.DeepCopyInto(&out.PodScheduling)
+	if in.PersistentVolumeClaimSpec != nil {
+		in, out := &in.PersistentVolumeClaimSpec, &out.PersistentVolumeClaimSpec
+		*out = new(v1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinioProvisionSpec.
+func (in *MinioProvisionSpec) DeepCopy() *MinioProvisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MinioProvisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MixedDistributionOptions) DeepCopyInto(out *MixedDistributionOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MixedDistributionOptions.
+func (in *MixedDistributionOptions) DeepCopy() *MixedDistributionOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MixedDistributionOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeEnvironment) DeepCopyInto(out *NodeEnvironment) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Allocatable != nil {
+		in, out := &in.Allocatable, &out.Allocatable
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeEnvironment.
+func (in *NodeEnvironment) DeepCopy() *NodeEnvironment {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeEnvironment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ntttcp) DeepCopyInto(out *Ntttcp) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ntttcp.
+func (in *Ntttcp) DeepCopy() *Ntttcp {
+	if in == nil {
+		return nil
+	}
+	out := new(Ntttcp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Ntttcp) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NtttcpConfigurationSpec) DeepCopyInto(out *NtttcpConfigurationSpec) {
+	*out = *in
+	in.PodConfigurationSpec.DeepCopyInto(&out.PodConfigurationSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NtttcpConfigurationSpec.
+func (in *NtttcpConfigurationSpec) DeepCopy() *NtttcpConfigurationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NtttcpConfigurationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NtttcpList) DeepCopyInto(out *NtttcpList) {
 	*out = *in
-	if in.ConsumerSleep != nil {
-		in, out := &in.ConsumerSleep, &out.ConsumerSleep
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Timeout != nil {
-		in, out := &in.Timeout, &out.Timeout
-		*out = new(int)
-		**out = **in
-	}
-	if in.ExtraProducerOpts != nil {
-		in, out := &in.ExtraProducerOpts, &out.ExtraProducerOpts
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Ntttcp, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTestSpec.
-func (in *KafkaTestSpec) DeepCopy() *KafkaTestSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NtttcpList.
+func (in *NtttcpList) DeepCopy() *NtttcpList {
 	if in == nil {
 		return nil
 	}
-	out := new(KafkaTestSpec)
+	out := new(NtttcpList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NtttcpList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MixedDistributionOptions) DeepCopyInto(out *MixedDistributionOptions) {
+func (in *NtttcpSpec) DeepCopyInto(out *NtttcpSpec) {
 	*out = *in
+	out.Image = in.Image
+	in.ServerConfiguration.DeepCopyInto(&out.ServerConfiguration)
+	in.ClientConfiguration.DeepCopyInto(&out.ClientConfiguration)
+	out.Log = in.Log
+	if in.ReadinessCmd != nil {
+		in, out := &in.ReadinessCmd, &out.ReadinessCmd
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Mapping = in.Mapping
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MixedDistributionOptions.
-func (in *MixedDistributionOptions) DeepCopy() *MixedDistributionOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NtttcpSpec.
+func (in *NtttcpSpec) DeepCopy() *NtttcpSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MixedDistributionOptions)
+	out := new(NtttcpSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -648,7 +1463,7 @@ func (in *OcpLogtest) DeepCopyInto(out *OcpLogtest) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OcpLogtest.
@@ -706,6 +1521,7 @@ func (in *OcpLogtestSpec) DeepCopyInto(out *OcpLogtestSpec) {
 	*out = *in
 	out.Image = in.Image
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OcpLogtestSpec.
@@ -724,7 +1540,7 @@ func (in *Pgbench) DeepCopyInto(out *Pgbench) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pgbench.
@@ -781,8 +1597,14 @@ func (in *PgbenchList) DeepCopyObject() runtime.Object {
 func (in *PgbenchSpec) DeepCopyInto(out *PgbenchSpec) {
 	*out = *in
 	out.Image = in.Image
-	out.Postgres = in.Postgres
+	in.Postgres.DeepCopyInto(&out.Postgres)
+	if in.Provision != nil {
+		in, out := &in.Provision, &out.Provision
+		*out = new(PostgresProvisionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgbenchSpec.
@@ -795,6 +1617,100 @@ func (in *PgbenchSpec) DeepCopy() *PgbenchSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ping) DeepCopyInto(out *Ping) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ping.
+func (in *Ping) DeepCopy() *Ping {
+	if in == nil {
+		return nil
+	}
+	out := new(Ping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Ping) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingConfigurationSpec) DeepCopyInto(out *PingConfigurationSpec) {
+	*out = *in
+	in.PodConfigurationSpec.DeepCopyInto(&out.PodConfigurationSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingConfigurationSpec.
+func (in *PingConfigurationSpec) DeepCopy() *PingConfigurationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingConfigurationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingList) DeepCopyInto(out *PingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Ping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingList.
+func (in *PingList) DeepCopy() *PingList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingSpec) DeepCopyInto(out *PingSpec) {
+	*out = *in
+	out.Image = in.Image
+	in.ServerConfiguration.DeepCopyInto(&out.ServerConfiguration)
+	in.ClientConfiguration.DeepCopyInto(&out.ClientConfiguration)
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingSpec.
+func (in *PingSpec) DeepCopy() *PingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodConfigurationSpec) DeepCopyInto(out *PodConfigurationSpec) {
 	*out = *in
@@ -860,9 +1776,45 @@ func (in *PodSchedulingSpec) DeepCopy() *PodSchedulingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresProvisionSpec) DeepCopyInto(out *PostgresProvisionSpec) {
+	*out = *in
+	out.Image = in.Image
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.PodScheduling.DeepCopyInto(&out.PodScheduling)
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresProvisionSpec.
+func (in *PostgresProvisionSpec) DeepCopy() *PostgresProvisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresProvisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresSpec) DeepCopyInto(out *PostgresSpec) {
 	*out = *in
+	if in.PasswordFrom != nil {
+		in, out := &in.PasswordFrom, &out.PasswordFrom
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresSpec.
@@ -875,13 +1827,28 @@ func (in *PostgresSpec) DeepCopy() *PostgresSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileReference) DeepCopyInto(out *ProfileReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileReference.
+func (in *ProfileReference) DeepCopy() *ProfileReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Qperf) DeepCopyInto(out *Qperf) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Qperf.
@@ -961,6 +1928,7 @@ func (in *QperfSpec) DeepCopyInto(out *QperfSpec) {
 	}
 	in.ServerConfiguration.DeepCopyInto(&out.ServerConfiguration)
 	in.ClientConfiguration.DeepCopyInto(&out.ClientConfiguration)
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QperfSpec.
@@ -973,6 +1941,80 @@ func (in *QperfSpec) DeepCopy() *QperfSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegressionSpec) DeepCopyInto(out *RegressionSpec) {
+	*out = *in
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make([]MetricThreshold, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegressionSpec.
+func (in *RegressionSpec) DeepCopy() *RegressionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RegressionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunPolicySpec) DeepCopyInto(out *RunPolicySpec) {
+	*out = *in
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Exclusivity != nil {
+		in, out := &in.Exclusivity, &out.Exclusivity
+		*out = new(ExclusivitySpec)
+		**out = **in
+	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(ArchiveSpec)
+		**out = **in
+	}
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]WebhookSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Regression != nil {
+		in, out := &in.Regression, &out.Regression
+		*out = new(RegressionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = new(VariablesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunPolicySpec.
+func (in *RunPolicySpec) DeepCopy() *RunPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RunPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3AnalysisOptions) DeepCopyInto(out *S3AnalysisOptions) {
 	*out = *in
@@ -1009,7 +2051,7 @@ func (in *S3Bench) DeepCopyInto(out *S3Bench) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Bench.
@@ -1065,6 +2107,16 @@ func (in *S3BenchList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3BenchOptions) DeepCopyInto(out *S3BenchOptions) {
 	*out = *in
+	if in.AccessKeyFrom != nil {
+		in, out := &in.AccessKeyFrom, &out.AccessKeyFrom
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyFrom != nil {
+		in, out := &in.SecretKeyFrom, &out.SecretKeyFrom
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BenchOptions.
@@ -1082,11 +2134,17 @@ func (in *S3BenchSpec) DeepCopyInto(out *S3BenchSpec) {
 	*out = *in
 	out.Image = in.Image
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
-	out.S3BenchOptions = in.S3BenchOptions
+	if in.Provision != nil {
+		in, out := &in.Provision, &out.Provision
+		*out = new(MinioProvisionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.S3BenchOptions.DeepCopyInto(&out.S3BenchOptions)
 	out.S3ObjectOptions = in.S3ObjectOptions
 	out.S3AutoTermOptions = in.S3AutoTermOptions
 	out.S3AnalysisOptions = in.S3AnalysisOptions
 	out.MixedDistributionOptions = in.MixedDistributionOptions
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BenchSpec.
@@ -1120,7 +2178,7 @@ func (in *Sysbench) DeepCopyInto(out *Sysbench) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sysbench.
@@ -1178,6 +2236,7 @@ func (in *SysbenchSpec) DeepCopyInto(out *SysbenchSpec) {
 	*out = *in
 	out.Image = in.Image
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SysbenchSpec.
@@ -1190,6 +2249,58 @@ func (in *SysbenchSpec) DeepCopy() *SysbenchSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VariablesSpec) DeepCopyInto(out *VariablesSpec) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VariablesSpec.
+func (in *VariablesSpec) DeepCopy() *VariablesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VariablesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeEnvironment) DeepCopyInto(out *VolumeEnvironment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeEnvironment.
+func (in *VolumeEnvironment) DeepCopy() *VolumeEnvironment {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeEnvironment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeInfo) DeepCopyInto(out *VolumeInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeInfo.
+func (in *VolumeInfo) DeepCopy() *VolumeInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSpec) DeepCopyInto(out *VolumeSpec) {
 	*out = *in
@@ -1211,13 +2322,38 @@ func (in *VolumeSpec) DeepCopy() *VolumeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookSpec) DeepCopyInto(out *WebhookSpec) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]WebhookEvent, len(*in))
+		copy(*out, *in)
+	}
+	if in.SigningSecret != nil {
+		in, out := &in.SigningSecret, &out.SigningSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookSpec.
+func (in *WebhookSpec) DeepCopy() *WebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *YcsbBench) DeepCopyInto(out *YcsbBench) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YcsbBench.
@@ -1297,7 +2433,15 @@ func (in *YcsbBenchSpec) DeepCopyInto(out *YcsbBenchSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.PropertiesFrom != nil {
+		in, out := &in.PropertiesFrom, &out.PropertiesFrom
+		*out = make(map[string]v1.SecretKeySelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
+	in.RunPolicySpec.DeepCopyInto(&out.RunPolicySpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YcsbBenchSpec.
@@ -0,0 +1,145 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// BenchmarkCommon contains the settings shared by every benchmark kind:
+// the image and the pod of the benchmark tool and the lifecycle of the
+// benchmark. It is inlined into the specs.
+type BenchmarkCommon struct {
+	// Image defines the docker image of the benchmark tool. It is required
+	// by every kind except S3Bench, which defaults to the image of warp.
+	// +optional
+	Image perfv1alpha1.ImageSpec `json:"image,omitempty"`
+
+	// PodConfig contains the configuration of the benchmark pod, including
+	// pod labels and scheduling policies (affinity, toleration, node selector...).
+	// The network benchmarks configure their server pod separately.
+	// +optional
+	PodConfig perfv1alpha1.PodConfigurationSpec `json:"podConfig,omitempty"`
+
+	// TimeoutSeconds limits the run time of the jobs of the benchmark.
+	// Jobs running longer are terminated and the benchmark fails.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+
+	// Iterations runs the benchmark repeatedly and aggregates the results
+	// +optional
+	Iterations *IterationsSpec `json:"iterations,omitempty"`
+
+	// Results configures the processing of the results
+	// +optional
+	Results *ResultsSpec `json:"results,omitempty"`
+
+	// Cleanup configures the removal of the finished benchmark
+	// +optional
+	Cleanup *CleanupSpec `json:"cleanup,omitempty"`
+
+	// Suspend postpones the start of the benchmark while set to true.
+	// It has no effect on benchmarks which are already running, those
+	// can be stopped with Cancel.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Cancel aborts the benchmark: the objects created for it are deleted
+	// and the benchmark is moved to the Cancelled terminal state
+	// +optional
+	Cancel bool `json:"cancel,omitempty"`
+
+	// Exclusivity makes the benchmark wait until the earlier benchmarks
+	// with the same exclusivity scope are finished
+	// +optional
+	Exclusivity *perfv1alpha1.ExclusivitySpec `json:"exclusivity,omitempty"`
+
+	// Profile is the name of the BenchmarkProfile providing the defaults
+	// of the spec. Profiles are written in the v1alpha1 format.
+	// +optional
+	Profile string `json:"profile,omitempty"`
+
+	// Variables provide the values of the {{ .Vars.<name> }} references
+	// in the string fields of the spec
+	// +optional
+	Variables *perfv1alpha1.VariablesSpec `json:"variables,omitempty"`
+
+	// DryRun submits the objects of the benchmark with server-side
+	// dry-run instead of running the benchmark
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// IterationsSpec describes the repeated runs of the benchmark pod
+type IterationsSpec struct {
+	// Count is the number of iterations whose results are aggregated.
+	// Overrides the completions of the benchmarks having them.
+	// +kubebuilder:validation:Minimum=1
+	Count int32 `json:"count"`
+
+	// Warmup is the number of iterations run before the measured ones,
+	// their results are discarded
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Warmup int32 `json:"warmup,omitempty"`
+}
+
+// ResultsSpec describes what happens with the results of the benchmark
+type ResultsSpec struct {
+	// Archive uploads the raw output of the finished benchmark to an
+	// S3 compatible bucket
+	// +optional
+	Archive *perfv1alpha1.ArchiveSpec `json:"archive,omitempty"`
+
+	// Regression compares the results to a baseline and sets the Passed
+	// or the Regressed condition accordingly
+	// +optional
+	Regression *perfv1alpha1.RegressionSpec `json:"regression,omitempty"`
+
+	// Webhooks are notified about the lifecycle events of the benchmark.
+	// When set, they replace the global webhooks of the manager. Their
+	// hosts must be allowed by the --benchmark-webhook-hosts of the manager.
+	// +optional
+	Webhooks []perfv1alpha1.WebhookSpec `json:"webhooks,omitempty"`
+}
+
+// CleanupSpec describes the removal of the finished benchmark
+type CleanupSpec struct {
+	// TTLSecondsAfterFinished limits the lifetime of a finished benchmark.
+	// Once the TTL expires the benchmark is deleted together with the
+	// objects created for it. Defaults to the setting of the manager.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// ServerSpec configures the server pod of the network benchmarks
+type ServerSpec struct {
+	// PodConfig contains the configuration of the server pod
+	// +optional
+	PodConfig perfv1alpha1.PodConfigurationSpec `json:"podConfig,omitempty"`
+
+	// Args are the command line arguments of the server.
+	// Not supported by Ping and Qperf.
+	// +optional
+	Args string `json:"args,omitempty"`
+
+	// HostNetwork runs the server in the network namespace of its node
+	// +optional
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// The v1alpha1 version is the hub of the conversions: it is the storage
+// version and the controllers work with it. The kinds of this version
+// implement conversion.Convertible in their <kind>_conversion.go files.
+
+// convertTo fills the settings of the v1alpha1 spec from the common part
+// of the v1beta1 spec
+func (c *BenchmarkCommon) convertTo(image *perfv1alpha1.ImageSpec,
+	podConfig *perfv1alpha1.PodConfigurationSpec, runPolicy *perfv1alpha1.RunPolicySpec) {
+	*image = c.Image
+	*podConfig = c.PodConfig
+	*runPolicy = perfv1alpha1.RunPolicySpec{
+		TimeoutSeconds: c.TimeoutSeconds,
+		Suspend:        c.Suspend,
+		Cancel:         c.Cancel,
+		Exclusivity:    c.Exclusivity,
+		Profile:        c.Profile,
+		Variables:      c.Variables,
+		DryRun:         c.DryRun,
+	}
+	if c.Iterations != nil {
+		runPolicy.Iterations = c.Iterations.Count
+		runPolicy.WarmupIterations = c.Iterations.Warmup
+	}
+	if c.Results != nil {
+		runPolicy.Archive = c.Results.Archive
+		runPolicy.Regression = c.Results.Regression
+		runPolicy.Webhooks = c.Results.Webhooks
+	}
+	if c.Cleanup != nil {
+		runPolicy.TTLSecondsAfterFinished = c.Cleanup.TTLSecondsAfterFinished
+	}
+}
+
+// convertFrom fills the common part of the v1beta1 spec from the settings
+// of the v1alpha1 spec. The optional groups are only created when one of
+// their fields is set, so the conversion round trips.
+func (c *BenchmarkCommon) convertFrom(image *perfv1alpha1.ImageSpec,
+	podConfig *perfv1alpha1.PodConfigurationSpec, runPolicy *perfv1alpha1.RunPolicySpec) {
+	*c = BenchmarkCommon{
+		Image:          *image,
+		PodConfig:      *podConfig,
+		TimeoutSeconds: runPolicy.TimeoutSeconds,
+		Suspend:        runPolicy.Suspend,
+		Cancel:         runPolicy.Cancel,
+		Exclusivity:    runPolicy.Exclusivity,
+		Profile:        runPolicy.Profile,
+		Variables:      runPolicy.Variables,
+		DryRun:         runPolicy.DryRun,
+	}
+	if runPolicy.Iterations != 0 || runPolicy.WarmupIterations != 0 {
+		c.Iterations = &IterationsSpec{Count: runPolicy.Iterations, Warmup: runPolicy.WarmupIterations}
+	}
+	if runPolicy.Archive != nil || runPolicy.Regression != nil || runPolicy.Webhooks != nil {
+		c.Results = &ResultsSpec{
+			Archive:    runPolicy.Archive,
+			Regression: runPolicy.Regression,
+			Webhooks:   runPolicy.Webhooks,
+		}
+	}
+	if runPolicy.TTLSecondsAfterFinished != nil {
+		c.Cleanup = &CleanupSpec{TTLSecondsAfterFinished: runPolicy.TTLSecondsAfterFinished}
+	}
+}
+
+// convertMetaTo returns the metadata of the v1alpha1 version, marked with
+// perfv1alpha1.ConvertedFromAnnotation. The annotations are copied, so
+// the metadata of the converted object is not modified.
+func convertMetaTo(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	annotations := map[string]string{}
+	for key, value := range meta.Annotations {
+		annotations[key] = value
+	}
+	annotations[perfv1alpha1.ConvertedFromAnnotation] = GroupVersion.Version
+	meta.Annotations = annotations
+	return meta
+}
+
+// convertMetaFrom returns the metadata of the v1beta1 version, without
+// the mark of convertMetaTo
+func convertMetaFrom(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	if _, found := meta.Annotations[perfv1alpha1.ConvertedFromAnnotation]; !found {
+		return meta
+	}
+	var annotations map[string]string
+	for key, value := range meta.Annotations {
+		if key == perfv1alpha1.ConvertedFromAnnotation {
+			continue
+		}
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[key] = value
+	}
+	meta.Annotations = annotations
+	return meta
+}
+
+// errServerArgs is returned by the kinds whose server has no arguments
+func errServerArgs(kind string) error {
+	return fmt.Errorf("spec.server.args is not supported by %v", kind)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// roundTrip converts the hub to the spoke and back. The result is marked
+// as converted, apart from that it equals to the hub.
+func roundTrip(hub conversion.Hub, spoke conversion.Convertible, result conversion.Hub) {
+	Expect(spoke.ConvertFrom(hub)).To(Succeed())
+	Expect(spoke.ConvertTo(result)).To(Succeed())
+	Expect(result.(metav1.Object).GetAnnotations()).To(Equal(
+		map[string]string{perfv1alpha1.ConvertedFromAnnotation: "v1beta1"}))
+	result.(metav1.Object).SetAnnotations(nil)
+	Expect(result).To(Equal(hub))
+}
+
+var _ = Describe("Conversion", func() {
+	ttl := int32(60)
+	timeout := int64(600)
+	runPolicy := perfv1alpha1.RunPolicySpec{
+		TTLSecondsAfterFinished: &ttl,
+		TimeoutSeconds:          &timeout,
+		Iterations:              5,
+		WarmupIterations:        1,
+		Regression:              &perfv1alpha1.RegressionSpec{BaselineResult: "baseline"},
+		Webhooks:                []perfv1alpha1.WebhookSpec{},
+		Profile:                 "fio-database-oltp",
+	}
+	podConfig := perfv1alpha1.PodConfigurationSpec{
+		PodLabels:     map[string]string{"app": "kubestone"},
+		PodScheduling: perfv1alpha1.PodSchedulingSpec{NodeName: "node-1"},
+	}
+	image := perfv1alpha1.ImageSpec{Name: "xridge/fio:3.13", PullPolicy: "Always"}
+
+	Describe("Fio", func() {
+		fio := &perfv1alpha1.Fio{
+			ObjectMeta: metav1.ObjectMeta{Name: "fio", Namespace: "kubestone"},
+			Spec: perfv1alpha1.FioSpec{
+				Image:           image,
+				BuiltinJobFiles: []string{"/jobs/rand_read.fio"},
+				CmdLineArgs:     "--size=1G",
+				PodConfig:       podConfig,
+				Volume: perfv1alpha1.VolumeSpec{
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				},
+				RunPolicySpec: runPolicy,
+			},
+			Status: perfv1alpha1.BenchmarkStatus{Running: true},
+		}
+
+		It("should group the common settings", func() {
+			var beta Fio
+			Expect(beta.ConvertFrom(fio)).To(Succeed())
+			Expect(beta.Name).To(Equal("fio"))
+			Expect(beta.Spec.Image).To(Equal(image))
+			Expect(beta.Spec.PodConfig).To(Equal(podConfig))
+			Expect(beta.Spec.Args).To(Equal("--size=1G"))
+			Expect(beta.Spec.Iterations).To(Equal(&IterationsSpec{Count: 5, Warmup: 1}))
+			Expect(beta.Spec.Results.Regression).To(Equal(runPolicy.Regression))
+			Expect(beta.Spec.Results.Archive).To(BeNil())
+			Expect(beta.Spec.Cleanup.TTLSecondsAfterFinished).To(Equal(&ttl))
+			Expect(*beta.Spec.TimeoutSeconds).To(Equal(timeout))
+			Expect(beta.Status.Running).To(BeTrue())
+		})
+
+		It("should round trip", func() {
+			roundTrip(fio, &Fio{}, &perfv1alpha1.Fio{})
+		})
+
+		It("should leave the unset groups empty", func() {
+			var beta Fio
+			Expect(beta.ConvertFrom(&perfv1alpha1.Fio{})).To(Succeed())
+			Expect(beta.Spec.Iterations).To(BeNil())
+			Expect(beta.Spec.Results).To(BeNil())
+			Expect(beta.Spec.Cleanup).To(BeNil())
+		})
+
+		It("should mark the converted objects", func() {
+			beta := Fio{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"team": "storage"}}}
+			var converted perfv1alpha1.Fio
+			Expect(beta.ConvertTo(&converted)).To(Succeed())
+			Expect(converted.Annotations).To(Equal(map[string]string{
+				"team": "storage", perfv1alpha1.ConvertedFromAnnotation: "v1beta1"}))
+			Expect(beta.Annotations).To(Equal(map[string]string{"team": "storage"}))
+
+			Expect(beta.ConvertFrom(&converted)).To(Succeed())
+			Expect(beta.Annotations).To(Equal(map[string]string{"team": "storage"}))
+		})
+	})
+
+	Describe("Iperf3", func() {
+		iperf3 := &perfv1alpha1.Iperf3{
+			Spec: perfv1alpha1.Iperf3Spec{
+				Image: image,
+				ServerConfiguration: perfv1alpha1.Iperf3ConfigurationSpec{
+					PodConfigurationSpec: podConfig,
+					CmdLineArgs:          "--verbose",
+					HostNetwork:          true,
+				},
+				ClientConfiguration: perfv1alpha1.Iperf3ConfigurationSpec{
+					CmdLineArgs: "--parallel 8",
+				},
+				UDP:           true,
+				Completions:   3,
+				RunPolicySpec: runPolicy,
+			},
+		}
+
+		It("should move the client settings to the top level", func() {
+			var beta Iperf3
+			Expect(beta.ConvertFrom(iperf3)).To(Succeed())
+			Expect(beta.Spec.Args).To(Equal("--parallel 8"))
+			Expect(beta.Spec.Server).To(Equal(ServerSpec{PodConfig: podConfig, Args: "--verbose", HostNetwork: true}))
+		})
+
+		It("should round trip", func() {
+			roundTrip(iperf3, &Iperf3{}, &perfv1alpha1.Iperf3{})
+		})
+	})
+
+	Describe("Ntttcp", func() {
+		It("should round trip", func() {
+			ntttcp := &perfv1alpha1.Ntttcp{
+				Spec: perfv1alpha1.NtttcpSpec{
+					Image:        image,
+					Port:         5001,
+					ReadinessCmd: []string{"true"},
+					Mapping:      perfv1alpha1.MappingSpec{SessionCount: "8", Processor: "*"},
+					Completions:  2,
+				},
+			}
+			roundTrip(ntttcp, &Ntttcp{}, &perfv1alpha1.Ntttcp{})
+		})
+	})
+
+	Describe("Ping", func() {
+		It("should round trip", func() {
+			ping := &perfv1alpha1.Ping{
+				Spec: perfv1alpha1.PingSpec{
+					Image:   image,
+					Options: "-c 10",
+					ServerConfiguration: perfv1alpha1.PingConfigurationSpec{
+						PodConfigurationSpec: podConfig,
+					},
+					RunPolicySpec: runPolicy,
+				},
+			}
+			roundTrip(ping, &Ping{}, &perfv1alpha1.Ping{})
+		})
+
+		It("should reject the server arguments", func() {
+			ping := &Ping{Spec: PingSpec{Server: ServerSpec{Args: "-v"}}}
+			Expect(ping.ConvertTo(&perfv1alpha1.Ping{})).NotTo(Succeed())
+		})
+	})
+
+	Describe("Sysbench", func() {
+		It("should round trip", func() {
+			sysbench := &perfv1alpha1.Sysbench{
+				Spec: perfv1alpha1.SysbenchSpec{
+					Image:    image,
+					Options:  "--threads=4",
+					TestName: "cpu",
+					Command:  "run",
+				},
+			}
+			roundTrip(sysbench, &Sysbench{}, &perfv1alpha1.Sysbench{})
+		})
+	})
+})
@@ -0,0 +1,57 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// ConvertTo converts the Drill to the v1alpha1 version
+func (cr *Drill) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*perfv1alpha1.Drill)
+	dst.ObjectMeta = convertMetaTo(cr.ObjectMeta)
+	dst.Spec = perfv1alpha1.DrillSpec{}
+	cr.Spec.convertTo(&dst.Spec.Image, &dst.Spec.PodConfig, &dst.Spec.RunPolicySpec)
+	dst.Spec.BenchmarksVolume = cr.Spec.BenchmarksVolume
+	dst.Spec.BenchmarkFile = cr.Spec.BenchmarkFile
+	dst.Spec.Options = cr.Spec.Args
+	dst.Spec.Command = cr.Spec.ContainerCommand
+	dst.Spec.Args = cr.Spec.ContainerArgs
+	dst.Spec.Log = cr.Spec.Log
+	dst.Spec.Completions = cr.Spec.Completions
+	dst.Status = cr.Status
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 version to the Drill
+func (cr *Drill) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*perfv1alpha1.Drill)
+	cr.ObjectMeta = convertMetaFrom(src.ObjectMeta)
+	cr.Spec = DrillSpec{}
+	cr.Spec.convertFrom(&src.Spec.Image, &src.Spec.PodConfig, &src.Spec.RunPolicySpec)
+	cr.Spec.BenchmarksVolume = src.Spec.BenchmarksVolume
+	cr.Spec.BenchmarkFile = src.Spec.BenchmarkFile
+	cr.Spec.Args = src.Spec.Options
+	cr.Spec.ContainerCommand = src.Spec.Command
+	cr.Spec.ContainerArgs = src.Spec.Args
+	cr.Spec.Log = src.Spec.Log
+	cr.Spec.Completions = src.Spec.Completions
+	cr.Status = src.Status
+	return nil
+}
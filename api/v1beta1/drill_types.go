@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// DrillSpec defines the desired state of Drill
+type DrillSpec struct {
+	// BenchmarkCommon contains the settings shared by every benchmark
+	BenchmarkCommon `json:",inline"`
+
+	// BenchmarksVolume holds the content of benchmark files.
+	// The key of the map specifies the filename and the value is the content
+	// of the file. ConfigMap is created from the map which is mounted as
+	// benchmarks directory to the benchmark pod.
+	// +optional
+	BenchmarksVolume map[string]string `json:"benchmarksVolume,omitempty"`
+
+	// BenchmarkFile is the entry point file (passed to --benchmark) specified to drill.
+	// +optional
+	BenchmarkFile string `json:"benchmarkFile,omitempty"`
+
+	// Args are appended to the options parameter set of drill
+	// +optional
+	Args string `json:"args,omitempty"`
+
+	// ContainerCommand overrides the command of the drill container
+	// +optional
+	ContainerCommand []string `json:"containerCommand,omitempty"`
+
+	// ContainerArgs overrides the arguments of the drill container
+	// +optional
+	ContainerArgs []string `json:"containerArgs,omitempty"`
+
+	// If enabled the controller will create a volume and send the log file to the host node.
+	// +optional
+	Log perfv1alpha1.LogSpec `json:"log,omitempty"`
+
+	// Number of times in a row to run the test
+	// +optional
+	Completions int32 `json:"completions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
+// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+
+// Drill is the Schema for the drills API
+type Drill struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DrillSpec                    `json:"spec,omitempty"`
+	Status perfv1alpha1.BenchmarkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DrillList contains a list of Drill
+type DrillList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Drill `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Drill{}, &DrillList{})
+}
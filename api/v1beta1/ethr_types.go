@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// EthrSpec defines the desired state of Ethr
+type EthrSpec struct {
+	// BenchmarkCommon contains the settings shared by every benchmark
+	BenchmarkCommon `json:",inline"`
+
+	// Args are the command line arguments of the client
+	// +optional
+	Args string `json:"args,omitempty"`
+
+	// HostNetwork runs the client in the network namespace of its node
+	// +optional
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+
+	// Server contains the configuration of the ethr server
+	// +optional
+	Server ServerSpec `json:"server,omitempty"`
+
+	// If enabled the controller will create a volume and send the log file to the host node.
+	// +optional
+	Log perfv1alpha1.LogSpec `json:"log,omitempty"`
+
+	// Number of times in a row to run the test
+	// +optional
+	Completions int32 `json:"completions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
+// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+
+// Ethr is the Schema for the ethrs API
+type Ethr struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EthrSpec                     `json:"spec,omitempty"`
+	Status perfv1alpha1.BenchmarkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EthrList contains a list of Ethr
+type EthrList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Ethr `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Ethr{}, &EthrList{})
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// ConvertTo converts the Fio to the v1alpha1 version
+func (cr *Fio) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*perfv1alpha1.Fio)
+	dst.ObjectMeta = convertMetaTo(cr.ObjectMeta)
+	dst.Spec = perfv1alpha1.FioSpec{}
+	cr.Spec.convertTo(&dst.Spec.Image, &dst.Spec.PodConfig, &dst.Spec.RunPolicySpec)
+	dst.Spec.BuiltinJobFiles = cr.Spec.BuiltinJobFiles
+	dst.Spec.CustomJobFiles = cr.Spec.CustomJobFiles
+	dst.Spec.CmdLineArgs = cr.Spec.Args
+	dst.Spec.Volume = cr.Spec.Volume
+	dst.Status = cr.Status
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 version to the Fio
+func (cr *Fio) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*perfv1alpha1.Fio)
+	cr.ObjectMeta = convertMetaFrom(src.ObjectMeta)
+	cr.Spec = FioSpec{}
+	cr.Spec.convertFrom(&src.Spec.Image, &src.Spec.PodConfig, &src.Spec.RunPolicySpec)
+	cr.Spec.BuiltinJobFiles = src.Spec.BuiltinJobFiles
+	cr.Spec.CustomJobFiles = src.Spec.CustomJobFiles
+	cr.Spec.Args = src.Spec.CmdLineArgs
+	cr.Spec.Volume = src.Spec.Volume
+	cr.Status = src.Status
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// IopingSpec defines the desired state of Ioping
+type IopingSpec struct {
+	// BenchmarkCommon contains the settings shared by every benchmark
+	BenchmarkCommon `json:",inline"`
+
+	// Args are appended to the predefined ioping parameters
+	// +optional
+	Args string `json:"args,omitempty"`
+
+	// Volume contains the configuration for the volume that the ioping job should
+	// run on.
+	// +optional
+	Volume perfv1alpha1.VolumeSpec `json:"volume,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
+// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+
+// Ioping is the Schema for the iopings API
+type Ioping struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IopingSpec                   `json:"spec,omitempty"`
+	Status perfv1alpha1.BenchmarkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IopingList contains a list of Ioping
+type IopingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Ioping `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Ioping{}, &IopingList{})
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// ConvertTo converts the Iperf2 to the v1alpha1 version
+func (cr *Iperf2) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*perfv1alpha1.Iperf2)
+	dst.ObjectMeta = convertMetaTo(cr.ObjectMeta)
+	dst.Spec = perfv1alpha1.Iperf2Spec{}
+	cr.Spec.convertTo(&dst.Spec.Image, &dst.Spec.ClientConfiguration.PodConfigurationSpec, &dst.Spec.RunPolicySpec)
+	dst.Spec.ClientConfiguration.CmdLineArgs = cr.Spec.Args
+	dst.Spec.ClientConfiguration.HostNetwork = cr.Spec.HostNetwork
+	dst.Spec.ServerConfiguration = perfv1alpha1.Iperf2ConfigurationSpec{
+		PodConfigurationSpec: cr.Spec.Server.PodConfig,
+		CmdLineArgs:          cr.Spec.Server.Args,
+		HostNetwork:          cr.Spec.Server.HostNetwork,
+	}
+	dst.Spec.UDP = cr.Spec.UDP
+	dst.Spec.Log = cr.Spec.Log
+	dst.Spec.Completions = cr.Spec.Completions
+	dst.Status = cr.Status
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 version to the Iperf2
+func (cr *Iperf2) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*perfv1alpha1.Iperf2)
+	cr.ObjectMeta = convertMetaFrom(src.ObjectMeta)
+	cr.Spec = Iperf2Spec{}
+	cr.Spec.convertFrom(&src.Spec.Image, &src.Spec.ClientConfiguration.PodConfigurationSpec, &src.Spec.RunPolicySpec)
+	cr.Spec.Args = src.Spec.ClientConfiguration.CmdLineArgs
+	cr.Spec.HostNetwork = src.Spec.ClientConfiguration.HostNetwork
+	cr.Spec.Server = ServerSpec{
+		PodConfig:   src.Spec.ServerConfiguration.PodConfigurationSpec,
+		Args:        src.Spec.ServerConfiguration.CmdLineArgs,
+		HostNetwork: src.Spec.ServerConfiguration.HostNetwork,
+	}
+	cr.Spec.UDP = src.Spec.UDP
+	cr.Spec.Log = src.Spec.Log
+	cr.Spec.Completions = src.Spec.Completions
+	cr.Status = src.Status
+	return nil
+}
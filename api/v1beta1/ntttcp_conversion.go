@@ -0,0 +1,67 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// ConvertTo converts the Ntttcp to the v1alpha1 version
+func (cr *Ntttcp) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*perfv1alpha1.Ntttcp)
+	dst.ObjectMeta = convertMetaTo(cr.ObjectMeta)
+	dst.Spec = perfv1alpha1.NtttcpSpec{}
+	cr.Spec.convertTo(&dst.Spec.Image, &dst.Spec.ClientConfiguration.PodConfigurationSpec, &dst.Spec.RunPolicySpec)
+	dst.Spec.ClientConfiguration.CmdLineArgs = cr.Spec.Args
+	dst.Spec.ClientConfiguration.HostNetwork = cr.Spec.HostNetwork
+	dst.Spec.ServerConfiguration = perfv1alpha1.NtttcpConfigurationSpec{
+		PodConfigurationSpec: cr.Spec.Server.PodConfig,
+		CmdLineArgs:          cr.Spec.Server.Args,
+		HostNetwork:          cr.Spec.Server.HostNetwork,
+	}
+	dst.Spec.Log = cr.Spec.Log
+	dst.Spec.Port = cr.Spec.Port
+	dst.Spec.ReadinessCmd = cr.Spec.ReadinessCommand
+	dst.Spec.Mapping = cr.Spec.Mapping
+	dst.Spec.Completions = int(cr.Spec.Completions)
+	dst.Status = cr.Status
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 version to the Ntttcp
+func (cr *Ntttcp) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*perfv1alpha1.Ntttcp)
+	cr.ObjectMeta = convertMetaFrom(src.ObjectMeta)
+	cr.Spec = NtttcpSpec{}
+	cr.Spec.convertFrom(&src.Spec.Image, &src.Spec.ClientConfiguration.PodConfigurationSpec, &src.Spec.RunPolicySpec)
+	cr.Spec.Args = src.Spec.ClientConfiguration.CmdLineArgs
+	cr.Spec.HostNetwork = src.Spec.ClientConfiguration.HostNetwork
+	cr.Spec.Server = ServerSpec{
+		PodConfig:   src.Spec.ServerConfiguration.PodConfigurationSpec,
+		Args:        src.Spec.ServerConfiguration.CmdLineArgs,
+		HostNetwork: src.Spec.ServerConfiguration.HostNetwork,
+	}
+	cr.Spec.Log = src.Spec.Log
+	cr.Spec.Port = src.Spec.Port
+	cr.Spec.ReadinessCommand = src.Spec.ReadinessCmd
+	cr.Spec.Mapping = src.Spec.Mapping
+	cr.Spec.Completions = int32(src.Spec.Completions)
+	cr.Status = src.Status
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// PgbenchSpec defines the desired state of Pgbench
+type PgbenchSpec struct {
+	// BenchmarkCommon contains the settings shared by every benchmark
+	BenchmarkCommon `json:",inline"`
+
+	// Postgres contains the configuration parameters for the PostgreSQL database
+	// that will run the benchmark. Required unless Provision is set.
+	// +optional
+	Postgres perfv1alpha1.PostgresSpec `json:"postgres,omitempty"`
+
+	// Provision deploys a temporary PostgreSQL server for the benchmark
+	// instead of connecting to the database given in Postgres. The server
+	// is removed once the benchmark is finished.
+	// +optional
+	Provision *perfv1alpha1.PostgresProvisionSpec `json:"provision,omitempty"`
+
+	// InitArgs contains the command line arguments passed to the init container
+	// +optional
+	InitArgs string `json:"initArgs,omitempty"`
+
+	// Args contains the command line arguments passed to the main pgbench container
+	// +optional
+	Args string `json:"args,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
+// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+
+// Pgbench is the Schema for the pgbenches API
+type Pgbench struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PgbenchSpec                  `json:"spec,omitempty"`
+	Status perfv1alpha1.BenchmarkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PgbenchList contains a list of Pgbench
+type PgbenchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Pgbench `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Pgbench{}, &PgbenchList{})
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// ConvertTo converts the Qperf to the v1alpha1 version
+func (cr *Qperf) ConvertTo(dstRaw conversion.Hub) error {
+	if cr.Spec.Server.Args != "" {
+		return errServerArgs("Qperf")
+	}
+	dst := dstRaw.(*perfv1alpha1.Qperf)
+	dst.ObjectMeta = convertMetaTo(cr.ObjectMeta)
+	dst.Spec = perfv1alpha1.QperfSpec{}
+	cr.Spec.convertTo(&dst.Spec.Image, &dst.Spec.ClientConfiguration.PodConfigurationSpec, &dst.Spec.RunPolicySpec)
+	dst.Spec.Options = cr.Spec.Args
+	dst.Spec.ClientConfiguration.HostNetwork = cr.Spec.HostNetwork
+	dst.Spec.ServerConfiguration = perfv1alpha1.QperfConfigurationSpec{
+		PodConfigurationSpec: cr.Spec.Server.PodConfig,
+		HostNetwork:          cr.Spec.Server.HostNetwork,
+	}
+	dst.Spec.Tests = cr.Spec.Tests
+	dst.Status = cr.Status
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 version to the Qperf
+func (cr *Qperf) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*perfv1alpha1.Qperf)
+	cr.ObjectMeta = convertMetaFrom(src.ObjectMeta)
+	cr.Spec = QperfSpec{}
+	cr.Spec.convertFrom(&src.Spec.Image, &src.Spec.ClientConfiguration.PodConfigurationSpec, &src.Spec.RunPolicySpec)
+	cr.Spec.Args = src.Spec.Options
+	cr.Spec.HostNetwork = src.Spec.ClientConfiguration.HostNetwork
+	cr.Spec.Server = ServerSpec{
+		PodConfig:   src.Spec.ServerConfiguration.PodConfigurationSpec,
+		HostNetwork: src.Spec.ServerConfiguration.HostNetwork,
+	}
+	cr.Spec.Tests = src.Spec.Tests
+	cr.Status = src.Status
+	return nil
+}
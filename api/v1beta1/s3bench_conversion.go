@@ -0,0 +1,59 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// ConvertTo converts the S3Bench to the v1alpha1 version
+func (cr *S3Bench) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*perfv1alpha1.S3Bench)
+	dst.ObjectMeta = convertMetaTo(cr.ObjectMeta)
+	dst.Spec = perfv1alpha1.S3BenchSpec{}
+	cr.Spec.convertTo(&dst.Spec.Image, &dst.Spec.PodConfig, &dst.Spec.RunPolicySpec)
+	dst.Spec.Mode = cr.Spec.Mode
+	dst.Spec.Host = cr.Spec.Host
+	dst.Spec.Provision = cr.Spec.Provision
+	dst.Spec.S3BenchOptions = cr.Spec.S3BenchOptions
+	dst.Spec.S3ObjectOptions = cr.Spec.S3ObjectOptions
+	dst.Spec.S3AutoTermOptions = cr.Spec.S3AutoTermOptions
+	dst.Spec.S3AnalysisOptions = cr.Spec.S3AnalysisOptions
+	dst.Spec.MixedDistributionOptions = cr.Spec.MixedDistributionOptions
+	dst.Status = cr.Status
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 version to the S3Bench
+func (cr *S3Bench) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*perfv1alpha1.S3Bench)
+	cr.ObjectMeta = convertMetaFrom(src.ObjectMeta)
+	cr.Spec = S3BenchSpec{}
+	cr.Spec.convertFrom(&src.Spec.Image, &src.Spec.PodConfig, &src.Spec.RunPolicySpec)
+	cr.Spec.Mode = src.Spec.Mode
+	cr.Spec.Host = src.Spec.Host
+	cr.Spec.Provision = src.Spec.Provision
+	cr.Spec.S3BenchOptions = src.Spec.S3BenchOptions
+	cr.Spec.S3ObjectOptions = src.Spec.S3ObjectOptions
+	cr.Spec.S3AutoTermOptions = src.Spec.S3AutoTermOptions
+	cr.Spec.S3AnalysisOptions = src.Spec.S3AnalysisOptions
+	cr.Spec.MixedDistributionOptions = src.Spec.MixedDistributionOptions
+	cr.Status = src.Status
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// S3BenchSpec defines the desired state of S3Bench
+type S3BenchSpec struct {
+	// BenchmarkCommon contains the settings shared by every benchmark
+	BenchmarkCommon `json:",inline"`
+
+	// Mode defines the operating mode of the benchmark test. See https://github.com/minio/warp#mixed for option definition.
+	// Currently accepted values are: get, put, delete, mixed
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// Host defines the host to benchmark against.
+	// Multiple hosts can be specified as a comma separated list. (default: "127.0.0.1:9000")
+	// Required unless Provision is set.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Provision deploys a temporary MinIO server for the benchmark instead
+	// of connecting to the one given in Host. The server is removed once
+	// the benchmark is finished.
+	// +optional
+	Provision *perfv1alpha1.MinioProvisionSpec `json:"provision,omitempty"`
+
+	// S3BenchOptions defines the runtime arguments for the benchmark test
+	perfv1alpha1.S3BenchOptions `json:",inline"`
+
+	// S3ObjectOptions defines options for the objects generated by the benchmark
+	// +optional
+	S3ObjectOptions perfv1alpha1.S3ObjectOptions `json:"objects,omitempty"`
+
+	// S3AutoTermOptions defines options for the auto terminate feature of warp.
+	// +optional
+	S3AutoTermOptions perfv1alpha1.S3AutoTermOptions `json:"autoTerm,omitempty"`
+
+	// S3AnalysisOptions defines options for the analysis features of warp
+	// +optional
+	S3AnalysisOptions perfv1alpha1.S3AnalysisOptions `json:"analysis,omitempty"`
+
+	// MixedDistributionOptions defines the distribution of operation types if using the mixed mode
+	// Will only be used in "mixed" mode.
+	// +optional
+	MixedDistributionOptions perfv1alpha1.MixedDistributionOptions `json:"mixedDist,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
+// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+
+// S3Bench is the Schema for the s3benches API
+type S3Bench struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   S3BenchSpec                  `json:"spec,omitempty"`
+	Status perfv1alpha1.BenchmarkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// S3BenchList contains a list of S3Bench
+type S3BenchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []S3Bench `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&S3Bench{}, &S3BenchList{})
+}
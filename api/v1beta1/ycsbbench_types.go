@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// YcsbBenchSpec defines the desired state of YcsbBench
+type YcsbBenchSpec struct {
+	// BenchmarkCommon contains the settings shared by every benchmark
+	BenchmarkCommon `json:",inline"`
+
+	// Database is the YCSB binding of the database under test
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// Workload is the name of the YCSB workload
+	// +optional
+	Workload string `json:"workload,omitempty"`
+
+	// Options are the options of the ycsb command
+	// +optional
+	Options perfv1alpha1.YcsbBenchOptions `json:"options,omitempty"`
+
+	// Properties are passed to YCSB as -p name=value
+	// +optional
+	Properties map[string]string `json:"properties,omitempty"`
+
+	// PropertiesFrom are passed to YCSB like the Properties, with their
+	// values read from Secrets. A property can not be given in both.
+	// +optional
+	PropertiesFrom map[string]corev1.SecretKeySelector `json:"propertiesFrom,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
+// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+
+// YcsbBench is the Schema for the ycsbbenches API
+type YcsbBench struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   YcsbBenchSpec                `json:"spec,omitempty"`
+	Status perfv1alpha1.BenchmarkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// YcsbBenchList contains a list of YcsbBench
+type YcsbBenchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []YcsbBench `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&YcsbBench{}, &YcsbBenchList{})
+}
@@ -0,0 +1,1373 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"github.com/xridge/kubestone/api/v1alpha1"
+	"k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkCommon) DeepCopyInto(out *BenchmarkCommon) {
+	*out = *in
+	out.Image = in.Image
+	in.PodConfig.DeepCopyInto(&out.PodConfig)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Iterations != nil {
+		in, out := &in.Iterations, &out.Iterations
+		*out = new(IterationsSpec)
+		**out = **in
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = new(ResultsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cleanup != nil {
+		in, out := &in.Cleanup, &out.Cleanup
+		*out = new(CleanupSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exclusivity != nil {
+		in, out := &in.Exclusivity, &out.Exclusivity
+		*out = new(v1alpha1.ExclusivitySpec)
+		**out = **in
+	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = new(v1alpha1.VariablesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkCommon.
+func (in *BenchmarkCommon) DeepCopy() *BenchmarkCommon {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkCommon)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupSpec) DeepCopyInto(out *CleanupSpec) {
+	*out = *in
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupSpec.
+func (in *CleanupSpec) DeepCopy() *CleanupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Drill) DeepCopyInto(out *Drill) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Drill.
+func (in *Drill) DeepCopy() *Drill {
+	if in == nil {
+		return nil
+	}
+	out := new(Drill)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Drill) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrillList) DeepCopyInto(out *DrillList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Drill, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrillList.
+func (in *DrillList) DeepCopy() *DrillList {
+	if in == nil {
+		return nil
+	}
+	out := new(DrillList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DrillList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrillSpec) DeepCopyInto(out *DrillSpec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+	if in.BenchmarksVolume != nil {
+		in, out := &in.BenchmarksVolume, &out.BenchmarksVolume
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ContainerCommand != nil {
+		in, out := &in.ContainerCommand, &out.ContainerCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContainerArgs != nil {
+		in, out := &in.ContainerArgs, &out.ContainerArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Log = in.Log
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrillSpec.
+func (in *DrillSpec) DeepCopy() *DrillSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DrillSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ethr) DeepCopyInto(out *Ethr) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ethr.
+func (in *Ethr) DeepCopy() *Ethr {
+	if in == nil {
+		return nil
+	}
+	out := new(Ethr)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Ethr) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EthrList) DeepCopyInto(out *EthrList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Ethr, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EthrList.
+func (in *EthrList) DeepCopy() *EthrList {
+	if in == nil {
+		return nil
+	}
+	out := new(EthrList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EthrList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EthrSpec) DeepCopyInto(out *EthrSpec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+	in.Server.DeepCopyInto(&out.Server)
+	out.Log = in.Log
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EthrSpec.
+func (in *EthrSpec) DeepCopy() *EthrSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EthrSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Fio) DeepCopyInto(out *Fio) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fio.
+func (in *Fio) DeepCopy() *Fio {
+	if in == nil {
+		return nil
+	}
+	out := new(Fio)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Fio) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FioList) DeepCopyInto(out *FioList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Fio, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioList.
+func (in *FioList) DeepCopy() *FioList {
+	if in == nil {
+		return nil
+	}
+	out := new(FioList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FioList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FioSpec) DeepCopyInto(out *FioSpec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+	if in.BuiltinJobFiles != nil {
+		in, out := &in.BuiltinJobFiles, &out.BuiltinJobFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomJobFiles != nil {
+		in, out := &in.CustomJobFiles, &out.CustomJobFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Volume.DeepCopyInto(&out.Volume)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioSpec.
+func (in *FioSpec) DeepCopy() *FioSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FioSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ioping) DeepCopyInto(out *Ioping) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ioping.
+func (in *Ioping) DeepCopy() *Ioping {
+	if in == nil {
+		return nil
+	}
+	out := new(Ioping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Ioping) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IopingList) DeepCopyInto(out *IopingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Ioping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IopingList.
+func (in *IopingList) DeepCopy() *IopingList {
+	if in == nil {
+		return nil
+	}
+	out := new(IopingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IopingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IopingSpec) DeepCopyInto(out *IopingSpec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+	in.Volume.DeepCopyInto(&out.Volume)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IopingSpec.
+func (in *IopingSpec) DeepCopy() *IopingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IopingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Iperf2) DeepCopyInto(out *Iperf2) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iperf2.
+func (in *Iperf2) DeepCopy() *Iperf2 {
+	if in == nil {
+		return nil
+	}
+	out := new(Iperf2)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Iperf2) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Iperf2List) DeepCopyInto(out *Iperf2List) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Iperf2, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iperf2List.
+func (in *Iperf2List) DeepCopy() *Iperf2List {
+	if in == nil {
+		return nil
+	}
+	out := new(Iperf2List)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Iperf2List) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Iperf2Spec) DeepCopyInto(out *Iperf2Spec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+	in.Server.DeepCopyInto(&out.Server)
+	out.Log = in.Log
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iperf2Spec.
+func (in *Iperf2Spec) DeepCopy() *Iperf2Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(Iperf2Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Iperf3) DeepCopyInto(out *Iperf3) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iperf3.
+func (in *Iperf3) DeepCopy() *Iperf3 {
+	if in == nil {
+		return nil
+	}
+	out := new(Iperf3)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Iperf3) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Iperf3List) DeepCopyInto(out *Iperf3List) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Iperf3, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iperf3List.
+func (in *Iperf3List) DeepCopy() *Iperf3List {
+	if in == nil {
+		return nil
+	}
+	out := new(Iperf3List)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Iperf3List) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Iperf3Spec) DeepCopyInto(out *Iperf3Spec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+	in.Server.DeepCopyInto(&out.Server)
+	out.Log = in.Log
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iperf3Spec.
+func (in *Iperf3Spec) DeepCopy() *Iperf3Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(Iperf3Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IterationsSpec) DeepCopyInto(out *IterationsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IterationsSpec.
+func (in *IterationsSpec) DeepCopy() *IterationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IterationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaBench) DeepCopyInto(out *KafkaBench) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBench.
+func (in *KafkaBench) DeepCopy() *KafkaBench {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaBench)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaBench) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaBenchList) DeepCopyInto(out *KafkaBenchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KafkaBench, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBenchList.
+func (in *KafkaBenchList) DeepCopy() *KafkaBenchList {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaBenchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaBenchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaBenchSpec) DeepCopyInto(out *KafkaBenchSpec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+	in.KafkaClusterInfo.DeepCopyInto(&out.KafkaClusterInfo)
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]v1alpha1.KafkaTestSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBenchSpec.
+func (in *KafkaBenchSpec) DeepCopy() *KafkaBenchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaBenchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ntttcp) DeepCopyInto(out *Ntttcp) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ntttcp.
+func (in *Ntttcp) DeepCopy() *Ntttcp {
+	if in == nil {
+		return nil
+	}
+	out := new(Ntttcp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Ntttcp) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NtttcpList) DeepCopyInto(out *NtttcpList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Ntttcp, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NtttcpList.
+func (in *NtttcpList) DeepCopy() *NtttcpList {
+	if in == nil {
+		return nil
+	}
+	out := new(NtttcpList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NtttcpList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NtttcpSpec) DeepCopyInto(out *NtttcpSpec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+	in.Server.DeepCopyInto(&out.Server)
+	out.Log = in.Log
+	if in.ReadinessCommand != nil {
+		in, out := &in.ReadinessCommand, &out.ReadinessCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Mapping = in.Mapping
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NtttcpSpec.
+func (in *NtttcpSpec) DeepCopy() *NtttcpSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NtttcpSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OcpLogtest) DeepCopyInto(out *OcpLogtest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OcpLogtest.
+func (in *OcpLogtest) DeepCopy() *OcpLogtest {
+	if in == nil {
+		return nil
+	}
+	out := new(OcpLogtest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OcpLogtest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OcpLogtestList) DeepCopyInto(out *OcpLogtestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OcpLogtest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OcpLogtestList.
+func (in *OcpLogtestList) DeepCopy() *OcpLogtestList {
+	if in == nil {
+		return nil
+	}
+	out := new(OcpLogtestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OcpLogtestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OcpLogtestSpec) DeepCopyInto(out *OcpLogtestSpec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OcpLogtestSpec.
+func (in *OcpLogtestSpec) DeepCopy() *OcpLogtestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OcpLogtestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pgbench) DeepCopyInto(out *Pgbench) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pgbench.
+func (in *Pgbench) DeepCopy() *Pgbench {
+	if in == nil {
+		return nil
+	}
+	out := new(Pgbench)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Pgbench) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgbenchList) DeepCopyInto(out *PgbenchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Pgbench, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgbenchList.
+func (in *PgbenchList) DeepCopy() *PgbenchList {
+	if in == nil {
+		return nil
+	}
+	out := new(PgbenchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PgbenchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgbenchSpec) DeepCopyInto(out *PgbenchSpec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+	in.Postgres.DeepCopyInto(&out.Postgres)
+	if in.Provision != nil {
+		in, out := &in.Provision, &out.Provision
+		*out = new(v1alpha1.PostgresProvisionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgbenchSpec.
+func (in *PgbenchSpec) DeepCopy() *PgbenchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PgbenchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ping) DeepCopyInto(out *Ping) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ping.
+func (in *Ping) DeepCopy() *Ping {
+	if in == nil {
+		return nil
+	}
+	out := new(Ping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Ping) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingList) DeepCopyInto(out *PingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Ping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingList.
+func (in *PingList) DeepCopy() *PingList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingSpec) DeepCopyInto(out *PingSpec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+	in.Server.DeepCopyInto(&out.Server)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingSpec.
+func (in *PingSpec) DeepCopy() *PingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Qperf) DeepCopyInto(out *Qperf) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Qperf.
+func (in *Qperf) DeepCopy() *Qperf {
+	if in == nil {
+		return nil
+	}
+	out := new(Qperf)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Qperf) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QperfList) DeepCopyInto(out *QperfList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Qperf, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QperfList.
+func (in *QperfList) DeepCopy() *QperfList {
+	if in == nil {
+		return nil
+	}
+	out := new(QperfList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QperfList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QperfSpec) DeepCopyInto(out *QperfSpec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+	in.Server.DeepCopyInto(&out.Server)
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QperfSpec.
+func (in *QperfSpec) DeepCopy() *QperfSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QperfSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultsSpec) DeepCopyInto(out *ResultsSpec) {
+	*out = *in
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(v1alpha1.ArchiveSpec)
+		**out = **in
+	}
+	if in.Regression != nil {
+		in, out := &in.Regression, &out.Regression
+		*out = new(v1alpha1.RegressionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]v1alpha1.WebhookSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultsSpec.
+func (in *ResultsSpec) DeepCopy() *ResultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Bench) DeepCopyInto(out *S3Bench) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Bench.
+func (in *S3Bench) DeepCopy() *S3Bench {
+	if in == nil {
+		return nil
+	}
+	out := new(S3Bench)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *S3Bench) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BenchList) DeepCopyInto(out *S3BenchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]S3Bench, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BenchList.
+func (in *S3BenchList) DeepCopy() *S3BenchList {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BenchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *S3BenchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BenchSpec) DeepCopyInto(out *S3BenchSpec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+	if in.Provision != nil {
+		in, out := &in.Provision, &out.Provision
+		*out = new(v1alpha1.MinioProvisionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.S3BenchOptions.DeepCopyInto(&out.S3BenchOptions)
+	out.S3ObjectOptions = in.S3ObjectOptions
+	out.S3AutoTermOptions = in.S3AutoTermOptions
+	out.S3AnalysisOptions = in.S3AnalysisOptions
+	out.MixedDistributionOptions = in.MixedDistributionOptions
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BenchSpec.
+func (in *S3BenchSpec) DeepCopy() *S3BenchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BenchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerSpec) DeepCopyInto(out *ServerSpec) {
+	*out = *in
+	in.PodConfig.DeepCopyInto(&out.PodConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSpec.
+func (in *ServerSpec) DeepCopy() *ServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Sysbench) DeepCopyInto(out *Sysbench) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sysbench.
+func (in *Sysbench) DeepCopy() *Sysbench {
+	if in == nil {
+		return nil
+	}
+	out := new(Sysbench)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Sysbench) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SysbenchList) DeepCopyInto(out *SysbenchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Sysbench, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SysbenchList.
+func (in *SysbenchList) DeepCopy() *SysbenchList {
+	if in == nil {
+		return nil
+	}
+	out := new(SysbenchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SysbenchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SysbenchSpec) DeepCopyInto(out *SysbenchSpec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SysbenchSpec.
+func (in *SysbenchSpec) DeepCopy() *SysbenchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SysbenchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YcsbBench) DeepCopyInto(out *YcsbBench) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YcsbBench.
+func (in *YcsbBench) DeepCopy() *YcsbBench {
+	if in == nil {
+		return nil
+	}
+	out := new(YcsbBench)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *YcsbBench) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YcsbBenchList) DeepCopyInto(out *YcsbBenchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]YcsbBench, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YcsbBenchList.
+func (in *YcsbBenchList) DeepCopy() *YcsbBenchList {
+	if in == nil {
+		return nil
+	}
+	out := new(YcsbBenchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *YcsbBenchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YcsbBenchSpec) DeepCopyInto(out *YcsbBenchSpec) {
+	*out = *in
+	in.BenchmarkCommon.DeepCopyInto(&out.BenchmarkCommon)
+	out.Options = in.Options
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PropertiesFrom != nil {
+		in, out := &in.PropertiesFrom, &out.PropertiesFrom
+		*out = make(map[string]v1.SecretKeySelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YcsbBenchSpec.
+func (in *YcsbBenchSpec) DeepCopy() *YcsbBenchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(YcsbBenchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/xridge/kubestone/pkg/cli"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// runLogs prints the logs of the client and server pods of a benchmark.
+// The pods are found via their cr-name label.
+func runLogs(args []string) error {
+	fs := flag.NewFlagSet("logs", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: kubectl stone logs <kind> <name> [flags]\n\n")
+		fs.PrintDefaults()
+	}
+	var cluster cli.ClusterFlags
+	cluster.Register(fs)
+	follow := fs.Bool("f", false, "Stream the logs of the running pods.")
+	container := fs.String("container", "", "The container of the pods. The first container is used if empty.")
+	tail := fs.Int64("tail", -1, "The number of the last lines shown from each pod. All lines are shown if negative.")
+	positional, _, err := cli.ParseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	c, namespace, err := cluster.NewClient()
+	if err != nil {
+		return err
+	}
+	s, err := cli.Scheme()
+	if err != nil {
+		return err
+	}
+	cr, err := cli.NewBenchmark(s, positional[0])
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: positional[1]}, cr); err != nil {
+		return err
+	}
+
+	config, err := cluster.RESTConfig()
+	if err != nil {
+		return err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	pods, err := clientset.CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: k8s.CrNameLabel + "=" + cr.GetName(),
+	})
+	if err != nil {
+		return err
+	}
+	if len(pods.Items) == 0 {
+		return fmt.Errorf("No pods found for %v/%v", positional[0], cr.GetName())
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].CreationTimestamp.Before(&pods.Items[j].CreationTimestamp)
+	})
+
+	options := corev1.PodLogOptions{Container: *container, Follow: *follow}
+	if *tail >= 0 {
+		options.TailLines = tail
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	errs := make(chan error, len(pods.Items))
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodPending {
+			fmt.Fprintf(os.Stderr, "Pod %v is pending\n", pod.Name)
+			continue
+		}
+		stream, err := clientset.CoreV1().Pods(namespace).GetLogs(pod.Name, &options).Stream()
+		if err != nil {
+			return err
+		}
+		prefix := ""
+		if len(pods.Items) > 1 {
+			prefix = "[" + pod.Name + "] "
+		}
+
+		// Streams are printed concurrently when following, so that the
+		// server and the client logs are interleaved
+		wg.Add(1)
+		copyLines := func(stream io.ReadCloser) {
+			defer wg.Done()
+			defer stream.Close()
+			scanner := bufio.NewScanner(stream)
+			for scanner.Scan() {
+				mu.Lock()
+				fmt.Println(prefix + scanner.Text())
+				mu.Unlock()
+			}
+			errs <- scanner.Err()
+		}
+		if *follow {
+			go copyLines(stream)
+		} else {
+			copyLines(stream)
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command kubectl-stone is a kubectl plugin running the benchmarks and
+// showing their progress, results and logs. It is invoked as
+// 'kubectl stone' when installed on the PATH.
+package main
+
+import (
+	"github.com/xridge/kubestone/pkg/cli"
+)
+
+func main() {
+	cli.Main("kubectl stone", map[string]cli.Command{
+		"run":     {Description: "Create a benchmark from flags", Run: runRun},
+		"watch":   {Description: "Follow the phase transitions and the events of a benchmark", Run: runWatch},
+		"results": {Description: "Print the results of a benchmark", Run: runResults},
+		"logs":    {Description: "Print the logs of the pods of a benchmark", Run: runLogs},
+	})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/cli"
+)
+
+// results are the parsed results of a benchmark or a BenchmarkResult
+type results struct {
+	Metrics     []perfv1alpha1.BenchmarkMetric  `json:"metrics"`
+	Aggregates  []perfv1alpha1.MetricAggregate  `json:"aggregates,omitempty"`
+	Comparisons []perfv1alpha1.MetricComparison `json:"comparisons,omitempty"`
+}
+
+// runResults prints the results of a benchmark, or of a BenchmarkResult
+// when the kind is benchmarkresult (br)
+func runResults(args []string) error {
+	fs := flag.NewFlagSet("results", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: kubectl stone results <kind|benchmarkresult> <name> [flags]\n\n")
+		fs.PrintDefaults()
+	}
+	var cluster cli.ClusterFlags
+	cluster.Register(fs)
+	output := fs.String("o", "table", "Output format: table or json.")
+	positional, _, err := cli.ParseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if *output != "table" && *output != "json" {
+		return fmt.Errorf("Unknown output format: %v", *output)
+	}
+
+	c, namespace, err := cluster.NewClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	nn := types.NamespacedName{Namespace: namespace, Name: positional[1]}
+
+	var r results
+	if kind := strings.ToLower(positional[0]); kind == "benchmarkresult" || kind == "br" {
+		var result perfv1alpha1.BenchmarkResult
+		if err := c.Get(ctx, nn, &result); err != nil {
+			return err
+		}
+		r = results{Metrics: result.Spec.Metrics, Aggregates: result.Spec.Aggregates}
+	} else {
+		s, err := cli.Scheme()
+		if err != nil {
+			return err
+		}
+		cr, err := cli.NewBenchmark(s, kind)
+		if err != nil {
+			return err
+		}
+		if err := c.Get(ctx, nn, cr); err != nil {
+			return err
+		}
+		status := cr.GetBenchmarkStatus()
+		if !status.Completed {
+			return fmt.Errorf("%v/%v is %v, the results are available once it is finished",
+				positional[0], nn.Name, strings.ToLower(status.Phase()))
+		}
+		r = results{Metrics: status.Metrics, Aggregates: status.Aggregates, Comparisons: status.Comparisons}
+	}
+
+	if *output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	}
+	if err := cli.WriteMetrics(os.Stdout, r.Metrics, r.Aggregates); err != nil {
+		return err
+	}
+	if len(r.Comparisons) > 0 {
+		fmt.Println()
+		return cli.WriteComparisons(os.Stdout, r.Comparisons)
+	}
+	return nil
+}
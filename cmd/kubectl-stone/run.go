@@ -0,0 +1,118 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/xridge/kubestone/pkg/cli"
+)
+
+// runRun creates a benchmark of the given kind from the flags:
+//
+//	kubectl stone run fio --storage-class fast -- --rw=randread
+func runRun(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: kubectl stone run <kind> [flags] [-- <tool arguments>]\n\n")
+		fs.PrintDefaults()
+	}
+	var cluster cli.ClusterFlags
+	cluster.Register(fs)
+	var opts cli.RunOptions
+	fs.StringVar(&opts.Name, "name", "", "Name of the benchmark. A name is generated from the kind if empty.")
+	fs.StringVar(&opts.Image, "image", "", "Image of the benchmark tool. The default image of the kind is used if empty.")
+	fs.StringVar(&opts.StorageClass, "storage-class", "", "Storage class of the generated volume.")
+	fs.StringVar(&opts.Size, "size", "", "Size of the generated volume, "+cli.DefaultVolumeSize+" by default.")
+	fs.StringVar(&opts.Node, "node", "", "The node of the (client) pods.")
+	fs.StringVar(&opts.ServerNode, "server-node", "", "The node of the server pods.")
+	iterations := fs.Int("iterations", 0, "Number of the measured iterations.")
+	ttl := fs.Int("ttl", -1, "Seconds after which the finished benchmark is deleted. Not set if negative.")
+	set := cli.StringList{}
+	fs.Var(&set, "set", "Sets a spec field, e.g. --set postgres.host=db. Repeatable.")
+	dryRun := fs.Bool("dry-run", false, "Print the benchmark instead of creating it.")
+	watch := fs.Bool("watch", false, "Follow the benchmark once it is created.")
+	timeout := fs.Duration("timeout", 0, "The maximum time of following the benchmark with --watch.")
+
+	positional, toolArgs, err := cli.ParseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	opts.Iterations = int32(*iterations)
+	opts.TTLSecondsAfterFinished = int32(*ttl)
+	opts.Set = set
+	opts.Args = toolArgs
+
+	s, err := cli.Scheme()
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		opts.Namespace = cluster.Namespace
+		cr, err := cli.BuildBenchmark(s, positional[0], &opts)
+		if err != nil {
+			return err
+		}
+		encoded, err := yaml.Marshal(cr)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(encoded)
+		return err
+	}
+
+	c, namespace, err := cluster.NewClient()
+	if err != nil {
+		return err
+	}
+	opts.Namespace = namespace
+	cr, err := cli.BuildBenchmark(s, positional[0], &opts)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	kind := cr.GetObjectKind().GroupVersionKind().Kind
+	if err := c.Create(ctx, cr); err != nil {
+		return err
+	}
+	fmt.Printf("%v/%v created\n", kind, cr.GetName())
+
+	if !*watch {
+		return nil
+	}
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	return watchBenchmark(ctx, c, kind, cr, watchInterval)
+}
+
+// watchInterval is the polling interval of following the benchmarks
+const watchInterval = 2 * time.Second
@@ -0,0 +1,160 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/cli"
+)
+
+// runWatch follows the given benchmark until it finishes
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: kubectl stone watch <kind> <name> [flags]\n\n")
+		fs.PrintDefaults()
+	}
+	var cluster cli.ClusterFlags
+	cluster.Register(fs)
+	timeout := fs.Duration("timeout", 0, "The maximum time of following the benchmark.")
+	positional, _, err := cli.ParseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	c, namespace, err := cluster.NewClient()
+	if err != nil {
+		return err
+	}
+	s, err := cli.Scheme()
+	if err != nil {
+		return err
+	}
+	cr, err := cli.NewBenchmark(s, positional[0])
+	if err != nil {
+		return err
+	}
+	cr.SetNamespace(namespace)
+	cr.SetName(positional[1])
+
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	return watchBenchmark(ctx, c, cr.GetObjectKind().GroupVersionKind().Kind, cr, watchInterval)
+}
+
+// watchBenchmark polls the benchmark and prints its phase transitions,
+// condition changes and events until it finishes. An error is returned
+// when the benchmark failed or regressed.
+func watchBenchmark(ctx context.Context, c client.Client, kind string, cr perfv1alpha1.Benchmark,
+	interval time.Duration) error {
+	nn := types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}
+	lastPhase := ""
+	conditions := map[perfv1alpha1.BenchmarkConditionType]corev1.ConditionStatus{}
+	seenEvents := map[types.UID]bool{}
+
+	for {
+		if err := c.Get(ctx, nn, cr); err != nil {
+			return err
+		}
+		status := cr.GetBenchmarkStatus()
+
+		var events corev1.EventList
+		err := c.List(ctx, &events, client.InNamespace(nn.Namespace),
+			client.MatchingField("involvedObject.name", nn.Name))
+		if err != nil {
+			return err
+		}
+		sort.SliceStable(events.Items, func(i, j int) bool {
+			return events.Items[i].LastTimestamp.Before(&events.Items[j].LastTimestamp)
+		})
+		for _, event := range events.Items {
+			if event.InvolvedObject.Kind != kind || seenEvents[event.UID] {
+				continue
+			}
+			seenEvents[event.UID] = true
+			fmt.Printf("%v  %v  %v: %v\n", event.LastTimestamp.Format(time.RFC3339),
+				event.Type, event.Reason, event.Message)
+		}
+
+		phase := status.Phase()
+		if status.QueuePosition > 0 && !status.Running {
+			phase = fmt.Sprintf("%v (position %v)", phase, status.QueuePosition)
+		}
+		if phase != lastPhase {
+			fmt.Printf("%v  %v/%v  %v\n", time.Now().Format(time.RFC3339), kind, nn.Name, phase)
+			lastPhase = phase
+		}
+		for _, condition := range status.Conditions {
+			if conditions[condition.Type] != condition.Status {
+				conditions[condition.Type] = condition.Status
+				fmt.Printf("%v  %v/%v  %v=%v %v\n", condition.LastTransitionTime.Format(time.RFC3339),
+					kind, nn.Name, condition.Type, condition.Status, condition.Message)
+			}
+		}
+
+		if status.Completed || status.Cancelled {
+			if status.DryRun != nil {
+				writeDryRun(status.DryRun)
+			}
+			if status.Failed {
+				return fmt.Errorf("%v/%v failed", kind, nn.Name)
+			}
+			if regressed := status.GetCondition(perfv1alpha1.RegressedCondition); regressed != nil &&
+				regressed.Status == corev1.ConditionTrue {
+				return fmt.Errorf("%v/%v regressed", kind, nn.Name)
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// writeDryRun prints the objects submitted with server-side dry-run
+func writeDryRun(dryRun *perfv1alpha1.DryRunStatus) {
+	table := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "KIND\tNAME\tACCEPTED\tREASON\tMESSAGE")
+	for _, object := range dryRun.Objects {
+		fmt.Fprintf(table, "%v\t%v\t%v\t%v\t%v\n", object.Kind, object.Name, object.Accepted,
+			object.Reason, object.Message)
+	}
+	_ = table.Flush()
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/cli"
+	"github.com/xridge/kubestone/pkg/report"
+)
+
+// runCompare compares the results of two or more runs to the first one
+func runCompare(args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: kubestone compare <baseline> <result> [<result>...] [flags]\n\n"+
+			"The results are given as exported JSON or YAML files, BenchmarkResult names,\n"+
+			"benchmark names (the latest result of the benchmark) or <kind>/<name> of\n"+
+			"finished benchmarks.\n\n")
+		fs.PrintDefaults()
+	}
+	var cluster cli.ClusterFlags
+	cluster.Register(fs)
+	output := fs.String("o", "text", "Output format: text, markdown or json.")
+	references, _, err := cli.ParseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(references) < 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if *output != "text" && *output != "markdown" && *output != "json" {
+		return fmt.Errorf("Unknown output format: %v", *output)
+	}
+	s, err := cli.Scheme()
+	if err != nil {
+		return err
+	}
+
+	var results []perfv1alpha1.BenchmarkResult
+	var c client.Client
+	var namespace string
+	for _, reference := range references {
+		if info, err := os.Stat(reference); err == nil && !info.IsDir() {
+			fileResults, err := readResultFiles([]string{reference})
+			if err != nil {
+				return fmt.Errorf("%v: %v", reference, err)
+			}
+			results = append(results, fileResults...)
+			continue
+		}
+
+		if c == nil {
+			if c, namespace, err = cluster.NewClient(); err != nil {
+				return err
+			}
+		}
+		result, err := cli.FindResult(context.Background(), c, s, namespace, reference)
+		if err != nil {
+			return err
+		}
+		results = append(results, *result)
+	}
+
+	comparison, err := report.Compare(results)
+	if err != nil {
+		return err
+	}
+	switch *output {
+	case "text":
+		return comparison.WriteText(os.Stdout)
+	case "markdown":
+		return comparison.WriteMarkdown(os.Stdout)
+	default:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(comparison)
+	}
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command kubestone contains the tools working with the benchmarks and
+// their results outside of the operator.
+package main
+
+import (
+	"github.com/xridge/kubestone/pkg/cli"
+)
+
+func main() {
+	cli.Main("kubestone", map[string]cli.Command{
+		"compare": {Description: "Compare the metrics of benchmark results", Run: runCompare},
+		"render":  {Description: "Print the Kubernetes objects of benchmarks without a cluster", Run: runRender},
+		"report":  {Description: "Render an HTML report comparing benchmark results", Run: runReport},
+	})
+}
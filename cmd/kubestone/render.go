@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/cli"
+	"github.com/xridge/kubestone/pkg/render"
+)
+
+// runRender prints the Kubernetes objects the controllers would create
+// for the benchmarks read from the files, without a cluster
+func runRender(args []string) error {
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	var files cli.StringList
+	fs.Var(&files, "f", "Benchmark custom resources (YAML or JSON), repeatable. - reads the standard input.")
+	namespace := fs.String("n", "", "Namespace of the benchmarks not setting their namespace.")
+	var varFlags cli.StringList
+	fs.Var(&varFlags, "var", "Variable of the templated benchmarks as name=value, repeatable. "+
+		"Replaces the ConfigMap of the variables.")
+	_ = fs.Parse(args)
+	if len(files) == 0 {
+		return fmt.Errorf("No benchmark given, use -f")
+	}
+
+	vars := map[string]string{}
+	for _, variable := range varFlags {
+		parts := strings.SplitN(variable, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("Invalid variable %q, use name=value", variable)
+		}
+		vars[parts[0]] = parts[1]
+	}
+
+	s, err := cli.Scheme()
+	if err != nil {
+		return err
+	}
+	var benchmarks []render.Benchmark
+	var profiles []perfv1alpha1.BenchmarkProfile
+	for _, path := range files {
+		fileBenchmarks, fileProfiles, err := readBenchmarkFile(s, path)
+		if err != nil {
+			return err
+		}
+		benchmarks = append(benchmarks, fileBenchmarks...)
+		profiles = append(profiles, fileProfiles...)
+	}
+
+	var objects []runtime.Object
+	for _, cr := range benchmarks {
+		if cr.GetNamespace() == "" {
+			cr.SetNamespace(*namespace)
+		}
+		err := render.ApplyProfile(s, cr, profiles)
+		if err == nil {
+			err = render.ResolveVariables(cr.Benchmark, vars)
+		}
+		if err != nil {
+			return fmt.Errorf("%v/%v: %v", cr.GetObjectKind().GroupVersionKind().Kind, cr.GetName(), err)
+		}
+		crObjects, err := render.Objects(cr.Benchmark)
+		if err != nil {
+			return fmt.Errorf("%v/%v: %v", cr.GetObjectKind().GroupVersionKind().Kind, cr.GetName(), err)
+		}
+		objects = append(objects, crObjects...)
+	}
+	return render.WriteYAML(os.Stdout, s, objects)
+}
+
+func readBenchmarkFile(s *runtime.Scheme, path string) ([]render.Benchmark,
+	[]perfv1alpha1.BenchmarkProfile, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer file.Close()
+		r = file
+	}
+	benchmarks, profiles, err := render.ReadBenchmarks(s, r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: %v", path, err)
+	}
+	return benchmarks, profiles, nil
+}
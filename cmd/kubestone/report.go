@@ -0,0 +1,118 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"strings"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/cli"
+	"github.com/xridge/kubestone/pkg/report"
+)
+
+// runReport renders the HTML report of the results read from the
+// exported files or, without files, from the cluster
+func runReport(args []string) error {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	var cluster cli.ClusterFlags
+	cluster.Register(fs)
+	var files cli.StringList
+	fs.Var(&files, "f", "Exported BenchmarkResults (JSON or YAML), repeatable. "+
+		"The results are read from the cluster if not given.")
+	selector := fs.String("l", "", "Label selector of the BenchmarkResults read from the cluster.")
+	kind := fs.String("kind", "", "Kind of the benchmarks, e.g. fio. Every kind is included if empty.")
+	baseline := fs.String("baseline", "", "Name of the BenchmarkResult the other runs are compared to.")
+	groupBy := fs.String("group-by", report.DefaultGroupBy,
+		"Comma separated dimensions grouping the runs: storageClass, nodes, label:<key> or spec:<path>.")
+	title := fs.String("title", "Kubestone benchmark report", "Title of the report.")
+	output := fs.String("o", "", "The file the report is written to. The standard output is used if empty.")
+	_ = fs.Parse(args)
+
+	dimensions, err := report.ParseDimensions(*groupBy)
+	if err != nil {
+		return err
+	}
+
+	var results []perfv1alpha1.BenchmarkResult
+	if len(files) > 0 {
+		results, err = readResultFiles(files)
+	} else {
+		results, err = listClusterResults(&cluster, *selector, *kind)
+	}
+	if err != nil {
+		return err
+	}
+	results = filterKind(results, *kind)
+
+	r, err := report.New(*title, results, dimensions, *baseline)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	return r.WriteHTML(w)
+}
+
+func readResultFiles(paths []string) ([]perfv1alpha1.BenchmarkResult, error) {
+	var results []perfv1alpha1.BenchmarkResult
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		fileResults, err := report.ReadResults(file)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, fileResults...)
+	}
+	return results, nil
+}
+
+func listClusterResults(cluster *cli.ClusterFlags, selector, kind string) ([]perfv1alpha1.BenchmarkResult, error) {
+	c, namespace, err := cluster.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	return cli.ListResults(context.Background(), c, namespace, selector, kind)
+}
+
+func filterKind(results []perfv1alpha1.BenchmarkResult, kind string) []perfv1alpha1.BenchmarkResult {
+	if kind == "" {
+		return results
+	}
+	filtered := []perfv1alpha1.BenchmarkResult{}
+	for _, result := range results {
+		if strings.EqualFold(result.Spec.Benchmark.Kind, kind) {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
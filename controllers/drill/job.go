@@ -93,6 +93,8 @@ func NewJob(cr *perfv1alpha1.Drill, configMap *corev1.ConfigMap) *batchv1.Job {
 	job.Spec.Template.Spec.Containers[0].Command = cr.Spec.Command
 	job.Spec.Template.Spec.Containers[0].Args = args
 	job.Spec.Template.Spec.Containers[0].VolumeMounts = volumeMounts
+	k8s.ApplyIterations(job, &cr.Spec.RunPolicySpec)
+	k8s.ApplyTimeout(job, &cr.Spec.RunPolicySpec)
 	return job
 }
 
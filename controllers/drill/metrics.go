@@ -0,0 +1,69 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drill
+
+import (
+	"errors"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/metrics"
+)
+
+const (
+	requestsPerSecondMetric = "kubestone_drill_requests_per_second"
+	requestTimeMetric       = "kubestone_drill_request_time_seconds"
+)
+
+func init() {
+	metrics.RegisterResult(requestsPerSecondMetric,
+		"Requests per second sent by the drill benchmark.")
+	metrics.RegisterResult(requestTimeMetric,
+		"Response time of the requests of the drill benchmark.", "stat")
+}
+
+// ParseMetrics converts the results found in the logs of the drill
+// pods to benchmark metrics
+func ParseMetrics(logs map[string]string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	for _, pod := range k8s.PodNames(logs) {
+		log := logs[pod]
+		result, err := ParseResults(log)
+		if err != nil {
+			// Failed pods (retried by the job) have no results
+			continue
+		}
+
+		return []perfv1alpha1.BenchmarkMetric{
+			{
+				Name:  requestsPerSecondMetric,
+				Value: metrics.FormatValue(result.RequestsPerSecond),
+			},
+			{
+				Name:   requestTimeMetric,
+				Labels: map[string]string{"stat": "median"},
+				Value:  metrics.FormatValue(result.MedianSeconds),
+			},
+			{
+				Name:   requestTimeMetric,
+				Labels: map[string]string{"stat": "average"},
+				Value:  metrics.FormatValue(result.AverageSeconds),
+			},
+		}, nil
+	}
+
+	return nil, errors.New("No drill results found in the logs")
+}
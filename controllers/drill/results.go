@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drill
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Result contains the summary of the drill benchmark
+type Result struct {
+	RequestsPerSecond float64
+	// MedianSeconds and AverageSeconds are the response times
+	MedianSeconds  float64
+	AverageSeconds float64
+}
+
+// colorRegexp matches the ANSI color codes of the output
+var colorRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// summaryRegexp matches the lines of the summary printed with --stats,
+// e.g. "Requests per second       66.99 [#/sec]". The statistics of the
+// individual requests are prefixed with the name of the request.
+var summaryRegexp = regexp.MustCompile(
+	`^(Requests per second|Median time per request|Average time per request)\s+([\d.]+)\s*(ms)?`)
+
+// ParseResults extracts the summary from the output of drill, which is
+// only printed when the --stats option is given
+func ParseResults(output string) (*Result, error) {
+	var result Result
+	found := map[string]bool{}
+	for _, line := range strings.Split(colorRegexp.ReplaceAllString(output, ""), "\n") {
+		match := summaryRegexp.FindStringSubmatch(strings.TrimRight(line, " \r"))
+		if match == nil {
+			continue
+		}
+		value, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "ms" {
+			value /= 1000
+		}
+		switch match[1] {
+		case "Requests per second":
+			result.RequestsPerSecond = value
+		case "Median time per request":
+			result.MedianSeconds = value
+		case "Average time per request":
+			result.AverageSeconds = value
+		}
+		found[match[1]] = true
+	}
+
+	if len(found) < 3 {
+		return nil, errors.New("No drill summary found in the output, is --stats set?")
+	}
+	return &result, nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drill
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const statsSampleOutput = `Concurrency 4
+Iterations 20
+Rampup 2
+Base URL http://localhost:9000
+
+Fetch users               http://localhost:9000/api/users 200 OK 9ms
+Fetch users               http://localhost:9000/api/users 200 OK 11ms
+
+Fetch users               Total requests            20
+Fetch users               Successful requests       20
+Fetch users               Failed requests           0
+Fetch users               Median time per request   9ms
+Fetch users               Average time per request  14ms
+Fetch users               Sample standard deviation 11ms
+
+Time taken for tests      0.3 seconds
+Total requests            20
+Successful requests       20
+Failed requests           0
+Requests per second       66.99 [#/sec]
+Median time per request   10ms
+Average time per request  15ms
+Sample standard deviation 11ms
+`
+
+var _ = Describe("drill results", func() {
+	It("should parse the summary", func() {
+		result, err := ParseResults(statsSampleOutput)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(&Result{
+			RequestsPerSecond: 66.99,
+			MedianSeconds:     0.010,
+			AverageSeconds:    0.015,
+		}))
+	})
+
+	It("should ignore the colors", func() {
+		result, err := ParseResults("\x1b[33mRequests per second\x1b[0m       \x1b[32m66.99\x1b[0m [#/sec]\n" +
+			"Median time per request   10ms\nAverage time per request  15ms\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequestsPerSecond).To(Equal(66.99))
+	})
+
+	It("should fail without --stats", func() {
+		_, err := ParseResults("Fetch users http://localhost:9000/api/users 200 OK 9ms\n")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -91,5 +91,8 @@ func NewClientJob(cr *perfv1alpha1.Ethr, serverAddress string) *batchv1.Job {
 	job.Spec.Template.Spec.Containers[0].Args = ethrCmdLineArgs
 	job.Spec.Template.Spec.HostNetwork = cr.Spec.ClientConfiguration.HostNetwork
 
+	k8s.ApplyIterations(job, &cr.Spec.RunPolicySpec)
+	k8s.ApplyTimeout(job, &cr.Spec.RunPolicySpec)
+
 	return job
 }
@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ethr
+
+import (
+	"errors"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/metrics"
+)
+
+// rateMetrics are the metrics of the measures reported by ethr
+var rateMetrics = map[string]string{
+	"Bits/s": "kubestone_ethr_bits_per_second",
+	"Conn/s": "kubestone_ethr_connections_per_second",
+	"Pkts/s": "kubestone_ethr_packets_per_second",
+}
+
+func init() {
+	metrics.RegisterResult(rateMetrics["Bits/s"],
+		"Throughput measured by the ethr benchmark.", "protocol")
+	metrics.RegisterResult(rateMetrics["Conn/s"],
+		"Connections per second measured by the ethr benchmark.", "protocol")
+	metrics.RegisterResult(rateMetrics["Pkts/s"],
+		"Packets per second measured by the ethr benchmark.", "protocol")
+}
+
+// ParseMetrics converts the results found in the logs of the ethr
+// client pods to benchmark metrics
+func ParseMetrics(logs map[string]string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	for _, pod := range k8s.PodNames(logs) {
+		log := logs[pod]
+		result, err := ParseResults(log)
+		if err != nil {
+			// Failed pods (retried by the job) have no results
+			continue
+		}
+		name, ok := rateMetrics[result.Measure]
+		if !ok {
+			return nil, errors.New("Unsupported ethr measure: " + result.Measure)
+		}
+
+		return []perfv1alpha1.BenchmarkMetric{{
+			Name:   name,
+			Labels: map[string]string{"protocol": result.Protocol},
+			Value:  metrics.FormatValue(result.Rate),
+		}}, nil
+	}
+
+	return nil, errors.New("No ethr results found in the logs")
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ethr
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Result contains the mean rate measured by the ethr client
+type Result struct {
+	// Measure is the column of the rate in the output, e.g. Bits/s,
+	// Conn/s or Pkts/s depending on the type of the test
+	Measure  string
+	Protocol string
+	Rate     float64
+}
+
+// headerRegexp matches the header of the periodic reports, e.g.
+// "[ ID]   Protocol    Interval      Bits/s"
+var headerRegexp = regexp.MustCompile(`^\[\s*ID\s*\]\s+Protocol\s+Interval\s+(\S+)\s*$`)
+
+// reportRegexp matches the periodic reports of the streams and their
+// sum, e.g. "[SUM]     TCP      000-001 sec     3.99G"
+var reportRegexp = regexp.MustCompile(
+	`^\[\s*(SUM|\d+)\]\s+(\S+)\s+(\d+)-(\d+)\s+sec\s+([\d.]+)([KMGT]?)\s*$`)
+
+var unitMultipliers = map[string]float64{
+	"": 1, "K": 1e3, "M": 1e6, "G": 1e9, "T": 1e12,
+}
+
+// ParseResults extracts the mean rate of the test from the output of
+// the ethr client. The rate of an interval is the value of its [SUM]
+// report, or of its single stream when only one stream is used.
+// Latency tests are not supported.
+func ParseResults(output string) (*Result, error) {
+	var measure, protocol string
+	var intervals []string
+	perInterval := map[string]float64{}
+	sumReported := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if match := headerRegexp.FindStringSubmatch(line); match != nil {
+			measure = match[1]
+			continue
+		}
+		match := reportRegexp.FindStringSubmatch(line)
+		if match == nil || measure == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(match[5], 64)
+		if err != nil {
+			return nil, err
+		}
+		value *= unitMultipliers[match[6]]
+
+		interval := match[3] + "-" + match[4]
+		if _, seen := perInterval[interval]; !seen {
+			intervals = append(intervals, interval)
+		}
+		protocol = match[2]
+		if match[1] == "SUM" {
+			perInterval[interval] = value
+			sumReported[interval] = true
+		} else if !sumReported[interval] {
+			perInterval[interval] += value
+		}
+	}
+
+	if len(intervals) == 0 {
+		return nil, errors.New("No ethr results found in the output")
+	}
+	var total float64
+	for _, interval := range intervals {
+		total += perInterval[interval]
+	}
+	return &Result{Measure: measure, Protocol: protocol, Rate: total / float64(len(intervals))}, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ethr
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const singleStreamSampleOutput = `Connecting to host [10.0.0.3], port 9999
+[  6] local 10.0.0.2 port 53690 connected to 10.0.0.3 port 9999
+- - - - - - - - - - - - - - - - - - - - - - -
+[ ID]   Protocol    Interval      Bits/s
+[  6]     TCP      000-001 sec     1.20G
+[  6]     TCP      001-002 sec     1.00G
+Ethr done, duration: 2s.
+`
+
+const parallelSampleOutput = `- - - - - - - - - - - - - - - - - - - - - - -
+[ ID]   Protocol    Interval      Bits/s
+[  6]     TCP      000-001 sec     1.00G
+[  7]     TCP      000-001 sec     1.00G
+[SUM]     TCP      000-001 sec     2.00G
+- - - - - - - - - - - - - - - - - - - - - - -
+[  6]     TCP      001-002 sec     2.00G
+[  7]     TCP      001-002 sec     2.00G
+[SUM]     TCP      001-002 sec     4.00G
+`
+
+const connectionsSampleOutput = `[ ID]   Protocol    Interval      Conn/s
+[SUM]     TCP      000-001 sec      20K
+[SUM]     TCP      001-002 sec      22K
+`
+
+var _ = Describe("ethr results", func() {
+	It("should average the intervals of a single stream", func() {
+		Expect(ParseResults(singleStreamSampleOutput)).To(Equal(&Result{
+			Measure: "Bits/s", Protocol: "TCP", Rate: 1.1e9,
+		}))
+	})
+
+	It("should use the [SUM] reports of parallel streams", func() {
+		Expect(ParseResults(parallelSampleOutput)).To(Equal(&Result{
+			Measure: "Bits/s", Protocol: "TCP", Rate: 3e9,
+		}))
+	})
+
+	It("should report the measure of the test", func() {
+		Expect(ParseResults(connectionsSampleOutput)).To(Equal(&Result{
+			Measure: "Conn/s", Protocol: "TCP", Rate: 21e3,
+		}))
+	})
+
+	It("should fail without results", func() {
+		_, err := ParseResults("Error: dial tcp 10.0.0.3:9999: connect: connection refused\n")
+		Expect(err).To(HaveOccurred())
+	})
+})
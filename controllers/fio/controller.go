@@ -17,12 +17,11 @@ limitations under the License.
 package fio
 
 import (
-	"context"
-
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
 	"github.com/xridge/kubestone/pkg/k8s"
@@ -43,15 +42,35 @@ type Reconciler struct {
 
 // Reconcile creates fio job(s) based on the custom resource(s)
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
+	ctx, cancel := r.K8S.NewReconcileContext()
+	defer cancel()
 
 	var cr perfv1alpha1.Fio
 	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+		return ctrl.Result{}, k8s.IgnoreDeleted(req.NamespacedName, err)
+	}
+
+	// Merge the referenced profile into the spec
+	if err := r.K8S.ApplyProfile(ctx, &cr); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Resolve the templated fields, invalid CRs are not requeued
+	if valid, err := r.K8S.ResolveVariables(ctx, &cr); !valid {
+		return ctrl.Result{}, err
 	}
 
 	// Run to one completion
-	if cr.Status.Completed {
+	if cr.Status.Completed || cr.Status.Cancelled {
+		return r.K8S.CleanupFinished(ctx, &cr)
+	}
+
+	if cr.Spec.Cancel {
+		return ctrl.Result{}, r.K8S.CancelBenchmark(ctx, &cr, NewJob(&cr))
+	}
+
+	// Suspended benchmarks are not started until they are resumed
+	if cr.Spec.Suspend && !cr.Status.Running {
 		return ctrl.Result{}, nil
 	}
 
@@ -66,8 +85,23 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		}
 	}
 
-	cr.Status.Running = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
+	// Submit the objects with server-side dry-run instead of running the benchmark
+	if cr.Spec.DryRun && !cr.Status.Running {
+		objects, err := NewObjects(&cr)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.K8S.DryRunBenchmark(ctx, &cr, objects...)
+	}
+
+	// Wait for the earlier benchmarks of the same exclusivity scope
+	if !cr.Status.Running {
+		if locked, err := r.K8S.AcquireLock(ctx, &cr); !locked {
+			return ctrl.Result{RequeueAfter: k8s.LockRetryInterval}, err
+		}
+	}
+
+	if err := r.K8S.StartBenchmark(ctx, &cr); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -92,7 +126,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	}
 
 	// Check if finished
-	jobFinished, err := r.K8S.IsJobFinished(types.NamespacedName{
+	jobFinished, err := r.K8S.IsJobFinished(ctx, types.NamespacedName{
 		Namespace: cr.Namespace,
 		Name:      cr.Name,
 	})
@@ -106,11 +140,9 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	// The cr could have been modified since the last time we got it
 	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+		return ctrl.Result{}, k8s.IgnoreDeleted(req.NamespacedName, err)
 	}
-	cr.Status.Running = false
-	cr.Status.Completed = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
+	if err := r.K8S.CompleteBenchmark(ctx, &cr, ParseMetrics, cr.Name); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -118,8 +150,10 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 }
 
 // SetupWithManager registers the Reconciler with the provided manager
-func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&perfv1alpha1.Fio{}).
+		WithOptions(options).
+		WithEventFilter(r.K8S.NamespacePredicate()).
 		Complete(r)
 }
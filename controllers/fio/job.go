@@ -72,6 +72,8 @@ func NewJob(cr *perfv1alpha1.Fio) *batchv1.Job {
 	job.Spec.Template.Spec.Volumes = volumes
 	job.Spec.Template.Spec.Containers[0].Args = fioCmdLineArgs
 	job.Spec.Template.Spec.Containers[0].VolumeMounts = volumeMounts
+	k8s.ApplyIterations(job, &cr.Spec.RunPolicySpec)
+	k8s.ApplyTimeout(job, &cr.Spec.RunPolicySpec)
 	return job
 }
 
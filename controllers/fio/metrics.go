@@ -0,0 +1,69 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fio
+
+import (
+	"errors"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/metrics"
+)
+
+const (
+	iopsMetric      = "kubestone_fio_iops"
+	bandwidthMetric = "kubestone_fio_bandwidth_bytes_per_second"
+)
+
+func init() {
+	metrics.RegisterResult(iopsMetric,
+		"I/O operations per second measured by the fio benchmark.", "job", "rw")
+	metrics.RegisterResult(bandwidthMetric,
+		"Bandwidth measured by the fio benchmark.", "job", "rw")
+}
+
+// ParseMetrics converts the results found in the logs of the fio pods
+// to benchmark metrics
+func ParseMetrics(logs map[string]string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	for _, pod := range k8s.PodNames(logs) {
+		log := logs[pod]
+		results, err := ParseResults(log)
+		if err != nil {
+			// Failed pods (retried by the job) have no results
+			continue
+		}
+
+		var benchmarkMetrics []perfv1alpha1.BenchmarkMetric
+		for _, result := range results {
+			labels := map[string]string{"job": result.Job, "rw": result.RW}
+			benchmarkMetrics = append(benchmarkMetrics,
+				perfv1alpha1.BenchmarkMetric{
+					Name:   iopsMetric,
+					Labels: labels,
+					Value:  metrics.FormatValue(result.IOPS),
+				},
+				perfv1alpha1.BenchmarkMetric{
+					Name:   bandwidthMetric,
+					Labels: labels,
+					Value:  metrics.FormatValue(result.BandwidthBytesPerSecond),
+				})
+		}
+		return benchmarkMetrics, nil
+	}
+
+	return nil, errors.New("No fio results found in the logs")
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fio
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Result contains the performance of a single fio job for one
+// of the I/O directions (read, write or trim)
+type Result struct {
+	Job                     string
+	RW                      string
+	IOPS                    float64
+	BandwidthBytesPerSecond float64
+}
+
+var (
+	jobHeaderRegexp = regexp.MustCompile(`^(\S+): \(groupid=\d+`)
+	rwResultRegexp  = regexp.MustCompile(
+		`^\s+(read|write|trim)\s*: IOPS=([\d.]+)([kMG]?), BW=([\d.]+)([KMGT]i?)?B/s`)
+)
+
+var unitMultipliers = map[string]float64{
+	"": 1, "k": 1e3, "K": 1e3, "M": 1e6, "G": 1e9, "T": 1e12,
+	"Ki": 1 << 10, "Mi": 1 << 20, "Gi": 1 << 30, "Ti": 1 << 40,
+}
+
+// ParseResults extracts the per job results from the output of fio.
+// Both the default (normal) and the json output formats are supported.
+func ParseResults(output string) ([]Result, error) {
+	if strings.HasPrefix(output, "{") {
+		return parseJSONResults(output)
+	}
+	if start := strings.Index(output, "\n{"); start >= 0 {
+		return parseJSONResults(output[start+1:])
+	}
+	return parseNormalResults(output)
+}
+
+func parseNormalResults(output string) ([]Result, error) {
+	var results []Result
+	job := ""
+	for _, line := range strings.Split(output, "\n") {
+		if match := jobHeaderRegexp.FindStringSubmatch(line); match != nil {
+			job = match[1]
+			continue
+		}
+
+		match := rwResultRegexp.FindStringSubmatch(line)
+		if match == nil || job == "" {
+			continue
+		}
+		iops, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			return nil, err
+		}
+		bandwidth, err := strconv.ParseFloat(match[4], 64)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, Result{
+			Job:                     job,
+			RW:                      match[1],
+			IOPS:                    iops * unitMultipliers[match[3]],
+			BandwidthBytesPerSecond: bandwidth * unitMultipliers[match[5]],
+		})
+	}
+
+	if len(results) == 0 {
+		return nil, errors.New("No fio results found in the output")
+	}
+	return results, nil
+}
+
+type jsonRWResult struct {
+	IOPS float64 `json:"iops"`
+	// BW is the bandwidth in KiB/s
+	BW float64 `json:"bw"`
+}
+
+type jsonOutput struct {
+	Jobs []struct {
+		JobName string       `json:"jobname"`
+		Read    jsonRWResult `json:"read"`
+		Write   jsonRWResult `json:"write"`
+		Trim    jsonRWResult `json:"trim"`
+	} `json:"jobs"`
+}
+
+func parseJSONResults(output string) ([]Result, error) {
+	var parsed jsonOutput
+	if err := json.NewDecoder(strings.NewReader(output)).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, job := range parsed.Jobs {
+		for _, direction := range []struct {
+			rw     string
+			result jsonRWResult
+		}{{"read", job.Read}, {"write", job.Write}, {"trim", job.Trim}} {
+			rw, result := direction.rw, direction.result
+			// Directions without any I/O are not reported
+			if result.IOPS == 0 && result.BW == 0 {
+				continue
+			}
+			results = append(results, Result{
+				Job:                     job.JobName,
+				RW:                      rw,
+				IOPS:                    result.IOPS,
+				BandwidthBytesPerSecond: result.BW * 1024,
+			})
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, errors.New("No fio results found in the output")
+	}
+	return results, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fio
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const normalSampleOutput = `randwrite: (g=0): rw=randwrite, bs=(R) 4096KiB-4096KiB, (W) 4096KiB-4096KiB, (T) 4096KiB-4096KiB, ioengine=psync, iodepth=1
+fio-3.13
+Starting 1 process
+
+randwrite: (groupid=0, jobs=1): err= 0: pid=47: Sat Aug 24 17:58:10 2019
+  write: IOPS=470, BW=1882MiB/s (1974MB/s)(256MiB/136msec); 0 zone resets
+    clat (usec): min=1887, max=2595, avg=2042.76, stdev=136.56
+randread: (groupid=1, jobs=1): err= 0: pid=48: Sat Aug 24 17:58:12 2019
+   read: IOPS=12.5k, BW=48.8MiB/s (51.2MB/s)(256MiB/5243msec)
+
+Run status group 0 (all jobs):
+  WRITE: bw=1882MiB/s (1974MB/s), 1882MiB/s-1882MiB/s (1974MB/s-1974MB/s), io=256MiB (268MB), run=136-136msec
+`
+
+const jsonSampleOutput = `note: both iodepth >= 1 and synchronous I/O engine are selected
+{
+  "fio version" : "fio-3.13",
+  "jobs" : [
+    {
+      "jobname" : "randrw",
+      "read" : { "iops" : 1000.5, "bw" : 4002 },
+      "write" : { "iops" : 500, "bw" : 2000 },
+      "trim" : { "iops" : 0, "bw" : 0 }
+    }
+  ]
+}
+`
+
+var _ = Describe("fio results", func() {
+	Context("with normal output", func() {
+		It("should parse the results of every job", func() {
+			results, err := ParseResults(normalSampleOutput)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal([]Result{
+				{Job: "randwrite", RW: "write", IOPS: 470, BandwidthBytesPerSecond: 1882 * (1 << 20)},
+				{Job: "randread", RW: "read", IOPS: 12500, BandwidthBytesPerSecond: 48.8 * (1 << 20)},
+			}))
+		})
+	})
+
+	Context("with json output", func() {
+		It("should parse the directions with I/O in a stable order", func() {
+			results, err := ParseResults(jsonSampleOutput)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal([]Result{
+				{Job: "randrw", RW: "read", IOPS: 1000.5, BandwidthBytesPerSecond: 4002 * 1024},
+				{Job: "randrw", RW: "write", IOPS: 500, BandwidthBytesPerSecond: 2000 * 1024},
+			}))
+		})
+	})
+
+	Context("without results", func() {
+		It("should fail", func() {
+			_, err := ParseResults("fio: failed to open file\n")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -17,11 +17,10 @@ limitations under the License.
 package iperf2
 
 import (
-	"context"
-
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	"github.com/xridge/kubestone/pkg/k8s"
 
@@ -34,9 +33,9 @@ type Reconciler struct {
 	Log logr.Logger
 }
 
-// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=Iperf2s,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=Iperf2s/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=Iperf2s/finalizers,verbs=update
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=iperf2s,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=iperf2s/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=iperf2s/finalizers,verbs=update
 
 // Reconcile Iperf2 Benchmark Requests by creating:
 //   - iperf2 server deployment
@@ -46,20 +45,56 @@ type Reconciler struct {
 // deployment completes. Once the iperf2 client pod is completed,
 // the server deployment and service objects are removed from k8s.
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
+	ctx, cancel := r.K8S.NewReconcileContext()
+	defer cancel()
 
 	var cr perfv1alpha1.Iperf2
 	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+		return ctrl.Result{}, k8s.IgnoreDeleted(req.NamespacedName, err)
+	}
+
+	// Merge the referenced profile into the spec
+	if err := r.K8S.ApplyProfile(ctx, &cr); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Resolve the templated fields, invalid CRs are not requeued
+	if valid, err := r.K8S.ResolveVariables(ctx, &cr); !valid {
+		return ctrl.Result{}, err
 	}
 
 	// Run to one completion
-	if cr.Status.Completed {
+	if cr.Status.Completed || cr.Status.Cancelled {
+		return r.K8S.CleanupFinished(ctx, &cr)
+	}
+
+	if cr.Spec.Cancel {
+		return ctrl.Result{}, r.K8S.CancelBenchmark(ctx, &cr, NewClientJob(&cr, ""),
+			NewServerService(&cr), NewServerDeployment(&cr))
+	}
+
+	// Suspended benchmarks are not started until they are resumed
+	if cr.Spec.Suspend && !cr.Status.Running {
 		return ctrl.Result{}, nil
 	}
 
-	cr.Status.Running = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
+	// Submit the objects with server-side dry-run instead of running the benchmark
+	if cr.Spec.DryRun && !cr.Status.Running {
+		objects, err := NewObjects(&cr)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.K8S.DryRunBenchmark(ctx, &cr, objects...)
+	}
+
+	// Wait for the earlier benchmarks of the same exclusivity scope
+	if !cr.Status.Running {
+		if locked, err := r.K8S.AcquireLock(ctx, &cr); !locked {
+			return ctrl.Result{RequeueAfter: k8s.LockRetryInterval}, err
+		}
+	}
+
+	if err := r.K8S.StartBenchmark(ctx, &cr); err != nil {
 		return ctrl.Result{}, err
 	}
 		serverDeployment := NewServerDeployment(&cr)
@@ -72,7 +107,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			return ctrl.Result{}, err
 		}
 
-		endpointReady, err := r.K8S.IsEndpointReady(types.NamespacedName{
+		endpointReady, err := r.K8S.IsEndpointReady(ctx, types.NamespacedName{
 			Namespace: cr.Namespace,
 			Name:      cr.Name})
 		if err != nil {
@@ -83,14 +118,17 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			return ctrl.Result{Requeue: true}, nil
 		}
 
-		serviceIp := r.K8S.GetEndpointAddress(types.NamespacedName{
+		serviceIp, err := r.K8S.GetEndpointAddress(ctx, types.NamespacedName{
 			Namespace: cr.Namespace,
 			Name:      cr.Name})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
 		if err := r.K8S.CreateWithReference(ctx, NewClientJob(&cr, serviceIp), &cr); err != nil {
 			return ctrl.Result{}, err
 		}
 
-		jobFinished, err := r.K8S.IsJobFinished(types.NamespacedName{
+		jobFinished, err := r.K8S.IsJobFinished(ctx, types.NamespacedName{
 			Namespace: cr.Namespace,
 			Name:      clientJobName(&cr),
 		})
@@ -109,9 +147,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			return ctrl.Result{}, err
 		}
 
-	cr.Status.Running = false
-	cr.Status.Completed = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
+	if err := r.K8S.CompleteBenchmark(ctx, &cr, ParseMetrics, clientJobName(&cr)); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -119,8 +155,10 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 }
 
 // SetupWithManager registers the Iperf2Reconciler with the provided manager
-func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&perfv1alpha1.Iperf2{}).
+		WithOptions(options).
+		WithEventFilter(r.K8S.NamespacePredicate()).
 		Complete(r)
 }
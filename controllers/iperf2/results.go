@@ -0,0 +1,85 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iperf2
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// reportRegexp matches the reports of the default output format, e.g.
+// "[  3]  0.0-10.0 sec  1.10 GBytes   943 Mbits/sec"
+var reportRegexp = regexp.MustCompile(
+	`^\[\s*(SUM|\d+)\]\s+[\d.]+-\s*[\d.]+\s+sec\s+\S+\s+\S*Bytes\s+([\d.]+)\s+([KMGT]?)bits/sec`)
+
+var unitMultipliers = map[string]float64{
+	"": 1, "K": 1e3, "M": 1e6, "G": 1e9, "T": 1e12,
+}
+
+// ParseResults extracts the throughput in bits per second from the
+// output of the iperf2 client. Both the default and the CSV (-y C)
+// output formats are supported. The last report of every stream is
+// its summary, parallel streams (-P) are summarized in the [SUM] report.
+func ParseResults(output string) (float64, error) {
+	perStream := map[string]float64{}
+	for _, line := range strings.Split(output, "\n") {
+		stream, bitsPerSecond, ok := parseReport(strings.TrimSpace(line))
+		if ok {
+			perStream[stream] = bitsPerSecond
+		}
+	}
+
+	if sum, ok := perStream["SUM"]; ok {
+		return sum, nil
+	}
+	if len(perStream) == 0 {
+		return 0, errors.New("No iperf2 results found in the output")
+	}
+	var total float64
+	for _, bitsPerSecond := range perStream {
+		total += bitsPerSecond
+	}
+	return total, nil
+}
+
+// parseReport returns the stream and the throughput of a report line
+func parseReport(line string) (stream string, bitsPerSecond float64, ok bool) {
+	if match := reportRegexp.FindStringSubmatch(line); match != nil {
+		value, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			return "", 0, false
+		}
+		return match[1], value * unitMultipliers[match[3]], true
+	}
+
+	// timestamp,source,port,destination,port,stream,interval,bytes,bits/sec[,udp fields]
+	fields := strings.Split(line, ",")
+	if len(fields) < 9 {
+		return "", 0, false
+	}
+	value, err := strconv.ParseFloat(fields[8], 64)
+	if err != nil {
+		return "", 0, false
+	}
+	stream = fields[5]
+	if stream == "-1" {
+		stream = "SUM"
+	}
+	return stream, value, true
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iperf2
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const textSampleOutput = `------------------------------------------------------------
+Client connecting to iperf2-sample, TCP port 5001
+TCP window size: 85.0 KByte (default)
+------------------------------------------------------------
+[  3] local 10.0.0.2 port 40000 connected with 10.0.0.3 port 5001
+[ ID] Interval       Transfer     Bandwidth
+[  3]  0.0- 1.0 sec   113 MBytes   948 Mbits/sec
+[  3]  0.0-10.0 sec  1.10 GBytes   943 Mbits/sec
+`
+
+const parallelSampleOutput = `[ ID] Interval       Transfer     Bandwidth
+[  4]  0.0-10.0 sec   563 MBytes   472 Mbits/sec
+[  3]  0.0-10.0 sec   561 MBytes   470 Mbits/sec
+[SUM]  0.0-10.0 sec  1.10 GBytes   942 Mbits/sec
+`
+
+const csvSampleOutput = `20191010120000,10.0.0.2,40000,10.0.0.3,5001,4,0.0-10.0,590348288,472278630
+20191010120000,10.0.0.2,40002,10.0.0.3,5001,3,0.0-10.0,588251136,470600908
+20191010120000,10.0.0.2,0,10.0.0.3,5001,-1,0.0-10.0,1178599424,942879538
+`
+
+var _ = Describe("iperf2 results", func() {
+	It("should parse the summary of the text output", func() {
+		Expect(ParseResults(textSampleOutput)).To(Equal(943e6))
+	})
+
+	It("should use the [SUM] report of parallel streams", func() {
+		Expect(ParseResults(parallelSampleOutput)).To(Equal(942e6))
+	})
+
+	It("should parse the CSV output", func() {
+		Expect(ParseResults(csvSampleOutput)).To(Equal(942879538.0))
+	})
+
+	It("should fail without results", func() {
+		_, err := ParseResults("connect failed: Connection refused\n")
+		Expect(err).To(HaveOccurred())
+	})
+})
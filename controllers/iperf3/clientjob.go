@@ -95,5 +95,8 @@ func NewClientJob(cr *perfv1alpha1.Iperf3, serverAddress string) *batchv1.Job {
 	job.Spec.Template.Spec.Containers[0].Args = iperfCmdLineArgs
 	job.Spec.Template.Spec.HostNetwork = cr.Spec.ClientConfiguration.HostNetwork
 
+	k8s.ApplyIterations(job, &cr.Spec.RunPolicySpec)
+	k8s.ApplyTimeout(job, &cr.Spec.RunPolicySpec)
+
 	return job
 }
@@ -118,5 +118,15 @@ var _ = Describe("Client Pod", func() {
 				Expect(job.ObjectMeta.Annotations).To(HaveKey("annotation_one"))
 			})
 		})
+
+		Context("with iterations", func() {
+			It("should run the warm-up and the measured iterations", func() {
+				cr.Spec.Completions = 3
+				cr.Spec.Iterations = 5
+				cr.Spec.WarmupIterations = 1
+				job = NewClientJob(&cr, "1.1.1.1")
+				Expect(*job.Spec.Completions).To(Equal(int32(6)))
+			})
+		})
 	})
 })
@@ -0,0 +1,57 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iperf3
+
+import (
+	"errors"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/metrics"
+)
+
+const bitsPerSecondMetric = "kubestone_iperf3_bits_per_second"
+
+func init() {
+	metrics.RegisterResult(bitsPerSecondMetric,
+		"Throughput measured by the iperf3 benchmark.", "role")
+}
+
+// ParseMetrics converts the results found in the logs of the iperf3
+// client pods to benchmark metrics
+func ParseMetrics(logs map[string]string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	for _, pod := range k8s.PodNames(logs) {
+		log := logs[pod]
+		results, err := ParseResults(log)
+		if err != nil {
+			// Failed pods (retried by the job) have no results
+			continue
+		}
+
+		var benchmarkMetrics []perfv1alpha1.BenchmarkMetric
+		for _, result := range results {
+			benchmarkMetrics = append(benchmarkMetrics, perfv1alpha1.BenchmarkMetric{
+				Name:   bitsPerSecondMetric,
+				Labels: map[string]string{"role": result.Role},
+				Value:  metrics.FormatValue(result.BitsPerSecond),
+			})
+		}
+		return benchmarkMetrics, nil
+	}
+
+	return nil, errors.New("No iperf3 results found in the logs")
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iperf3
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Result contains the throughput measured by one side
+// (sender or receiver) of the iperf3 test
+type Result struct {
+	Role          string
+	BitsPerSecond float64
+}
+
+// summaryRegexp matches the summary lines printed at the end of the test,
+// e.g. "[SUM]   0.00-10.00  sec  1.09 GBytes   939 Mbits/sec    0   sender"
+var summaryRegexp = regexp.MustCompile(
+	`^\[\s*(SUM|\d+)\]\s+\S+\s+sec\s+\S+\s+\S*Bytes\s+([\d.]+)\s+([KMGT]?)bits/sec.*\s(sender|receiver)\s*$`)
+
+var unitMultipliers = map[string]float64{
+	"": 1, "K": 1e3, "M": 1e6, "G": 1e9, "T": 1e12,
+}
+
+// ParseResults extracts the sender and receiver throughput from the
+// output of the iperf3 client. Both the default and the json (-J)
+// output formats are supported.
+func ParseResults(output string) ([]Result, error) {
+	if strings.HasPrefix(strings.TrimSpace(output), "{") {
+		return parseJSONResults(output)
+	}
+	return parseTextResults(output)
+}
+
+func parseTextResults(output string) ([]Result, error) {
+	// Parallel streams (-P) are summarized in [SUM] lines, which
+	// take precedence over the per stream summaries
+	perStream := map[string]float64{}
+	sum := map[string]float64{}
+	for _, line := range strings.Split(output, "\n") {
+		match := summaryRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		value, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			return nil, err
+		}
+		value *= unitMultipliers[match[3]]
+		if match[1] == "SUM" {
+			sum[match[4]] = value
+		} else {
+			perStream[match[4]] += value
+		}
+	}
+
+	summary := perStream
+	if len(sum) > 0 {
+		summary = sum
+	}
+	return toResults(summary)
+}
+
+type jsonSum struct {
+	BitsPerSecond float64 `json:"bits_per_second"`
+}
+
+type jsonOutput struct {
+	End struct {
+		SumSent     *jsonSum `json:"sum_sent"`
+		SumReceived *jsonSum `json:"sum_received"`
+		// Sum is reported instead of SumSent and SumReceived for UDP
+		Sum *jsonSum `json:"sum"`
+	} `json:"end"`
+}
+
+func parseJSONResults(output string) ([]Result, error) {
+	var parsed jsonOutput
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		return nil, err
+	}
+
+	summary := map[string]float64{}
+	if parsed.End.SumSent != nil {
+		summary["sender"] = parsed.End.SumSent.BitsPerSecond
+	}
+	if parsed.End.SumReceived != nil {
+		summary["receiver"] = parsed.End.SumReceived.BitsPerSecond
+	}
+	if len(summary) == 0 && parsed.End.Sum != nil {
+		summary["sender"] = parsed.End.Sum.BitsPerSecond
+	}
+	return toResults(summary)
+}
+
+func toResults(summary map[string]float64) ([]Result, error) {
+	if len(summary) == 0 {
+		return nil, errors.New("No iperf3 results found in the output")
+	}
+
+	var results []Result
+	for _, role := range []string{"sender", "receiver"} {
+		if value, ok := summary[role]; ok {
+			results = append(results, Result{Role: role, BitsPerSecond: value})
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iperf3
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const textSampleOutput = `Connecting to host iperf3-sample, port 5201
+[  5] local 10.0.0.2 port 40000 connected to 10.0.0.3 port 5201
+[ ID] Interval           Transfer     Bitrate         Retr  Cwnd
+[  5]   0.00-1.00   sec   112 MBytes   940 Mbits/sec    0    380 KBytes
+- - - - - - - - - - - - - - - - - - - - - - - - -
+[ ID] Interval           Transfer     Bitrate         Retr
+[  5]   0.00-10.00  sec  1.09 GBytes   939 Mbits/sec    0             sender
+[  5]   0.00-10.04  sec  1.09 GBytes   934 Mbits/sec                  receiver
+
+iperf Done.
+`
+
+const parallelSampleOutput = `[ ID] Interval           Transfer     Bitrate         Retr
+[  5]   0.00-10.00  sec   560 MBytes   470 Mbits/sec    0             sender
+[  5]   0.00-10.04  sec   558 MBytes   466 Mbits/sec                  receiver
+[  7]   0.00-10.00  sec   560 MBytes   470 Mbits/sec    0             sender
+[  7]   0.00-10.04  sec   558 MBytes   466 Mbits/sec                  receiver
+[SUM]   0.00-10.00  sec  1.09 GBytes   940 Mbits/sec    0             sender
+[SUM]   0.00-10.04  sec  1.09 GBytes   932 Mbits/sec                  receiver
+`
+
+const jsonSampleOutput = `{
+	"start": {},
+	"end": {
+		"sum_sent": { "bits_per_second": 9.39e8 },
+		"sum_received": { "bits_per_second": 9.34e8 }
+	}
+}`
+
+var _ = Describe("iperf3 results", func() {
+	Context("with text output", func() {
+		It("should parse the sender and receiver summary", func() {
+			results, err := ParseResults(textSampleOutput)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal([]Result{
+				{Role: "sender", BitsPerSecond: 939e6},
+				{Role: "receiver", BitsPerSecond: 934e6},
+			}))
+		})
+	})
+
+	Context("with parallel streams", func() {
+		It("should use the [SUM] lines", func() {
+			results, err := ParseResults(parallelSampleOutput)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal([]Result{
+				{Role: "sender", BitsPerSecond: 940e6},
+				{Role: "receiver", BitsPerSecond: 932e6},
+			}))
+		})
+	})
+
+	Context("with json output", func() {
+		It("should parse the end summary", func() {
+			results, err := ParseResults(jsonSampleOutput)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal([]Result{
+				{Role: "sender", BitsPerSecond: 9.39e8},
+				{Role: "receiver", BitsPerSecond: 9.34e8},
+			}))
+		})
+	})
+
+	Context("without results", func() {
+		It("should fail", func() {
+			_, err := ParseResults("iperf3: error - unable to connect to server\n")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -59,6 +59,9 @@ func NewConsumerJob(cr *perfv1alpha1.KafkaBench, ts *perfv1alpha1.KafkaTestSpec)
 	// Add pod affinity
 	AddPodAffinity(job, jobName)
 
+	k8s.ApplyIterations(job, &cr.Spec.RunPolicySpec)
+	k8s.ApplyTimeout(job, &cr.Spec.RunPolicySpec)
+
 	return job
 }
 
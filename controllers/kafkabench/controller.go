@@ -26,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 )
 
 // KafkaBenchReconciler reconciles a KafkaBench object
@@ -39,23 +40,63 @@ type KafkaBenchReconciler struct {
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;create;delete
 
 func (r *KafkaBenchReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
+	ctx, cancel := r.K8S.NewReconcileContext()
+	defer cancel()
 	_ = r.Log.WithValues("kafkabench", req.NamespacedName)
 
 	var cr perfv1alpha1.KafkaBench
 
 	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+		return ctrl.Result{}, k8s.IgnoreDeleted(req.NamespacedName, err)
+	}
+
+	// Merge the referenced profile into the spec
+	if err := r.K8S.ApplyProfile(ctx, &cr); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Resolve the templated fields, invalid CRs are not requeued
+	if valid, err := r.K8S.ResolveVariables(ctx, &cr); !valid {
+		return ctrl.Result{}, err
 	}
 
 	// If its already completed then return
-	if cr.Status.Completed {
+	if cr.Status.Completed || cr.Status.Cancelled {
+		return r.K8S.CleanupFinished(ctx, &cr)
+	}
+
+	if cr.Spec.Cancel {
+		var jobs []metav1.Object
+		for i := range cr.Spec.Tests {
+			jobs = append(jobs, NewProducerJob(&cr, &cr.Spec.Tests[i]),
+				NewConsumerJob(&cr, &cr.Spec.Tests[i]))
+		}
+		return ctrl.Result{}, r.K8S.CancelBenchmark(ctx, &cr, jobs...)
+	}
+
+	// Suspended benchmarks are not started until they are resumed
+	if cr.Spec.Suspend && !cr.Status.Running {
 		return ctrl.Result{}, nil
 	}
 
+	// Submit the objects with server-side dry-run instead of running the benchmark
+	if cr.Spec.DryRun && !cr.Status.Running {
+		objects, err := NewObjects(&cr)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.K8S.DryRunBenchmark(ctx, &cr, objects...)
+	}
+
+	// Wait for the earlier benchmarks of the same exclusivity scope
+	if !cr.Status.Running {
+		if locked, err := r.K8S.AcquireLock(ctx, &cr); !locked {
+			return ctrl.Result{RequeueAfter: k8s.LockRetryInterval}, err
+		}
+	}
+
 	// Set status to running
-	cr.Status.Running = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
+	if err := r.K8S.StartBenchmark(ctx, &cr); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -72,7 +113,7 @@ func (r *KafkaBenchReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 
 	// Check all the job statuses
 	for _, job := range jobs {
-		jobFinished, err := r.K8S.IsJobFinished(types.NamespacedName{
+		jobFinished, err := r.K8S.IsJobFinished(ctx, types.NamespacedName{
 			Namespace: cr.Namespace,
 			Name:      job.Name,
 		})
@@ -90,11 +131,13 @@ func (r *KafkaBenchReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 
 	// The cr could have been modified since the last time we got it
 	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+		return ctrl.Result{}, k8s.IgnoreDeleted(req.NamespacedName, err)
+	}
+	var jobNames []string
+	for _, job := range jobs {
+		jobNames = append(jobNames, job.Name)
 	}
-	cr.Status.Running = false
-	cr.Status.Completed = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
+	if err := r.K8S.CompleteBenchmark(ctx, &cr, nil, jobNames...); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -118,9 +161,11 @@ func (r *KafkaBenchReconciler) ProcessKafkaTest(cr perfv1alpha1.KafkaBench, test
 	return ctrl.Result{}, nil, []*batchv1.Job{consumerJob, producerJob}
 }
 
-func (r *KafkaBenchReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *KafkaBenchReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&perfv1alpha1.KafkaBench{}).
+		WithOptions(options).
+		WithEventFilter(r.K8S.NamespacePredicate()).
 		Complete(r)
 }
 
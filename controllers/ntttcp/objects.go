@@ -0,0 +1,32 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ntttcp
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// NewObjects creates the objects of the benchmark in the order of their
+// creation. It is used for the dry-run and by kubestone render. The client
+// reaches the server via the name of its service instead of the endpoint
+// address looked up when the benchmark runs.
+func NewObjects(cr *perfv1alpha1.Ntttcp) ([]metav1.Object, error) {
+	service := NewServerService(cr)
+	return []metav1.Object{NewServerDeployment(cr), service, NewClientJob(cr, service.Name)}, nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ntttcp
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// throughputRegexp matches the throughput in the totals printed at the
+// end of the test, e.g. "23:09:26 INFO: 	 throughput	:9.23Gbps"
+var throughputRegexp = regexp.MustCompile(`\sthroughput\s*:\s*([\d.]+)([KMGT]?)bps`)
+
+var unitMultipliers = map[string]float64{
+	"": 1, "K": 1e3, "M": 1e6, "G": 1e9, "T": 1e12,
+}
+
+// ParseResults extracts the throughput in bits per second from the
+// totals of the ntttcp output
+func ParseResults(output string) (float64, error) {
+	var bitsPerSecond float64
+	found := false
+	for _, line := range strings.Split(output, "\n") {
+		match := throughputRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		value, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return 0, err
+		}
+		bitsPerSecond = value * unitMultipliers[match[2]]
+		found = true
+	}
+
+	if !found {
+		return 0, errors.New("No ntttcp results found in the output")
+	}
+	return bitsPerSecond, nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ntttcp
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const sampleOutput = "NTTTCP for Linux 1.4.0\n" +
+	"---------------------------------------------------------\n" +
+	"23:09:16 INFO: 17 threads created\n" +
+	"23:09:16 INFO: 64 connections created in 12345 microseconds\n" +
+	"23:09:16 INFO: Network activity progressing...\n" +
+	"23:09:26 INFO: Test run completed.\n" +
+	"23:09:26 INFO: #####  Totals:  #####\n" +
+	"23:09:26 INFO: test duration\t:10.00 seconds\n" +
+	"23:09:26 INFO: total bytes\t:11532861440\n" +
+	"23:09:26 INFO: \t throughput\t:9.23Gbps\n" +
+	"23:09:26 INFO: \t retrans segs\t:1326\n"
+
+var _ = Describe("ntttcp results", func() {
+	It("should parse the total throughput", func() {
+		Expect(ParseResults(sampleOutput)).To(Equal(9.23e9))
+	})
+
+	It("should fail without results", func() {
+		_, err := ParseResults("23:09:16 ERROR: cannot connect to the receiver\n")
+		Expect(err).To(HaveOccurred())
+	})
+})
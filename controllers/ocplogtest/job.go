@@ -40,5 +40,8 @@ func NewJob(cr *perfv1alpha1.OcpLogtest) *batchv1.Job {
 	job.Spec.Template.Spec.Containers[0].Command = []string{"python"}
 	job.Spec.Template.Spec.Containers[0].Args = args
 
+	k8s.ApplyIterations(job, &cr.Spec.RunPolicySpec)
+	k8s.ApplyTimeout(job, &cr.Spec.RunPolicySpec)
+
 	return job
 }
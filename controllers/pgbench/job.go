@@ -17,6 +17,7 @@ limitations under the License.
 package pgbench
 
 import (
+	"errors"
 	"fmt"
 
 	batchv1 "k8s.io/api/batch/v1"
@@ -35,12 +36,18 @@ func NewJob(cr *perfv1alpha1.Pgbench) *batchv1.Job {
 		Namespace: cr.Namespace,
 	}
 
+	postgres := connection(cr)
+	password := corev1.EnvVar{Name: "PGPASSWORD", Value: postgres.Password}
+	if postgres.PasswordFrom != nil {
+		password = corev1.EnvVar{Name: "PGPASSWORD", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: postgres.PasswordFrom}}
+	}
 	env := []corev1.EnvVar{
-		{Name: "PGHOST", Value: cr.Spec.Postgres.Host},
-		{Name: "PGPORT", Value: fmt.Sprintf("%d", cr.Spec.Postgres.Port)},
-		{Name: "PGUSER", Value: cr.Spec.Postgres.User},
-		{Name: "PGPASSWORD", Value: cr.Spec.Postgres.Password},
-		{Name: "PGDATABASE", Value: cr.Spec.Postgres.Database},
+		{Name: "PGHOST", Value: postgres.Host},
+		{Name: "PGPORT", Value: fmt.Sprintf("%d", postgres.Port)},
+		{Name: "PGUSER", Value: postgres.User},
+		password,
+		{Name: "PGDATABASE", Value: postgres.Database},
 	}
 
 	initContainer := corev1.Container{
@@ -58,5 +65,26 @@ func NewJob(cr *perfv1alpha1.Pgbench) *batchv1.Job {
 		job.Spec.Template.Spec.InitContainers, initContainer)
 	job.Spec.Template.Spec.Containers[0].Args = qsplit.ToStrings([]byte(cr.Spec.Args))
 	job.Spec.Template.Spec.Containers[0].Env = env
+	k8s.ApplyIterations(job, &cr.Spec.RunPolicySpec)
+	k8s.ApplyTimeout(job, &cr.Spec.RunPolicySpec)
 	return job
 }
+
+// IsCrValid validates the given CR and raises error if semantic errors detected
+// For pgbench, the password is checked to be given at most one way and
+// the database is checked to be either given or provisioned
+func IsCrValid(cr *perfv1alpha1.Pgbench) (valid bool, err error) {
+	if cr.Spec.Provision != nil {
+		if cr.Spec.Postgres != (perfv1alpha1.PostgresSpec{}) {
+			return false, errors.New("postgres and provision are mutually exclusive")
+		}
+		return true, nil
+	}
+	if cr.Spec.Postgres.Host == "" {
+		return false, errors.New("postgres.host is required unless provision is set")
+	}
+	if err := cr.Spec.Postgres.Validate(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
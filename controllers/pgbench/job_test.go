@@ -121,4 +121,67 @@ var _ = Describe("pgbench job", func() {
 		})
 
 	})
+
+	Describe("NewJob with the password in a Secret", func() {
+		secret := &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "postgres"},
+			Key:                  "password",
+		}
+		cr := perfv1alpha1.Pgbench{
+			Spec: perfv1alpha1.PgbenchSpec{
+				Image: perfv1alpha1.ImageSpec{Name: "postgres:latest"},
+				Postgres: perfv1alpha1.PostgresSpec{
+					Host:         "postgres",
+					Port:         5432,
+					User:         "admin",
+					PasswordFrom: secret,
+					Database:     "benchdb",
+				},
+			},
+		}
+		job := NewJob(&cr)
+
+		It("should read the password from the Secret", func() {
+			password := corev1.EnvVar{Name: "PGPASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: secret}}
+			Expect(job.Spec.Template.Spec.InitContainers[0].Env).To(ContainElement(password))
+			Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(password))
+		})
+	})
+
+	Describe("IsCrValid", func() {
+		It("should reject both a password and a reference", func() {
+			cr := perfv1alpha1.Pgbench{Spec: perfv1alpha1.PgbenchSpec{
+				Postgres: perfv1alpha1.PostgresSpec{
+					Host:         "postgres",
+					Password:     "admin",
+					PasswordFrom: &corev1.SecretKeySelector{Key: "password"},
+				},
+			}}
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should require the host unless the database is provisioned", func() {
+			cr := perfv1alpha1.Pgbench{}
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+
+			cr.Spec.Provision = &perfv1alpha1.PostgresProvisionSpec{Size: resource.MustParse("1Gi")}
+			valid, err = IsCrValid(&cr)
+			Expect(valid).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject both a database and a provisioned one", func() {
+			cr := perfv1alpha1.Pgbench{Spec: perfv1alpha1.PgbenchSpec{
+				Postgres:  perfv1alpha1.PostgresSpec{Host: "postgres"},
+				Provision: &perfv1alpha1.PostgresProvisionSpec{Size: resource.MustParse("1Gi")},
+			}}
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
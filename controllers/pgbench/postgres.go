@@ -0,0 +1,270 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbench
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;create;delete;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;create;delete;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=create;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=create;delete
+
+const (
+	// PostgresPort is the port of the provisioned PostgreSQL server
+	PostgresPort = 5432
+	// PostgresUser is the user of the provisioned PostgreSQL server
+	PostgresUser = "pgbench"
+	// PostgresDatabase is the database of the provisioned PostgreSQL server
+	PostgresDatabase = "pgbench"
+	// PostgresPasswordKey is the key of the password in the Secret
+	// of the provisioned PostgreSQL server
+	PostgresPasswordKey = "password"
+
+	postgresDataPath = "/var/lib/postgresql/data"
+)
+
+// DefaultPostgresImage is used when the provision spec has no image
+var DefaultPostgresImage = perfv1alpha1.ImageSpec{
+	Name:       "postgres:11.5-alpine",
+	PullPolicy: "IfNotPresent",
+}
+
+// postgresName is the name of every object of the provisioned server
+func postgresName(cr *perfv1alpha1.Pgbench) string {
+	return cr.Name + "-postgres"
+}
+
+func postgresLabels(cr *perfv1alpha1.Pgbench) map[string]string {
+	return map[string]string{
+		k8s.AppLabel:    "pgbench-postgres",
+		k8s.CrNameLabel: cr.Name,
+	}
+}
+
+// connection returns the connection parameters of the benchmarked
+// database: the provisioned server or the one given in the spec
+func connection(cr *perfv1alpha1.Pgbench) perfv1alpha1.PostgresSpec {
+	if cr.Spec.Provision == nil {
+		return cr.Spec.Postgres
+	}
+	return perfv1alpha1.PostgresSpec{
+		Host: postgresName(cr),
+		Port: PostgresPort,
+		User: PostgresUser,
+		PasswordFrom: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: postgresName(cr)},
+			Key:                  PostgresPasswordKey,
+		},
+		Database: PostgresDatabase,
+	}
+}
+
+// NewPostgresObjects creates the objects of the provisioned PostgreSQL
+// server in the order of their creation. No objects are returned when
+// the database is not provisioned.
+func NewPostgresObjects(cr *perfv1alpha1.Pgbench) ([]metav1.Object, error) {
+	if cr.Spec.Provision == nil {
+		return nil, nil
+	}
+	secret, err := NewPostgresSecret(cr)
+	if err != nil {
+		return nil, err
+	}
+	return []metav1.Object{secret, NewPostgresPVC(cr), NewPostgresStatefulSet(cr),
+		NewPostgresService(cr)}, nil
+}
+
+// NewPostgresSecret creates the Secret holding the generated password
+// of the provisioned PostgreSQL server
+func NewPostgresSecret(cr *perfv1alpha1.Pgbench) (*corev1.Secret, error) {
+	password := make([]byte, 16)
+	if _, err := rand.Read(password); err != nil {
+		return nil, err
+	}
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      postgresName(cr),
+			Namespace: cr.Namespace,
+			Labels:    postgresLabels(cr),
+		},
+		StringData: map[string]string{
+			PostgresPasswordKey: hex.EncodeToString(password),
+		},
+	}
+	return &secret, nil
+}
+
+// NewPostgresPVC creates the data volume of the provisioned PostgreSQL server.
+// It is not a volume claim template of the StatefulSet, as those are not
+// removed with the StatefulSet.
+func NewPostgresPVC(cr *perfv1alpha1.Pgbench) *corev1.PersistentVolumeClaim {
+	pvc := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      postgresName(cr),
+			Namespace: cr.Namespace,
+			Labels:    postgresLabels(cr),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: cr.Spec.Provision.StorageClassName,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: cr.Spec.Provision.Size},
+			},
+		},
+	}
+	return &pvc
+}
+
+// NewPostgresService creates the headless service of the provisioned
+// PostgreSQL server. Its endpoint becomes ready with the server.
+func NewPostgresService(cr *perfv1alpha1.Pgbench) *corev1.Service {
+	service := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      postgresName(cr),
+			Namespace: cr.Namespace,
+			Labels:    postgresLabels(cr),
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:     "postgres",
+					Protocol: corev1.ProtocolTCP,
+					Port:     PostgresPort,
+				},
+			},
+			Selector:  postgresLabels(cr),
+			ClusterIP: "None",
+		},
+	}
+	return &service
+}
+
+// NewPostgresStatefulSet creates the StatefulSet of the provisioned
+// PostgreSQL server. The settings of the Config are passed to the
+// server with -c.
+func NewPostgresStatefulSet(cr *perfv1alpha1.Pgbench) *appsv1.StatefulSet {
+	provision := cr.Spec.Provision
+	image := provision.Image
+	if (image == perfv1alpha1.ImageSpec{}) {
+		image = DefaultPostgresImage
+	}
+
+	names := []string{}
+	for name := range provision.Config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	args := []string{}
+	for _, name := range names {
+		args = append(args, "-c", fmt.Sprintf("%v=%v", name, provision.Config[name]))
+	}
+
+	var pullSecrets []corev1.LocalObjectReference
+	if image.PullSecret != "" {
+		pullSecrets = []corev1.LocalObjectReference{{Name: image.PullSecret}}
+	}
+
+	replicas := int32(1)
+	statefulSet := appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      postgresName(cr),
+			Namespace: cr.Namespace,
+			Labels:    postgresLabels(cr),
+		},
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName: postgresName(cr),
+			Replicas:    &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: postgresLabels(cr),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: postgresLabels(cr),
+				},
+				Spec: corev1.PodSpec{
+					ImagePullSecrets: pullSecrets,
+					Containers: []corev1.Container{
+						{
+							Name:            "postgres",
+							Image:           image.Name,
+							ImagePullPolicy: corev1.PullPolicy(image.PullPolicy),
+							Args:            args,
+							Env: []corev1.EnvVar{
+								{Name: "POSTGRES_USER", Value: PostgresUser},
+								{Name: "POSTGRES_DB", Value: PostgresDatabase},
+								{Name: "POSTGRES_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+									SecretKeyRef: connection(cr).PasswordFrom}},
+								// The root of the volume may hold lost+found
+								{Name: "PGDATA", Value: postgresDataPath + "/pgdata"},
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "postgres",
+									ContainerPort: PostgresPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							// The server listens on TCP only once it is initialized
+							ReadinessProbe: &corev1.Probe{
+								Handler: corev1.Handler{
+									Exec: &corev1.ExecAction{
+										Command: []string{"pg_isready", "--host", "127.0.0.1",
+											"--username", PostgresUser, "--dbname", PostgresDatabase},
+									},
+								},
+								InitialDelaySeconds: 5,
+								TimeoutSeconds:      2,
+								PeriodSeconds:       2,
+							},
+							Resources: provision.Resources,
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "data", MountPath: postgresDataPath},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "data",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: postgresName(cr),
+								},
+							},
+						},
+					},
+					Affinity:     provision.PodScheduling.Affinity,
+					Tolerations:  provision.PodScheduling.Tolerations,
+					NodeSelector: provision.PodScheduling.NodeSelector,
+					NodeName:     provision.PodScheduling.NodeName,
+				},
+			},
+		},
+	}
+	return &statefulSet
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbench
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("provisioned postgres", func() {
+	storageClass := "fast"
+	cr := perfv1alpha1.Pgbench{
+		ObjectMeta: metav1.ObjectMeta{Name: "pgbench-sample", Namespace: "kubestone"},
+		Spec: perfv1alpha1.PgbenchSpec{
+			Image: perfv1alpha1.ImageSpec{Name: "xridge/pgbench:12.0"},
+			Provision: &perfv1alpha1.PostgresProvisionSpec{
+				Config: map[string]string{
+					"shared_buffers":  "1GB",
+					"max_connections": "200",
+				},
+				StorageClassName: &storageClass,
+				Size:             resource.MustParse("10Gi"),
+			},
+		},
+	}
+
+	Describe("NewPostgresObjects", func() {
+		It("should create the server before the service", func() {
+			objects, err := NewPostgresObjects(&cr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objects).To(HaveLen(4))
+			Expect(objects[0]).To(BeAssignableToTypeOf(&corev1.Secret{}))
+			Expect(objects[1]).To(BeAssignableToTypeOf(&corev1.PersistentVolumeClaim{}))
+			Expect(objects[2]).To(BeAssignableToTypeOf(&appsv1.StatefulSet{}))
+			Expect(objects[3]).To(BeAssignableToTypeOf(&corev1.Service{}))
+			for _, object := range objects {
+				Expect(object.GetName()).To(Equal("pgbench-sample-postgres"))
+				Expect(object.GetNamespace()).To(Equal("kubestone"))
+			}
+		})
+
+		It("should create nothing without provision", func() {
+			objects, err := NewPostgresObjects(&perfv1alpha1.Pgbench{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objects).To(BeEmpty())
+		})
+	})
+
+	Describe("NewPostgresSecret", func() {
+		It("should generate a new password", func() {
+			first, err := NewPostgresSecret(&cr)
+			Expect(err).NotTo(HaveOccurred())
+			second, err := NewPostgresSecret(&cr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first.StringData[PostgresPasswordKey]).To(HaveLen(32))
+			Expect(first.StringData[PostgresPasswordKey]).NotTo(Equal(second.StringData[PostgresPasswordKey]))
+		})
+	})
+
+	Describe("NewPostgresPVC", func() {
+		pvc := NewPostgresPVC(&cr)
+
+		It("should have the given storage class and size", func() {
+			Expect(pvc.Spec.StorageClassName).To(Equal(&storageClass))
+			Expect(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("10Gi")))
+		})
+	})
+
+	Describe("NewPostgresStatefulSet", func() {
+		statefulSet := NewPostgresStatefulSet(&cr)
+		container := statefulSet.Spec.Template.Spec.Containers[0]
+
+		It("should use the default image", func() {
+			Expect(container.Image).To(Equal(DefaultPostgresImage.Name))
+		})
+
+		It("should override the settings in sorted order", func() {
+			Expect(container.Args).To(Equal([]string{
+				"-c", "max_connections=200", "-c", "shared_buffers=1GB"}))
+		})
+
+		It("should read the password from the Secret", func() {
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{
+				Name: "POSTGRES_PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "pgbench-sample-postgres"},
+					Key:                  PostgresPasswordKey,
+				}},
+			}))
+		})
+
+		It("should be ready once the server accepts connections", func() {
+			Expect(container.ReadinessProbe.Exec.Command).To(ContainElement("pg_isready"))
+		})
+
+		It("should not select the pods of the job", func() {
+			job := NewJob(&cr)
+			Expect(statefulSet.Spec.Selector.MatchLabels).NotTo(Equal(job.Spec.Template.Labels))
+		})
+	})
+
+	Describe("NewJob", func() {
+		job := NewJob(&cr)
+
+		It("should connect to the provisioned server", func() {
+			env := job.Spec.Template.Spec.Containers[0].Env
+			Expect(env).To(ContainElement(corev1.EnvVar{Name: "PGHOST", Value: "pgbench-sample-postgres"}))
+			Expect(env).To(ContainElement(corev1.EnvVar{Name: "PGUSER", Value: PostgresUser}))
+			Expect(env).To(ContainElement(corev1.EnvVar{Name: "PGDATABASE", Value: PostgresDatabase}))
+		})
+	})
+})
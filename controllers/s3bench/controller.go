@@ -18,12 +18,16 @@ package s3bench
 
 import (
 	"context"
+
 	"github.com/xridge/kubestone/pkg/k8s"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 )
 
 // Reconciler reconciles a S3Bench object
@@ -37,28 +41,96 @@ type Reconciler struct {
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=s3benches/finalizers,verbs=update
 
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
+	ctx, cancel := r.K8S.NewReconcileContext()
+	defer cancel()
 
 	var cr perfv1alpha1.S3Bench
 	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+		return ctrl.Result{}, k8s.IgnoreDeleted(req.NamespacedName, err)
+	}
+
+	// Merge the referenced profile into the spec
+	if err := r.K8S.ApplyProfile(ctx, &cr); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Resolve the templated fields, invalid CRs are not requeued
+	if valid, err := r.K8S.ResolveVariables(ctx, &cr); !valid {
+		return ctrl.Result{}, err
+	}
+
+	if cr.Status.Completed || cr.Status.Cancelled {
+		// The provisioned server is torn down once the benchmark is
+		// finished, a failed teardown is retried here
+		if err := r.deleteMinio(ctx, &cr); err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.K8S.CleanupFinished(ctx, &cr)
 	}
 
-	if cr.Status.Completed {
+	if cr.Spec.Cancel {
+		return ctrl.Result{}, r.K8S.CancelBenchmark(ctx, &cr, NewJob(&cr))
+	}
+
+	// Suspended benchmarks are not started until they are resumed
+	if cr.Spec.Suspend && !cr.Status.Running {
 		return ctrl.Result{}, nil
 	}
 
-	cr.Status.Running = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
+	// Validate on first entry
+	if !cr.Status.Completed && !cr.Status.Running {
+		if valid, err := IsCrValid(&cr); !valid {
+			_ = r.K8S.RecordEventf(&cr, corev1.EventTypeWarning, k8s.CreateFailed,
+				"CR validation failed: %v", err)
+
+			// Do not requeue invalid CRs
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// Submit the objects with server-side dry-run instead of running the benchmark
+	if cr.Spec.DryRun && !cr.Status.Running {
+		objects, err := NewObjects(&cr)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.K8S.DryRunBenchmark(ctx, &cr, objects...)
+	}
+
+	// Wait for the earlier benchmarks of the same exclusivity scope
+	if !cr.Status.Running {
+		if locked, err := r.K8S.AcquireLock(ctx, &cr); !locked {
+			return ctrl.Result{RequeueAfter: k8s.LockRetryInterval}, err
+		}
+	}
+
+	if err := r.K8S.StartBenchmark(ctx, &cr); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if cr.Spec.Provision != nil {
+		if err := r.createMinio(ctx, &cr); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		statefulSetReady, err := r.K8S.IsStatefulSetReady(ctx, types.NamespacedName{
+			Namespace: cr.Namespace,
+			Name:      minioName(&cr)})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !statefulSetReady {
+			// Wait for every MinIO server to accept requests
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+
 	job := NewJob(&cr)
 	if err := r.K8S.CreateWithReference(ctx, job, &cr); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	jobFinished, err := r.K8S.IsJobFinished(types.NamespacedName{
+	jobFinished, err := r.K8S.IsJobFinished(ctx, types.NamespacedName{
 		Namespace: cr.Namespace,
 		Name:      cr.Name,
 	})
@@ -72,19 +144,63 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	// The cr could have been modified since the last time we got it
 	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+		return ctrl.Result{}, k8s.IgnoreDeleted(req.NamespacedName, err)
 	}
-	cr.Status.Running = false
-	cr.Status.Completed = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
+	if err := r.K8S.CompleteBenchmark(ctx, &cr, nil, cr.Name); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	// The server is torn down after the benchmark is completed, so that
+	// a failed teardown is not followed by provisioning it again
+	return ctrl.Result{}, r.deleteMinio(ctx, &cr)
+}
+
+// createMinio creates the objects of the provisioned MinIO server
+func (r *Reconciler) createMinio(ctx context.Context, cr *perfv1alpha1.S3Bench) error {
+	objects, err := NewMinioObjects(cr)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		if err := r.K8S.CreateWithReference(ctx, object, cr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteMinio tears down the provisioned MinIO server. The Secret and
+// the volumes are deleted without reading them, as they are not cached.
+// The teardown is recorded in the status, so it is done only once.
+func (r *Reconciler) deleteMinio(ctx context.Context, cr *perfv1alpha1.S3Bench) error {
+	if cr.Spec.Provision == nil || cr.Status.ProvisionDeleted {
+		return nil
+	}
+	if err := r.K8S.DeleteObject(ctx, NewMinioService(cr), cr); err != nil {
+		return err
+	}
+	if err := r.K8S.DeleteObject(ctx, NewMinioStatefulSet(cr), cr); err != nil {
+		return err
+	}
+	for _, pvc := range NewMinioPVCs(cr) {
+		if err := r.K8S.DeleteUncachedObject(ctx, pvc, cr); err != nil {
+			return err
+		}
+	}
+	err := r.K8S.DeleteUncachedObject(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name: minioName(cr), Namespace: cr.Namespace}}, cr)
+	if err != nil {
+		return err
+	}
+
+	cr.Status.ProvisionDeleted = true
+	return r.K8S.Client.Status().Update(ctx, cr)
 }
 
-func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&perfv1alpha1.S3Bench{}).
+		WithOptions(options).
+		WithEventFilter(r.K8S.NamespacePredicate()).
 		Complete(r)
 }
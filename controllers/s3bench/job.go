@@ -17,13 +17,23 @@ limitations under the License.
 package s3bench
 
 import (
+	"errors"
+
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
 	"github.com/xridge/kubestone/pkg/k8s"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"strconv"
 )
 
+const (
+	// AccessKeyEnv holds the access key read from a Secret
+	AccessKeyEnv = "WARP_ACCESS_KEY"
+	// SecretKeyEnv holds the secret key read from a Secret
+	SecretKeyEnv = "WARP_SECRET_KEY"
+)
+
 // NewJob creates a s3bench benchmark job
 func NewJob(cr *perfv1alpha1.S3Bench) *batchv1.Job {
 	objectMeta := metav1.ObjectMeta{
@@ -31,9 +41,10 @@ func NewJob(cr *perfv1alpha1.S3Bench) *batchv1.Job {
 		Namespace: cr.Namespace,
 	}
 
+	spec := connection(cr)
 	var s3benchCmdLineArgs []string
-	s3benchCmdLineArgs = append(s3benchCmdLineArgs, cr.Spec.Mode)
-	s3benchCmdLineArgs = append(s3benchCmdLineArgs, ProcessS3BenchArgs(&cr.Spec)...)
+	s3benchCmdLineArgs = append(s3benchCmdLineArgs, spec.Mode)
+	s3benchCmdLineArgs = append(s3benchCmdLineArgs, ProcessS3BenchArgs(&spec)...)
 
 	image := cr.Spec.Image
 	if (image == perfv1alpha1.ImageSpec{}) {
@@ -45,6 +56,13 @@ func NewJob(cr *perfv1alpha1.S3Bench) *batchv1.Job {
 
 	job := k8s.NewPerfJob(objectMeta, "s3bench", image, cr.Spec.PodConfig)
 	job.Spec.Template.Spec.Containers[0].Args = s3benchCmdLineArgs
+	job.Spec.Template.Spec.Containers[0].Env = credentialEnv(&spec.S3BenchOptions)
+	if cr.Spec.Provision != nil {
+		job.Spec.Template.Spec.InitContainers = append(
+			job.Spec.Template.Spec.InitContainers, newBucketContainer(cr, &spec))
+	}
+	k8s.ApplyIterations(job, &cr.Spec.RunPolicySpec)
+	k8s.ApplyTimeout(job, &cr.Spec.RunPolicySpec)
 	return job
 }
 
@@ -60,6 +78,13 @@ func ProcessS3BenchArgs(spec *perfv1alpha1.S3BenchSpec) []string {
 		checkAndAppendBool(&cmdArgs, opts.Debug, "--insecure")
 		checkAndAppendString(&cmdArgs, opts.AccessKey, "--access-key")
 		checkAndAppendString(&cmdArgs, opts.SecretKey, "--secret-key")
+		// The keys read from Secrets are expanded from the environment by Kubernetes
+		if opts.AccessKeyFrom != nil {
+			cmdArgs = append(cmdArgs, "--access-key", "$("+AccessKeyEnv+")")
+		}
+		if opts.SecretKeyFrom != nil {
+			cmdArgs = append(cmdArgs, "--secret-key", "$("+SecretKeyEnv+")")
+		}
 		checkAndAppendBool(&cmdArgs, opts.Tls, "--tls")
 		checkAndAppendString(&cmdArgs, opts.Region, "--region")
 		checkAndAppendBool(&cmdArgs, opts.Encrypt, "--encrypt")
@@ -120,6 +145,47 @@ func ProcessS3BenchArgs(spec *perfv1alpha1.S3BenchSpec) []string {
 	return cmdArgs
 }
 
+// credentialEnv returns the environment variables of the keys read from Secrets
+func credentialEnv(opts *perfv1alpha1.S3BenchOptions) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	if opts.AccessKeyFrom != nil {
+		env = append(env, corev1.EnvVar{Name: AccessKeyEnv,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: opts.AccessKeyFrom}})
+	}
+	if opts.SecretKeyFrom != nil {
+		env = append(env, corev1.EnvVar{Name: SecretKeyEnv,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: opts.SecretKeyFrom}})
+	}
+	return env
+}
+
+// IsCrValid validates the given CR and raises error if semantic errors detected
+// For s3bench, the credentials are checked to be given at most one way
+// and the server is checked to be either given or provisioned
+func IsCrValid(cr *perfv1alpha1.S3Bench) (valid bool, err error) {
+	if cr.Spec.Provision != nil {
+		opts := cr.Spec.S3BenchOptions
+		if cr.Spec.Host != "" || opts.AccessKey != "" || opts.AccessKeyFrom != nil ||
+			opts.SecretKey != "" || opts.SecretKeyFrom != nil {
+			return false, errors.New("host and the keys are generated when provision is set")
+		}
+		if opts.Tls {
+			return false, errors.New("tls is not supported by the provisioned server")
+		}
+		if err := cr.Spec.Provision.Validate(); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if cr.Spec.Host == "" {
+		return false, errors.New("host is required unless provision is set")
+	}
+	if err := cr.Spec.S3BenchOptions.Validate(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func checkAndAppendInt(cmdArgs *[]string, dist int32, arg string) {
 	if dist > 0 {
 		*cmdArgs = append(*cmdArgs, arg, strconv.Itoa(int(dist)))
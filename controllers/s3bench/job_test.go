@@ -19,6 +19,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
 )
@@ -53,4 +54,42 @@ var _ = Describe("s3bench job", func() {
 			})
 		})
 	})
+
+	Describe("cr with the keys in a Secret", func() {
+		accessKey := &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "minio"}, Key: "accesskey"}
+		secretKey := &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "minio"}, Key: "secretkey"}
+		cr := perfv1alpha1.S3Bench{Spec: perfv1alpha1.S3BenchSpec{
+			Mode: "get",
+			Host: "minio:9000",
+			S3BenchOptions: perfv1alpha1.S3BenchOptions{
+				AccessKeyFrom: accessKey,
+				SecretKeyFrom: secretKey,
+			},
+		}}
+		job := NewJob(&cr)
+		container := job.Spec.Template.Spec.Containers[0]
+
+		It("should read the keys from the Secret", func() {
+			Expect(container.Env).To(Equal([]corev1.EnvVar{
+				{Name: AccessKeyEnv, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: accessKey}},
+				{Name: SecretKeyEnv, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: secretKey}},
+			}))
+		})
+
+		It("should pass the keys via the environment", func() {
+			Expect(container.Args).To(ContainElement("$(" + AccessKeyEnv + ")"))
+			Expect(container.Args).To(ContainElement("$(" + SecretKeyEnv + ")"))
+		})
+
+		It("should reject both a key and a reference", func() {
+			invalid := cr.DeepCopy()
+			invalid.Spec.S3BenchOptions.SecretKey = "secret"
+			valid, err := IsCrValid(invalid)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+			Expect(IsCrValid(&cr)).To(BeTrue())
+		})
+	})
 })
@@ -0,0 +1,333 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3bench
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;create;delete;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;create;delete;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=create;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=create;delete
+
+const (
+	// MinioPort is the port of the provisioned MinIO servers
+	MinioPort = 9000
+	// MinioAccessKey is the key of the access key in the Secret
+	// of the provisioned MinIO server
+	MinioAccessKey = "accesskey"
+	// MinioSecretKey is the key of the secret key in the Secret
+	// of the provisioned MinIO server
+	MinioSecretKey = "secretkey"
+	// DefaultBucket is the bucket used by warp when none is given
+	DefaultBucket = "warp-benchmark-bucket"
+
+	minioDataPath = "/data"
+)
+
+// DefaultMinioImage is used when the provision spec has no image
+var DefaultMinioImage = perfv1alpha1.ImageSpec{
+	Name:       "minio/minio:RELEASE.2019-10-12T01-39-57Z",
+	PullPolicy: "IfNotPresent",
+}
+
+// DefaultMinioClientImage is used when the provision spec has no client image
+var DefaultMinioClientImage = perfv1alpha1.ImageSpec{
+	Name:       "minio/mc:RELEASE.2019-10-09T22-54-57Z",
+	PullPolicy: "IfNotPresent",
+}
+
+// minioName is the name of every object of the provisioned server
+func minioName(cr *perfv1alpha1.S3Bench) string {
+	return cr.Name + "-minio"
+}
+
+func minioLabels(cr *perfv1alpha1.S3Bench) map[string]string {
+	return map[string]string{
+		k8s.AppLabel:    "s3bench-minio",
+		k8s.CrNameLabel: cr.Name,
+	}
+}
+
+// minioServers returns the number of the provisioned MinIO servers
+func minioServers(cr *perfv1alpha1.S3Bench) int32 {
+	if cr.Spec.Provision.Servers == 0 {
+		return 1
+	}
+	return cr.Spec.Provision.Servers
+}
+
+// minioHost returns the address of the given MinIO server,
+// resolved via the headless service
+func minioHost(cr *perfv1alpha1.S3Bench, server int32) string {
+	return fmt.Sprintf("%v-%d.%v.%v.svc", minioName(cr), server, minioName(cr), cr.Namespace)
+}
+
+// minioPVCName returns the name of the data volume of the given MinIO
+// server. The StatefulSet uses this claim instead of creating one from
+// its volume claim template.
+func minioPVCName(cr *perfv1alpha1.S3Bench, server int32) string {
+	return fmt.Sprintf("data-%v-%d", minioName(cr), server)
+}
+
+func minioKeySelector(cr *perfv1alpha1.S3Bench, key string) *corev1.SecretKeySelector {
+	return &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: minioName(cr)},
+		Key:                  key,
+	}
+}
+
+// connection returns the spec of the benchmark pointed at the
+// provisioned servers, or the spec itself if nothing is provisioned
+func connection(cr *perfv1alpha1.S3Bench) perfv1alpha1.S3BenchSpec {
+	spec := cr.Spec
+	if spec.Provision == nil {
+		return spec
+	}
+	hosts := []string{}
+	for server := int32(0); server < minioServers(cr); server++ {
+		hosts = append(hosts, fmt.Sprintf("%v:%d", minioHost(cr, server), MinioPort))
+	}
+	spec.Host = strings.Join(hosts, ",")
+	spec.AccessKeyFrom = minioKeySelector(cr, MinioAccessKey)
+	spec.SecretKeyFrom = minioKeySelector(cr, MinioSecretKey)
+	if spec.Bucket == "" {
+		spec.Bucket = DefaultBucket
+	}
+	return spec
+}
+
+// NewMinioObjects creates the objects of the provisioned MinIO server
+// in the order of their creation. No objects are returned when the
+// server is not provisioned.
+func NewMinioObjects(cr *perfv1alpha1.S3Bench) ([]metav1.Object, error) {
+	if cr.Spec.Provision == nil {
+		return nil, nil
+	}
+	secret, err := NewMinioSecret(cr)
+	if err != nil {
+		return nil, err
+	}
+	objects := []metav1.Object{secret}
+	for _, pvc := range NewMinioPVCs(cr) {
+		objects = append(objects, pvc)
+	}
+	return append(objects, NewMinioStatefulSet(cr), NewMinioService(cr)), nil
+}
+
+// NewMinioSecret creates the Secret holding the generated credentials
+// of the provisioned MinIO server
+func NewMinioSecret(cr *perfv1alpha1.S3Bench) (*corev1.Secret, error) {
+	keys := make([]byte, 10+20)
+	if _, err := rand.Read(keys); err != nil {
+		return nil, err
+	}
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      minioName(cr),
+			Namespace: cr.Namespace,
+			Labels:    minioLabels(cr),
+		},
+		StringData: map[string]string{
+			MinioAccessKey: hex.EncodeToString(keys[:10]),
+			MinioSecretKey: hex.EncodeToString(keys[10:]),
+		},
+	}
+	return &secret, nil
+}
+
+// NewMinioPVCs creates the data volumes of the provisioned MinIO servers.
+// They are created by kubestone instead of the StatefulSet, as the claims
+// of the volume claim templates are not removed with the StatefulSet.
+// No volumes are returned when the data is stored on the nodes.
+func NewMinioPVCs(cr *perfv1alpha1.S3Bench) []*corev1.PersistentVolumeClaim {
+	pvcSpec := cr.Spec.Provision.PersistentVolumeClaimSpec
+	if pvcSpec == nil {
+		return nil
+	}
+	pvcs := []*corev1.PersistentVolumeClaim{}
+	for server := int32(0); server < minioServers(cr); server++ {
+		pvc := k8s.NewPersistentVolumeClaim(*pvcSpec.DeepCopy(), minioPVCName(cr, server), cr.Namespace)
+		pvc.Labels = minioLabels(cr)
+		pvcs = append(pvcs, pvc)
+	}
+	return pvcs
+}
+
+// NewMinioService creates the headless service of the provisioned MinIO
+// servers. The addresses of the servers are published before they are
+// ready, as distributed MinIO servers only become ready once they have
+// found each other.
+func NewMinioService(cr *perfv1alpha1.S3Bench) *corev1.Service {
+	service := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      minioName(cr),
+			Namespace: cr.Namespace,
+			Labels:    minioLabels(cr),
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:     "minio",
+					Protocol: corev1.ProtocolTCP,
+					Port:     MinioPort,
+				},
+			},
+			Selector:                 minioLabels(cr),
+			ClusterIP:                "None",
+			PublishNotReadyAddresses: true,
+		},
+	}
+	return &service
+}
+
+// NewMinioStatefulSet creates the StatefulSet of the provisioned MinIO
+// servers. Multiple servers are started in distributed mode with one
+// drive each.
+func NewMinioStatefulSet(cr *perfv1alpha1.S3Bench) *appsv1.StatefulSet {
+	provision := cr.Spec.Provision
+	image := provision.Image
+	if (image == perfv1alpha1.ImageSpec{}) {
+		image = DefaultMinioImage
+	}
+
+	args := []string{"server", minioDataPath}
+	if servers := minioServers(cr); servers > 1 {
+		args = []string{"server", fmt.Sprintf("http://%v-{0...%d}.%v.%v.svc%v",
+			minioName(cr), servers-1, minioName(cr), cr.Namespace, minioDataPath)}
+	}
+
+	var pullSecrets []corev1.LocalObjectReference
+	if image.PullSecret != "" {
+		pullSecrets = []corev1.LocalObjectReference{{Name: image.PullSecret}}
+	}
+
+	var volumes []corev1.Volume
+	var claimTemplates []corev1.PersistentVolumeClaim
+	if provision.PersistentVolumeClaimSpec == nil {
+		volumes = []corev1.Volume{
+			{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		}
+	} else {
+		claimTemplates = []corev1.PersistentVolumeClaim{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "data"},
+				Spec:       *provision.PersistentVolumeClaimSpec.DeepCopy(),
+			},
+		}
+	}
+
+	replicas := minioServers(cr)
+	statefulSet := appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      minioName(cr),
+			Namespace: cr.Namespace,
+			Labels:    minioLabels(cr),
+		},
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName: minioName(cr),
+			Replicas:    &replicas,
+			// Distributed servers wait for each other before becoming ready
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: minioLabels(cr),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: minioLabels(cr),
+				},
+				Spec: corev1.PodSpec{
+					ImagePullSecrets: pullSecrets,
+					Containers: []corev1.Container{
+						{
+							Name:            "minio",
+							Image:           image.Name,
+							ImagePullPolicy: corev1.PullPolicy(image.PullPolicy),
+							Args:            args,
+							Env: []corev1.EnvVar{
+								{Name: "MINIO_ACCESS_KEY", ValueFrom: &corev1.EnvVarSource{
+									SecretKeyRef: minioKeySelector(cr, MinioAccessKey)}},
+								{Name: "MINIO_SECRET_KEY", ValueFrom: &corev1.EnvVarSource{
+									SecretKeyRef: minioKeySelector(cr, MinioSecretKey)}},
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "minio",
+									ContainerPort: MinioPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							ReadinessProbe: &corev1.Probe{
+								Handler: corev1.Handler{
+									HTTPGet: &corev1.HTTPGetAction{
+										Path: "/minio/health/ready",
+										Port: intstr.FromInt(MinioPort),
+									},
+								},
+								InitialDelaySeconds: 5,
+								TimeoutSeconds:      2,
+								PeriodSeconds:       2,
+							},
+							Resources: provision.Resources,
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "data", MountPath: minioDataPath},
+							},
+						},
+					},
+					Volumes:      volumes,
+					Affinity:     provision.PodScheduling.Affinity,
+					Tolerations:  provision.PodScheduling.Tolerations,
+					NodeSelector: provision.PodScheduling.NodeSelector,
+					NodeName:     provision.PodScheduling.NodeName,
+				},
+			},
+			VolumeClaimTemplates: claimTemplates,
+		},
+	}
+	return &statefulSet
+}
+
+// newBucketContainer creates the init container of the benchmark job
+// creating the bucket on the provisioned MinIO server
+func newBucketContainer(cr *perfv1alpha1.S3Bench, spec *perfv1alpha1.S3BenchSpec) corev1.Container {
+	image := cr.Spec.Provision.ClientImage
+	if (image == perfv1alpha1.ImageSpec{}) {
+		image = DefaultMinioClientImage
+	}
+	// The keys are hexadecimal, so they can be used in the URL unescaped
+	target := fmt.Sprintf("http://$(%v):$(%v)@%v:%d", AccessKeyEnv, SecretKeyEnv, minioHost(cr, 0), MinioPort)
+	return corev1.Container{
+		Name:            "create-bucket",
+		Image:           image.Name,
+		ImagePullPolicy: corev1.PullPolicy(image.PullPolicy),
+		Args:            []string{"mb", "--ignore-existing", "minio/" + spec.Bucket},
+		Env: append(credentialEnv(&spec.S3BenchOptions),
+			corev1.EnvVar{Name: "MC_HOST_minio", Value: target}),
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3bench
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("provisioned minio", func() {
+	newCR := func(servers int32, pvcSpec *corev1.PersistentVolumeClaimSpec) *perfv1alpha1.S3Bench {
+		return &perfv1alpha1.S3Bench{
+			ObjectMeta: metav1.ObjectMeta{Name: "s3bench-sample", Namespace: "kubestone"},
+			Spec: perfv1alpha1.S3BenchSpec{
+				Mode: "mixed",
+				Provision: &perfv1alpha1.MinioProvisionSpec{
+					Servers:                   servers,
+					PersistentVolumeClaimSpec: pvcSpec,
+				},
+			},
+		}
+	}
+	pvcSpec := &corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+		},
+	}
+
+	Describe("single server", func() {
+		cr := newCR(0, nil)
+		statefulSet := NewMinioStatefulSet(cr)
+
+		It("should serve a single drive", func() {
+			Expect(*statefulSet.Spec.Replicas).To(Equal(int32(1)))
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{"server", "/data"}))
+		})
+
+		It("should store the data on the node", func() {
+			Expect(statefulSet.Spec.Template.Spec.Volumes[0].EmptyDir).NotTo(BeNil())
+			Expect(statefulSet.Spec.VolumeClaimTemplates).To(BeEmpty())
+			Expect(NewMinioPVCs(cr)).To(BeEmpty())
+		})
+	})
+
+	Describe("distributed servers", func() {
+		cr := newCR(4, pvcSpec)
+		statefulSet := NewMinioStatefulSet(cr)
+
+		It("should start the servers together", func() {
+			Expect(*statefulSet.Spec.Replicas).To(Equal(int32(4)))
+			Expect(statefulSet.Spec.PodManagementPolicy).To(Equal(appsv1.ParallelPodManagement))
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{
+				"server", "http://s3bench-sample-minio-{0...3}.s3bench-sample-minio.kubestone.svc/data"}))
+		})
+
+		It("should create the volumes of the claim templates", func() {
+			pvcs := NewMinioPVCs(cr)
+			Expect(pvcs).To(HaveLen(4))
+			Expect(pvcs[3].Name).To(Equal(statefulSet.Spec.VolumeClaimTemplates[0].Name + "-" +
+				statefulSet.Name + "-3"))
+			Expect(pvcs[3].Spec).To(Equal(*pvcSpec))
+		})
+
+		It("should publish the servers before they are ready", func() {
+			Expect(NewMinioService(cr).Spec.PublishNotReadyAddresses).To(BeTrue())
+		})
+
+		It("should create the servers after their volumes", func() {
+			objects, err := NewMinioObjects(cr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objects).To(HaveLen(7))
+			Expect(objects[0]).To(BeAssignableToTypeOf(&corev1.Secret{}))
+			Expect(objects[5]).To(BeAssignableToTypeOf(&appsv1.StatefulSet{}))
+			Expect(objects[6]).To(BeAssignableToTypeOf(&corev1.Service{}))
+		})
+	})
+
+	Describe("NewMinioSecret", func() {
+		It("should generate new keys", func() {
+			first, err := NewMinioSecret(newCR(0, nil))
+			Expect(err).NotTo(HaveOccurred())
+			second, err := NewMinioSecret(newCR(0, nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first.StringData[MinioAccessKey]).To(HaveLen(20))
+			Expect(first.StringData[MinioSecretKey]).To(HaveLen(40))
+			Expect(first.StringData[MinioSecretKey]).NotTo(Equal(second.StringData[MinioSecretKey]))
+		})
+	})
+
+	Describe("NewJob", func() {
+		job := NewJob(newCR(4, nil))
+		container := job.Spec.Template.Spec.Containers[0]
+
+		It("should benchmark every server", func() {
+			Expect(container.Args).To(ContainElement(
+				"s3bench-sample-minio-0.s3bench-sample-minio.kubestone.svc:9000," +
+					"s3bench-sample-minio-1.s3bench-sample-minio.kubestone.svc:9000," +
+					"s3bench-sample-minio-2.s3bench-sample-minio.kubestone.svc:9000," +
+					"s3bench-sample-minio-3.s3bench-sample-minio.kubestone.svc:9000"))
+		})
+
+		It("should read the keys from the generated Secret", func() {
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: AccessKeyEnv,
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "s3bench-sample-minio"},
+					Key:                  MinioAccessKey,
+				}}}))
+		})
+
+		It("should create the bucket first", func() {
+			bucket := job.Spec.Template.Spec.InitContainers[0]
+			Expect(bucket.Args).To(Equal([]string{"mb", "--ignore-existing", "minio/" + DefaultBucket}))
+			Expect(container.Args).To(ContainElement(DefaultBucket))
+		})
+	})
+
+	Describe("IsCrValid", func() {
+		It("should accept 1 or 4 to 16 servers", func() {
+			for _, servers := range []int32{0, 1, 4, 16} {
+				Expect(IsCrValid(newCR(servers, nil))).To(BeTrue())
+			}
+			for _, servers := range []int32{-1, 2, 3, 17} {
+				valid, err := IsCrValid(newCR(servers, nil))
+				Expect(valid).To(BeFalse())
+				Expect(err).To(HaveOccurred())
+			}
+		})
+
+		It("should reject a host with provision", func() {
+			cr := newCR(0, nil)
+			cr.Spec.Host = "minio:9000"
+			valid, err := IsCrValid(cr)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should require a host without provision", func() {
+			valid, err := IsCrValid(&perfv1alpha1.S3Bench{})
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
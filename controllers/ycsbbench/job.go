@@ -23,9 +23,38 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
 	"strconv"
 )
 
+// propertyEnvPrefix prefixes the environment variables holding the
+// properties read from Secrets
+const propertyEnvPrefix = "YCSB_PROPERTY_"
+
+// secretProperties returns the names of the properties read from Secrets
+// in a stable order
+func secretProperties(cr *perfv1alpha1.YcsbBench) []string {
+	names := []string{}
+	for name := range cr.Spec.PropertiesFrom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// propertyEnv returns the environment variables of the properties read from Secrets
+func propertyEnv(cr *perfv1alpha1.YcsbBench) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for i, name := range secretProperties(cr) {
+		selector := cr.Spec.PropertiesFrom[name]
+		env = append(env, corev1.EnvVar{
+			Name:      propertyEnvPrefix + strconv.Itoa(i),
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &selector},
+		})
+	}
+	return env
+}
+
 func formatArgs(cr *perfv1alpha1.YcsbBench) []string {
 	args := []string{
 		cr.Spec.Database,
@@ -45,9 +74,22 @@ func formatArgs(cr *perfv1alpha1.YcsbBench) []string {
 	for key, val := range cr.Spec.Properties {
 		args = append(args, "-p", fmt.Sprintf("%s=%s", key, val))
 	}
+	// The values read from Secrets are expanded from the environment by Kubernetes
+	for i, name := range secretProperties(cr) {
+		args = append(args, "-p", fmt.Sprintf("%s=$(%s%d)", name, propertyEnvPrefix, i))
+	}
 	return args
 }
 
+// IsCrValid validates the given CR and raises error if semantic errors detected
+// For ycsbbench, the properties are checked to be given at most one way
+func IsCrValid(cr *perfv1alpha1.YcsbBench) (valid bool, err error) {
+	if err := cr.Spec.ValidateProperties(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func NewJob(cr *perfv1alpha1.YcsbBench) *batchv1.Job {
 	objectMeta := metav1.ObjectMeta{
 		Name:      cr.Name,
@@ -55,12 +97,14 @@ func NewJob(cr *perfv1alpha1.YcsbBench) *batchv1.Job {
 	}
 
 	args := formatArgs(cr)
+	env := propertyEnv(cr)
 	initContainer := corev1.Container{
 		Name:            "ycsbbench-load",
 		Image:           cr.Spec.Image.Name,
 		ImagePullPolicy: corev1.PullPolicy(cr.Spec.Image.PullPolicy),
 		Command:         []string{"./bin/ycsb", "load"},
 		Args:            args,
+		Env:             env,
 	}
 	// append([]string{"./bin/ycsb", "load", args},
 	job := k8s.NewPerfJob(objectMeta, "ycsbbench", cr.Spec.Image, cr.Spec.PodConfig)
@@ -69,6 +113,10 @@ func NewJob(cr *perfv1alpha1.YcsbBench) *batchv1.Job {
 
 	job.Spec.Template.Spec.Containers[0].Command = []string{"./bin/ycsb", "run"}
 	job.Spec.Template.Spec.Containers[0].Args = args
+	job.Spec.Template.Spec.Containers[0].Env = env
+
+	k8s.ApplyIterations(job, &cr.Spec.RunPolicySpec)
+	k8s.ApplyTimeout(job, &cr.Spec.RunPolicySpec)
 
 	return job
 }
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 var _ = Describe("ycsbbench job", func() {
@@ -106,6 +107,54 @@ var _ = Describe("ycsbbench job", func() {
 
 		testNewJob(cr, expected_args)
 	})
+
+	Describe("NewJob with properties in Secrets", func() {
+		password := corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "redis"}, Key: "password"}
+		cr := perfv1alpha1.YcsbBench{
+			Spec: perfv1alpha1.YcsbBenchSpec{
+				Image: perfv1alpha1.ImageSpec{
+					Name: "diamantisolutions/ycsb:latest",
+				},
+				Database: "redis",
+				Workload: "a",
+				Properties: map[string]string{
+					"redis.host": "10.0.0.1",
+				},
+				PropertiesFrom: map[string]corev1.SecretKeySelector{
+					"redis.password": password,
+				},
+			},
+		}
+		expected_args := []string{
+			"redis",
+			"-P",
+			"workloads/workloada",
+			"-p",
+			"redis.host=10.0.0.1",
+			"-p",
+			"redis.password=$(YCSB_PROPERTY_0)",
+		}
+
+		testNewJob(cr, expected_args)
+
+		It("should read the properties from the Secrets", func() {
+			job := NewJob(&cr)
+			env := []corev1.EnvVar{
+				{Name: "YCSB_PROPERTY_0", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &password}},
+			}
+			Expect(job.Spec.Template.Spec.InitContainers[0].Env).To(Equal(env))
+			Expect(job.Spec.Template.Spec.Containers[0].Env).To(Equal(env))
+		})
+
+		It("should reject a property given both ways", func() {
+			invalid := cr.DeepCopy()
+			invalid.Spec.Properties["redis.password"] = "secret"
+			valid, err := IsCrValid(invalid)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
 
 func testNewJob(cr perfv1alpha1.YcsbBench, expected_args []string) {
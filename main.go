@@ -17,21 +17,31 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
+	"io/ioutil"
 	"github.com/xridge/kubestone/controllers/ocplogtest"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/xridge/kubestone/controllers/ycsbbench"
 
+	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	k8sscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmanager "sigs.k8s.io/controller-runtime/pkg/manager"
 
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	perfv1beta1 "github.com/xridge/kubestone/api/v1beta1"
 	"github.com/xridge/kubestone/controllers/drill"
 	"github.com/xridge/kubestone/controllers/fio"
 	"github.com/xridge/kubestone/controllers/ioping"
@@ -46,6 +56,10 @@ import (
 	"github.com/xridge/kubestone/controllers/ethr"
 	"github.com/xridge/kubestone/controllers/ntttcp"
 	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/manager"
+	"github.com/xridge/kubestone/pkg/resultsapi"
+	"github.com/xridge/kubestone/pkg/sink"
+	"github.com/xridge/kubestone/pkg/version"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -59,149 +73,248 @@ func init() {
 	_ = k8sscheme.AddToScheme(scheme)
 
 	_ = perfv1alpha1.AddToScheme(scheme)
+	_ = perfv1beta1.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
 }
 
+// reconciler is implemented by the Reconciler of every benchmark kind
+type reconciler interface {
+	SetupWithManager(mgr ctrl.Manager, options controller.Options) error
+}
+
+func controllerLog(kind string) logr.Logger {
+	return ctrl.Log.WithName("controllers").WithName(kind)
+}
+
 // +kubebuilder:rbac:groups="",resources=events,verbs=create
 
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var ttlSecondsAfterFinished int
+	var lockNamespace string
+	var controllers string
+	var watchNamespaces string
+	var controllerNamespaces string
+	var maxConcurrentReconciles int
+	var controllerConcurrency string
+	var reconcileTimeout time.Duration
+	var resultSinkURL string
+	var resultSinkType string
+	var webhookConfig string
+	var webhookHosts string
+	var resultsAddr string
+	var resultsTokenFile string
+	var webhookPort int
+	var webhookCertDir string
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
+	flag.IntVar(&ttlSecondsAfterFinished, "ttl-seconds-after-finished", -1,
+		"Default time to live of finished benchmarks which do not specify ttlSecondsAfterFinished. Negative value disables the clean up.")
+	flag.StringVar(&lockNamespace, "lock-namespace", "kubestone-system",
+		"The namespace of the leases used to serialize the benchmarks with exclusivity.")
+	flag.StringVar(&controllers, "controllers", "*",
+		"Comma separated list of the benchmark controllers to run. '*' enables all controllers, "+
+			"'name' enables and '-name' disables the given controller, e.g. '*,-kafkabench'.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "",
+		"Comma separated list of the namespaces watched for benchmarks. All namespaces are watched if empty.")
+	flag.StringVar(&controllerNamespaces, "controller-namespaces", "",
+		"Comma separated list of name=namespace pairs restricting the given controllers to the given namespaces, "+
+			"e.g. 'iperf3=net-a,iperf3=net-b'. The other controllers reconcile every watched namespace.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"The number of benchmarks each controller reconciles concurrently.")
+	flag.StringVar(&controllerConcurrency, "controller-concurrency", "",
+		"Comma separated list of per controller overrides of --max-concurrent-reconciles, e.g. 'fio=4,iperf3=2'.")
+	flag.DurationVar(&reconcileTimeout, "reconcile-timeout", 2*time.Minute,
+		"The maximum duration of a single reconcile, the pending API calls are abandoned after it. Zero disables the timeout.")
+	flag.StringVar(&resultSinkURL, "result-sink-url", "",
+		"The URL where the results of the finished benchmarks are pushed. Results are not pushed if empty.")
+	flag.StringVar(&resultSinkType, "result-sink-type", sink.PushgatewayType,
+		"The type of the result sink: '"+sink.PushgatewayType+"' or '"+sink.RemoteWriteType+"'.")
+	flag.StringVar(&webhookConfig, "webhook-config", "",
+		"The ConfigMap ('namespace/name' or 'name' in the lock namespace) holding the global webhooks "+
+			"notified about the benchmark lifecycle events. Webhooks are only notified by the benchmarks "+
+			"configuring them if empty.")
+	flag.StringVar(&webhookHosts, "benchmark-webhook-hosts", "",
+		"Comma separated list of the hosts ('host' or 'host:port') the webhooks given in the benchmarks may notify. "+
+			"The webhooks of the benchmarks are rejected if empty.")
+	flag.StringVar(&resultsAddr, "results-addr", "",
+		"The address the read-only results API binds to. The API is disabled if empty.")
+	flag.StringVar(&resultsTokenFile, "results-token-file", "",
+		"The file holding the bearer token required by the results API. "+
+			"Without token the API can only bind to a loopback address.")
+	flag.IntVar(&webhookPort, "webhook-port", 443,
+		"The port the conversion webhook of the v1beta1 API binds to. Zero disables the webhook.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs",
+		"The directory holding the tls.crt and tls.key of the conversion webhook.")
 	flag.Parse()
 
 	ctrl.SetLogger(zapr.NewLogger(rootLog))
 
-	restClientConfig := ctrl.GetConfigOrDie()
-	mgr, err := ctrl.NewManager(restClientConfig, ctrl.Options{
+	managerOptions := ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
 		LeaderElection:     enableLeaderElection,
-	})
+		Port:               webhookPort,
+	}
+	namespaces := manager.SplitList(watchNamespaces)
+	if len(namespaces) == 1 {
+		managerOptions.Namespace = namespaces[0]
+	} else if len(namespaces) > 1 {
+		managerOptions.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+
+	restClientConfig := ctrl.GetConfigOrDie()
+	mgr, err := ctrl.NewManager(restClientConfig, managerOptions)
 	if err != nil {
 		setupLog.Error(err, "Unable to start manager")
 		os.Exit(1)
 	}
 
+	// The API calls of the reconcilers are abandoned when the manager stops
+	stop := ctrl.SetupSignalHandler()
+	baseContext, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stop
+		cancel()
+	}()
+
 	clientSet := kubernetes.NewForConfigOrDie(restClientConfig)
 	k8sAccess := k8s.Access{
-		Client:        mgr.GetClient(),
-		Clientset:     clientSet,
-		Scheme:        mgr.GetScheme(),
-		EventRecorder: k8s.NewEventRecorder(clientSet, rootLog.Sugar().Infof),
-	}
-	if err = (&ntttcp.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Ntttcp"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Ntttcp")
-		os.Exit(1)
-	}
-	if err = (&ethr.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Ethr"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Ethr")
-		os.Exit(1)
-	}
-	if err = (&ping.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Ping"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Ping")
-		os.Exit(1)
-	}
-	if err = (&iperf2.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Iperf2"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Iperf2")
-		os.Exit(1)
+		Client:           mgr.GetClient(),
+		Clientset:        clientSet,
+		Scheme:           mgr.GetScheme(),
+		EventRecorder:    k8s.NewEventRecorder(clientSet, rootLog.Sugar().Infof),
+		WebhookHosts:     manager.SplitList(webhookHosts),
+		LockNamespace:    lockNamespace,
+		BaseContext:      baseContext,
+		ReconcileTimeout: reconcileTimeout,
 	}
-	if err = (&iperf3.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Iperf3"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Iperf3")
-		os.Exit(1)
+	if ttlSecondsAfterFinished >= 0 {
+		ttl := int32(ttlSecondsAfterFinished)
+		k8sAccess.DefaultTTLSecondsAfterFinished = &ttl
 	}
-	if err = (&fio.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Fio"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Fio")
-		os.Exit(1)
+	if resultSinkURL != "" {
+		k8sAccess.ResultSink, err = sink.New(resultSinkType, resultSinkURL)
+		if err != nil {
+			setupLog.Error(err, "Invalid --result-sink-type")
+			os.Exit(1)
+		}
 	}
-	if err = (&sysbench.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Sysbench"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Sysbench")
-		os.Exit(1)
+	if webhookConfig != "" {
+		k8sAccess.WebhookConfig = types.NamespacedName{Namespace: lockNamespace, Name: webhookConfig}
+		if i := strings.Index(webhookConfig, "/"); i >= 0 {
+			k8sAccess.WebhookConfig = types.NamespacedName{
+				Namespace: webhookConfig[:i], Name: webhookConfig[i+1:]}
+		}
 	}
-	if err = (&drill.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Drill"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Drill")
-		os.Exit(1)
+	reconcilers := []struct {
+		kind          string
+		newReconciler func(access k8s.Access) reconciler
+	}{
+		{"Ntttcp", func(a k8s.Access) reconciler { return &ntttcp.Reconciler{K8S: a, Log: controllerLog("Ntttcp")} }},
+		{"Ethr", func(a k8s.Access) reconciler { return &ethr.Reconciler{K8S: a, Log: controllerLog("Ethr")} }},
+		{"Ping", func(a k8s.Access) reconciler { return &ping.Reconciler{K8S: a, Log: controllerLog("Ping")} }},
+		{"Iperf2", func(a k8s.Access) reconciler { return &iperf2.Reconciler{K8S: a, Log: controllerLog("Iperf2")} }},
+		{"Iperf3", func(a k8s.Access) reconciler { return &iperf3.Reconciler{K8S: a, Log: controllerLog("Iperf3")} }},
+		{"Fio", func(a k8s.Access) reconciler { return &fio.Reconciler{K8S: a, Log: controllerLog("Fio")} }},
+		{"Sysbench", func(a k8s.Access) reconciler { return &sysbench.Reconciler{K8S: a, Log: controllerLog("Sysbench")} }},
+		{"Drill", func(a k8s.Access) reconciler { return &drill.Reconciler{K8S: a, Log: controllerLog("Drill")} }},
+		{"Pgbench", func(a k8s.Access) reconciler { return &pgbench.Reconciler{K8S: a, Log: controllerLog("Pgbench")} }},
+		{"Ioping", func(a k8s.Access) reconciler { return &ioping.Reconciler{K8S: a, Log: controllerLog("Ioping")} }},
+		{"Qperf", func(a k8s.Access) reconciler { return &qperf.Reconciler{K8S: a, Log: controllerLog("Qperf")} }},
+		{"YcsbBench", func(a k8s.Access) reconciler { return &ycsbbench.Reconciler{K8S: a, Log: controllerLog("YcsbBench")} }},
+		{"OcpLogtest", func(a k8s.Access) reconciler { return &ocplogtest.Reconciler{K8S: a, Log: controllerLog("OcpLogtest")} }},
+		{"S3Bench", func(a k8s.Access) reconciler { return &s3bench.Reconciler{K8S: a, Log: controllerLog("S3Bench")} }},
+		{"KafkaBench", func(a k8s.Access) reconciler { return &kafkabench.KafkaBenchReconciler{K8S: a, Log: controllerLog("KafkaBench")} }},
 	}
-	if err = (&pgbench.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Pgbench"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Pgbench")
-		os.Exit(1)
+
+	// Controllers are referred by their lowercase kind on the command line
+	var knownControllers []string
+	for _, entry := range reconcilers {
+		knownControllers = append(knownControllers, strings.ToLower(entry.kind))
 	}
-	if err = (&ioping.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Ioping"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Ioping")
+	enabledControllers, err := manager.SelectControllers(controllers, knownControllers)
+	if err != nil {
+		setupLog.Error(err, "Invalid --controllers")
 		os.Exit(1)
 	}
-	if err = (&qperf.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Qperf"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Qperf")
+	concurrency, err := manager.ParseMaxConcurrentReconciles(controllerConcurrency, knownControllers)
+	if err != nil {
+		setupLog.Error(err, "Invalid --controller-concurrency")
 		os.Exit(1)
 	}
-	if err = (&ycsbbench.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("YcsbBench"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "YcsbBench")
+	namespacesOfControllers, err := manager.ParseControllerNamespaces(controllerNamespaces, knownControllers, namespaces)
+	if err != nil {
+		setupLog.Error(err, "Invalid --controller-namespaces")
 		os.Exit(1)
 	}
-	if err = (&ocplogtest.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("OcpLogtest"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "OcpLogtest")
-		os.Exit(1)
+
+	var enabledKinds []string
+	for _, entry := range reconcilers {
+		name := strings.ToLower(entry.kind)
+		if !enabledControllers[name] {
+			setupLog.Info("controller is disabled", "controller", entry.kind)
+			continue
+		}
+		enabledKinds = append(enabledKinds, entry.kind)
+
+		options := controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}
+		if count, ok := concurrency[name]; ok {
+			options.MaxConcurrentReconciles = count
+		}
+		access := k8sAccess
+		access.Namespaces = namespacesOfControllers[name]
+		if err = entry.newReconciler(access).SetupWithManager(mgr, options); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", entry.kind)
+			os.Exit(1)
+		}
 	}
-	if err = (&s3bench.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("S3Bench"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "S3Bench")
-		os.Exit(1)
+	// +kubebuilder:scaffold:builder
+
+	// The v1beta1 benchmarks are converted to the stored v1alpha1 version
+	// by the webhook, regardless of the enabled controllers
+	if webhookPort > 0 {
+		mgr.GetWebhookServer().CertDir = webhookCertDir
+		for _, object := range []runtime.Object{
+			&perfv1beta1.Drill{}, &perfv1beta1.Ethr{}, &perfv1beta1.Fio{}, &perfv1beta1.Ioping{},
+			&perfv1beta1.Iperf2{}, &perfv1beta1.Iperf3{}, &perfv1beta1.KafkaBench{}, &perfv1beta1.Ntttcp{},
+			&perfv1beta1.OcpLogtest{}, &perfv1beta1.Pgbench{}, &perfv1beta1.Ping{}, &perfv1beta1.Qperf{},
+			&perfv1beta1.S3Bench{}, &perfv1beta1.Sysbench{}, &perfv1beta1.YcsbBench{},
+		} {
+			if err := ctrl.NewWebhookManagedBy(mgr).For(object).Complete(); err != nil {
+				setupLog.Error(err, "Unable to create the conversion webhook")
+				os.Exit(1)
+			}
+		}
 	}
 
-	if err = (&kafkabench.KafkaBenchReconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("KafkaBench"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "KafkaBench")
-		os.Exit(1)
+	if resultsAddr != "" {
+		resultsServer := &resultsapi.Server{
+			Reader: mgr.GetCache(),
+			Scheme: mgr.GetScheme(),
+			Kinds:  enabledKinds,
+		}
+		if resultsTokenFile != "" {
+			token, err := ioutil.ReadFile(resultsTokenFile)
+			if err != nil {
+				setupLog.Error(err, "Unable to read --results-token-file")
+				os.Exit(1)
+			}
+			resultsServer.Token = strings.TrimSpace(string(token))
+		}
+		err = mgr.Add(ctrlmanager.RunnableFunc(func(stop <-chan struct{}) error {
+			return resultsServer.Start(resultsAddr, stop)
+		}))
+		if err != nil {
+			setupLog.Error(err, "Unable to add the results API")
+			os.Exit(1)
+		}
 	}
-	// +kubebuilder:scaffold:builder
 
-	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	setupLog.Info("starting manager", "version", version.Version)
+	if err := mgr.Start(stop); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
@@ -0,0 +1,57 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// BenchmarkKinds returns the sorted benchmark kinds of the scheme
+func BenchmarkKinds(s *runtime.Scheme) []string {
+	kinds := []string{}
+	for kind := range s.KnownTypes(perfv1alpha1.GroupVersion) {
+		if object, err := s.New(perfv1alpha1.GroupVersion.WithKind(kind)); err == nil {
+			if _, ok := object.(perfv1alpha1.Benchmark); ok {
+				kinds = append(kinds, kind)
+			}
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// NewBenchmark returns an empty benchmark of the given kind, which is
+// matched case insensitively, e.g. fio returns a Fio
+func NewBenchmark(s *runtime.Scheme, kind string) (perfv1alpha1.Benchmark, error) {
+	for _, known := range BenchmarkKinds(s) {
+		if strings.EqualFold(known, kind) {
+			object, err := s.New(perfv1alpha1.GroupVersion.WithKind(known))
+			if err != nil {
+				return nil, err
+			}
+			object.GetObjectKind().SetGroupVersionKind(perfv1alpha1.GroupVersion.WithKind(known))
+			return object.(perfv1alpha1.Benchmark), nil
+		}
+	}
+	return nil, fmt.Errorf("Unknown benchmark kind: %v", kind)
+}
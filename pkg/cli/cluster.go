@@ -0,0 +1,194 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cli contains the helpers shared by the command line tools.
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	perfv1beta1 "github.com/xridge/kubestone/api/v1beta1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// ClusterFlags select the cluster and the namespace of the commands
+type ClusterFlags struct {
+	Kubeconfig    string
+	Context       string
+	Namespace     string
+	AllNamespaces bool
+}
+
+// Register registers the flags in the given flag set
+func (f *ClusterFlags) Register(fs *flag.FlagSet) {
+	fs.StringVar(&f.Kubeconfig, "kubeconfig", "", "Path to the kubeconfig file.")
+	fs.StringVar(&f.Context, "context", "", "The kubeconfig context to use.")
+	fs.StringVar(&f.Namespace, "namespace", "",
+		"The namespace of the benchmarks. The namespace of the kubeconfig context is used if empty.")
+	fs.StringVar(&f.Namespace, "n", "", "Shorthand for --namespace.")
+	fs.BoolVar(&f.AllNamespaces, "all-namespaces", false, "Use the benchmarks of all namespaces.")
+	fs.BoolVar(&f.AllNamespaces, "A", false, "Shorthand for --all-namespaces.")
+}
+
+// Scheme returns the scheme of the Kubernetes and the benchmark types
+func Scheme() (*runtime.Scheme, error) {
+	s := runtime.NewScheme()
+	if err := scheme.AddToScheme(s); err != nil {
+		return nil, err
+	}
+	if err := perfv1alpha1.AddToScheme(s); err != nil {
+		return nil, err
+	}
+	if err := perfv1beta1.AddToScheme(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (f *ClusterFlags) clientConfig() clientcmd.ClientConfig {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = f.Kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: f.Context}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+}
+
+// RESTConfig returns the configuration of the selected cluster
+func (f *ClusterFlags) RESTConfig() (*rest.Config, error) {
+	return f.clientConfig().ClientConfig()
+}
+
+// NewClient returns a client of the selected cluster together with the
+// selected namespace, which is empty when all namespaces are selected
+func (f *ClusterFlags) NewClient() (client.Client, string, error) {
+	config, err := f.RESTConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	s, err := Scheme()
+	if err != nil {
+		return nil, "", err
+	}
+	c, err := client.New(config, client.Options{Scheme: s})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if f.AllNamespaces {
+		return c, "", nil
+	}
+	namespace := f.Namespace
+	if namespace == "" {
+		if namespace, _, err = f.clientConfig().Namespace(); err != nil {
+			return nil, "", err
+		}
+	}
+	return c, namespace, nil
+}
+
+// ListResults lists the BenchmarkResults of the namespace (all namespaces
+// if empty) matching the label selector and the kind (if not empty)
+func ListResults(ctx context.Context, c client.Reader, namespace, selector, kind string) (
+	[]perfv1alpha1.BenchmarkResult, error) {
+	parsedSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var list perfv1alpha1.BenchmarkResultList
+	if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	results := []perfv1alpha1.BenchmarkResult{}
+	for _, result := range list.Items {
+		if kind != "" && !strings.EqualFold(result.Spec.Benchmark.Kind, kind) {
+			continue
+		}
+		if !parsedSelector.Matches(labels.Set(result.Labels)) {
+			continue
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// FindResult returns the result given by its reference:
+//   - <kind>/<name>: the results in the status of the finished benchmark
+//   - <name>: the BenchmarkResult of the given name or, if there is no
+//     such BenchmarkResult, the latest result of the benchmark of the
+//     given name
+func FindResult(ctx context.Context, c client.Reader, s *runtime.Scheme, namespace, reference string) (
+	*perfv1alpha1.BenchmarkResult, error) {
+	if parts := strings.SplitN(reference, "/", 2); len(parts) == 2 {
+		cr, err := NewBenchmark(s, parts[0])
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: parts[1]}, cr); err != nil {
+			return nil, err
+		}
+		status := cr.GetBenchmarkStatus()
+		if !status.Completed {
+			return nil, fmt.Errorf("%v is %v, its results are available once it is finished",
+				reference, strings.ToLower(status.Phase()))
+		}
+		kind := cr.GetObjectKind().GroupVersionKind().Kind
+		return &perfv1alpha1.BenchmarkResult{
+			ObjectMeta: metav1.ObjectMeta{Name: cr.GetName(), Namespace: cr.GetNamespace()},
+			Spec: perfv1alpha1.BenchmarkResultSpec{
+				Benchmark:      perfv1alpha1.BenchmarkReference{Kind: kind, Name: cr.GetName()},
+				CompletionTime: status.CompletionTime,
+				Metrics:        status.Metrics,
+				Aggregates:     status.Aggregates,
+			},
+		}, nil
+	}
+
+	var result perfv1alpha1.BenchmarkResult
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: reference}, &result)
+	if err == nil || !errors.IsNotFound(err) {
+		return &result, err
+	}
+
+	results, err := ListResults(ctx, c, namespace, k8s.CrNameLabel+"="+reference, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("No BenchmarkResult or benchmark found named %v", reference)
+	}
+	latest := &results[0]
+	for i := range results {
+		if latest.Spec.CompletionTime.Before(results[i].Spec.CompletionTime) {
+			latest = &results[i]
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("ListResults", func() {
+	newResult := func(name, namespace, kind, app string) *perfv1alpha1.BenchmarkResult {
+		return &perfv1alpha1.BenchmarkResult{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace,
+				Labels: map[string]string{"kubestone.xridge.io/app": app}},
+			Spec: perfv1alpha1.BenchmarkResultSpec{
+				Benchmark: perfv1alpha1.BenchmarkReference{Kind: kind, Name: name},
+			},
+		}
+	}
+
+	names := func(results []perfv1alpha1.BenchmarkResult, err error) []string {
+		Expect(err).NotTo(HaveOccurred())
+		names := []string{}
+		for _, result := range results {
+			names = append(names, result.Namespace+"/"+result.Name)
+		}
+		return names
+	}
+
+	var c client.Client
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		// The fake client decodes the objects with the client-go scheme
+		Expect(perfv1alpha1.AddToScheme(k8sscheme.Scheme)).To(Succeed())
+		c = fake.NewFakeClientWithScheme(k8sscheme.Scheme,
+			newResult("fio", "storage", "Fio", "fio"),
+			newResult("iperf3", "network", "Iperf3", "iperf3"))
+	})
+
+	It("should list the results of the namespace", func() {
+		Expect(names(ListResults(ctx, c, "storage", "", ""))).To(Equal([]string{"storage/fio"}))
+	})
+
+	It("should filter by kind and labels", func() {
+		Expect(names(ListResults(ctx, c, "", "", "iperf3"))).To(Equal([]string{"network/iperf3"}))
+		Expect(names(ListResults(ctx, c, "", "kubestone.xridge.io/app=fio", ""))).To(
+			Equal([]string{"storage/fio"}))
+	})
+
+	It("should reject invalid selectors", func() {
+		_, err := ListResults(ctx, c, "", "a==b=c", "")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("StringList", func() {
+	It("should collect the repeated values", func() {
+		var values StringList
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Var(&values, "f", "")
+		Expect(fs.Parse([]string{"-f", "a.json", "-f", "b.yaml"})).To(Succeed())
+		Expect(values).To(Equal(StringList{"a.json", "b.yaml"}))
+	})
+})
+
+var _ = Describe("FindResult", func() {
+	var c client.Client
+	var s *runtime.Scheme
+	ctx := context.Background()
+
+	newResult := func(name, crName string, completed time.Time) *perfv1alpha1.BenchmarkResult {
+		completionTime := metav1.NewTime(completed)
+		return &perfv1alpha1.BenchmarkResult{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kubestone",
+				Labels: map[string]string{"kubestone.xridge.io/cr-name": crName}},
+			Spec: perfv1alpha1.BenchmarkResultSpec{
+				Benchmark:      perfv1alpha1.BenchmarkReference{Kind: "Fio", Name: crName},
+				CompletionTime: &completionTime,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		s, err = Scheme()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(perfv1alpha1.AddToScheme(k8sscheme.Scheme)).To(Succeed())
+		day := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+		c = fake.NewFakeClientWithScheme(k8sscheme.Scheme,
+			newResult("fio-sample-1", "fio-sample", day),
+			newResult("fio-sample-2", "fio-sample", day.Add(time.Hour)),
+			&perfv1alpha1.Fio{
+				ObjectMeta: metav1.ObjectMeta{Name: "fio-running", Namespace: "kubestone"},
+				Status:     perfv1alpha1.BenchmarkStatus{Running: true},
+			},
+			&perfv1alpha1.Fio{
+				ObjectMeta: metav1.ObjectMeta{Name: "fio-done", Namespace: "kubestone"},
+				Status: perfv1alpha1.BenchmarkStatus{Completed: true,
+					Metrics: []perfv1alpha1.BenchmarkMetric{{Name: "kubestone_fio_iops", Value: "1000"}}},
+			})
+	})
+
+	It("should find the results by their name", func() {
+		result, err := FindResult(ctx, c, s, "kubestone", "fio-sample-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Name).To(Equal("fio-sample-1"))
+	})
+
+	It("should find the latest result of a benchmark", func() {
+		result, err := FindResult(ctx, c, s, "kubestone", "fio-sample")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Name).To(Equal("fio-sample-2"))
+		_, err = FindResult(ctx, c, s, "kubestone", "missing")
+		Expect(err).To(MatchError("No BenchmarkResult or benchmark found named missing"))
+	})
+
+	It("should read the results of finished benchmarks", func() {
+		result, err := FindResult(ctx, c, s, "kubestone", "fio/fio-done")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Spec.Benchmark.Kind).To(Equal("Fio"))
+		Expect(result.Spec.Metrics).To(HaveLen(1))
+		_, err = FindResult(ctx, c, s, "kubestone", "fio/fio-running")
+		Expect(err).To(MatchError(ContainSubstring("is running")))
+	})
+})
@@ -0,0 +1,62 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Command is a subcommand of a command line tool
+type Command struct {
+	Description string
+	Run         func(args []string) error
+}
+
+// Main runs the subcommand selected by the first argument and exits
+// with a non-zero status on failure
+func Main(name string, commands map[string]Command) {
+	if len(os.Args) < 2 {
+		usage(os.Stderr, name, commands)
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage(os.Stderr, name, commands)
+		os.Exit(2)
+	}
+
+	if err := cmd.Run(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage(w io.Writer, name string, commands map[string]Command) {
+	fmt.Fprintf(w, "Usage: %v <command> [flags]\n\nCommands:\n", name)
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %v\n", name, commands[name].Description)
+	}
+	fmt.Fprintf(w, "\nRun '%v <command> -h' for the flags of the command.\n", name)
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// DefaultVolumeSize is the size of the generated volumes when only their
+// storage class is given
+const DefaultVolumeSize = "1Gi"
+
+// RunOptions describe a benchmark created from command line flags
+type RunOptions struct {
+	// Name of the benchmark. A name prefixed by the lowercase kind is
+	// generated if empty.
+	Name      string
+	Namespace string
+	// Image of the benchmark tool, the default image of the kind is
+	// used if empty
+	Image string
+	// StorageClass and Size of the generated volume of the storage
+	// benchmarks
+	StorageClass string
+	Size         string
+	// Node and ServerNode are the nodes of the client and the server
+	// pods
+	Node       string
+	ServerNode string
+	Iterations int32
+	// TTLSecondsAfterFinished is not set if negative
+	TTLSecondsAfterFinished int32
+	// Set holds path=value assignments of spec fields, e.g.
+	// postgres.host=db. Values are parsed as JSON if possible.
+	Set []string
+	// Args are the arguments of the benchmark tool
+	Args []string
+}
+
+// runDefaults describes how a kind is built from the RunOptions
+type runDefaults struct {
+	// image is the default image of the benchmark tool
+	image string
+	// spec holds the default values of the required fields as JSON
+	spec string
+	// args is the path of the tool arguments
+	args string
+	// volume tells if the benchmark has a volume under spec.volume
+	volume bool
+	// nodeName and serverNodeName are the paths of the nodeName
+	// of the client and the server pods
+	nodeName       string
+	serverNodeName string
+}
+
+const (
+	podNodeName    = "podConfig.podScheduling.nodeName"
+	clientNodeName = "clientConfiguration.podScheduling.nodeName"
+	serverNodeName = "serverConfiguration.podScheduling.nodeName"
+	emptyDirVolume = `{"volume": {"volumeSource": {"emptyDir": {}}}}`
+)
+
+var kindDefaults = map[string]runDefaults{
+	"Drill": {image: "xridge/drill:0.5.0", args: "options", nodeName: podNodeName},
+	"Ethr": {args: "clientConfiguration.cmdLineArgs",
+		nodeName: clientNodeName, serverNodeName: serverNodeName},
+	"Fio": {image: "xridge/fio:3.13", spec: emptyDirVolume, args: "cmdLineArgs", volume: true,
+		nodeName: podNodeName},
+	"Ioping": {image: "xridge/ioping:1.1", spec: emptyDirVolume, args: "args", volume: true,
+		nodeName: podNodeName},
+	"Iperf2": {args: "clientConfiguration.cmdLineArgs",
+		nodeName: clientNodeName, serverNodeName: serverNodeName},
+	"Iperf3": {image: "xridge/iperf3:3.7.0", args: "clientConfiguration.cmdLineArgs",
+		nodeName: clientNodeName, serverNodeName: serverNodeName},
+	"KafkaBench": {image: "confluentinc/cp-kafka:5.2.1", nodeName: podNodeName},
+	"Ntttcp": {args: "clientConfiguration.cmdLineArgs",
+		nodeName: clientNodeName, serverNodeName: serverNodeName},
+	"OcpLogtest": {image: "quay.io/mffiedler/ocp-logtest:latest", nodeName: podNodeName},
+	"Pgbench":    {image: "xridge/pgbench", args: "args", nodeName: podNodeName},
+	"Ping": {args: "options",
+		nodeName: clientNodeName, serverNodeName: serverNodeName},
+	"Qperf": {image: "xridge/qperf:0.4.11-r0", spec: `{"tests": ["tcp_bw", "tcp_lat"]}`, args: "options",
+		nodeName: clientNodeName, serverNodeName: serverNodeName},
+	"S3Bench": {nodeName: podNodeName},
+	"Sysbench": {image: "xridge/sysbench:1.0.17-1", spec: `{"testName": "cpu", "command": "run"}`,
+		args: "options", nodeName: podNodeName},
+	"YcsbBench": {image: "diamantisolutions/ycsb:latest", nodeName: podNodeName},
+}
+
+// BuildBenchmark creates a benchmark of the given kind from the options.
+// The spec starts from the defaults of the kind, which are overridden by
+// the options. Unknown spec fields are rejected.
+func BuildBenchmark(s *runtime.Scheme, kind string, opts *RunOptions) (perfv1alpha1.Benchmark, error) {
+	cr, err := NewBenchmark(s, kind)
+	if err != nil {
+		return nil, err
+	}
+	gvk := cr.GetObjectKind().GroupVersionKind()
+	defaults := kindDefaults[gvk.Kind]
+
+	spec := map[string]interface{}{}
+	if defaults.spec != "" {
+		if err := json.Unmarshal([]byte(defaults.spec), &spec); err != nil {
+			return nil, err
+		}
+	}
+	set := func(path string, value interface{}) {
+		if err == nil {
+			err = setField(spec, path, value)
+		}
+	}
+
+	if opts.Image != "" {
+		set("image.name", opts.Image)
+	} else if defaults.image != "" {
+		set("image.name", defaults.image)
+	}
+
+	if len(opts.Args) > 0 {
+		if defaults.args == "" {
+			return nil, fmt.Errorf("%v does not take tool arguments, use --set instead", gvk.Kind)
+		}
+		set(defaults.args, joinArgs(opts.Args))
+	}
+
+	if opts.StorageClass != "" || opts.Size != "" {
+		if !defaults.volume {
+			return nil, fmt.Errorf("%v has no volume", gvk.Kind)
+		}
+		size := opts.Size
+		if size == "" {
+			size = DefaultVolumeSize
+		}
+		claimSpec := map[string]interface{}{
+			"accessModes": []interface{}{"ReadWriteOnce"},
+			"resources": map[string]interface{}{
+				"requests": map[string]interface{}{"storage": size},
+			},
+		}
+		if opts.StorageClass != "" {
+			claimSpec["storageClassName"] = opts.StorageClass
+		}
+		set("volume", map[string]interface{}{
+			"volumeSource": map[string]interface{}{
+				"persistentVolumeClaim": map[string]interface{}{"claimName": perfv1alpha1.GeneratedPVC},
+			},
+			"persistentVolumeClaimSpec": claimSpec,
+		})
+	}
+
+	if opts.Node != "" {
+		if defaults.nodeName == "" {
+			return nil, fmt.Errorf("The node of %v cannot be set, use --set instead", gvk.Kind)
+		}
+		set(defaults.nodeName, opts.Node)
+	}
+	if opts.ServerNode != "" {
+		if defaults.serverNodeName == "" {
+			return nil, fmt.Errorf("%v has no server", gvk.Kind)
+		}
+		set(defaults.serverNodeName, opts.ServerNode)
+	}
+
+	if opts.Iterations > 0 {
+		set("iterations", opts.Iterations)
+	}
+	if opts.TTLSecondsAfterFinished >= 0 {
+		set("ttlSecondsAfterFinished", opts.TTLSecondsAfterFinished)
+	}
+
+	for _, assignment := range opts.Set {
+		parts := strings.SplitN(assignment, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid assignment %q, expected path=value", assignment)
+		}
+		var value interface{}
+		if json.Unmarshal([]byte(parts[1]), &value) != nil {
+			value = parts[1]
+		}
+		set(parts[0], value)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]interface{}{"namespace": opts.Namespace}
+	if opts.Name != "" {
+		metadata["name"] = opts.Name
+	} else {
+		metadata["generateName"] = strings.ToLower(gvk.Kind) + "-"
+	}
+	encoded, err := json.Marshal(map[string]interface{}{
+		"apiVersion": gvk.GroupVersion().String(),
+		"kind":       gvk.Kind,
+		"metadata":   metadata,
+		"spec":       spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cr); err != nil {
+		return nil, fmt.Errorf("Invalid %v spec: %v", gvk.Kind, err)
+	}
+	return cr, nil
+}
+
+// setField sets the field of the object at the given dot separated path,
+// creating the missing parent objects
+func setField(object map[string]interface{}, path string, value interface{}) error {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := object[key]
+		if !ok {
+			child = map[string]interface{}{}
+			object[key] = child
+		}
+		childObject, ok := child.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("Cannot set %v: %v is not an object", path, key)
+		}
+		object = childObject
+	}
+	object[keys[len(keys)-1]] = value
+	return nil
+}
+
+// joinArgs joins the arguments into a command line, quoting the
+// arguments containing white space
+func joinArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t\n\"") {
+			arg = strconv.Quote(arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
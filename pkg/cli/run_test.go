@@ -0,0 +1,119 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"flag"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("BuildBenchmark", func() {
+	var s *runtime.Scheme
+
+	BeforeEach(func() {
+		var err error
+		s, err = Scheme()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should find the kinds case insensitively", func() {
+		Expect(BenchmarkKinds(s)).To(ContainElement("Fio"))
+		Expect(BenchmarkKinds(s)).NotTo(ContainElement("BenchmarkResult"))
+		cr, err := NewBenchmark(s, "IPERF3")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cr).To(BeAssignableToTypeOf(&perfv1alpha1.Iperf3{}))
+		_, err = NewBenchmark(s, "foo")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should build a fio with a generated volume", func() {
+		cr, err := BuildBenchmark(s, "fio", &RunOptions{
+			Namespace:               "kubestone",
+			StorageClass:            "fast",
+			Node:                    "node-1",
+			Iterations:              3,
+			TTLSecondsAfterFinished: -1,
+			Set:                     []string{"podConfig.podLabels.team=storage"},
+			Args:                    []string{"--rw=randread", "--name=random read"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		fio := cr.(*perfv1alpha1.Fio)
+		Expect(fio.Namespace).To(Equal("kubestone"))
+		Expect(fio.GenerateName).To(Equal("fio-"))
+		Expect(fio.Spec.Image.Name).To(Equal(kindDefaults["Fio"].image))
+		Expect(fio.Spec.CmdLineArgs).To(Equal(`--rw=randread "--name=random read"`))
+		Expect(fio.Spec.Volume.VolumeSource.PersistentVolumeClaim.ClaimName).To(
+			Equal(perfv1alpha1.GeneratedPVC))
+		Expect(*fio.Spec.Volume.PersistentVolumeClaimSpec.StorageClassName).To(Equal("fast"))
+		Expect(fio.Spec.Volume.PersistentVolumeClaimSpec.Resources.Requests).To(
+			HaveKeyWithValue(corev1.ResourceStorage, resource.MustParse(DefaultVolumeSize)))
+		Expect(fio.Spec.PodConfig.PodScheduling.NodeName).To(Equal("node-1"))
+		Expect(fio.Spec.PodConfig.PodLabels).To(HaveKeyWithValue("team", "storage"))
+		Expect(fio.Spec.Iterations).To(Equal(int32(3)))
+		Expect(fio.Spec.TTLSecondsAfterFinished).To(BeNil())
+	})
+
+	It("should set the server node of the network benchmarks", func() {
+		cr, err := BuildBenchmark(s, "iperf3", &RunOptions{
+			Name: "iperf3", Node: "node-1", ServerNode: "node-2", TTLSecondsAfterFinished: 60,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		iperf3 := cr.(*perfv1alpha1.Iperf3)
+		Expect(iperf3.Name).To(Equal("iperf3"))
+		Expect(iperf3.Spec.ClientConfiguration.PodScheduling.NodeName).To(Equal("node-1"))
+		Expect(iperf3.Spec.ServerConfiguration.PodScheduling.NodeName).To(Equal("node-2"))
+		Expect(*iperf3.Spec.TTLSecondsAfterFinished).To(Equal(int32(60)))
+	})
+
+	It("should reject the options not applicable to the kind", func() {
+		_, err := BuildBenchmark(s, "iperf3", &RunOptions{StorageClass: "fast", TTLSecondsAfterFinished: -1})
+		Expect(err).To(MatchError("Iperf3 has no volume"))
+		_, err = BuildBenchmark(s, "fio", &RunOptions{ServerNode: "node-2", TTLSecondsAfterFinished: -1})
+		Expect(err).To(MatchError("Fio has no server"))
+	})
+
+	It("should reject unknown and invalid fields", func() {
+		_, err := BuildBenchmark(s, "fio", &RunOptions{Set: []string{"foo=bar"}, TTLSecondsAfterFinished: -1})
+		Expect(err).To(HaveOccurred())
+		_, err = BuildBenchmark(s, "fio", &RunOptions{Set: []string{"image=foo"}, TTLSecondsAfterFinished: -1})
+		Expect(err).To(HaveOccurred())
+		_, err = BuildBenchmark(s, "fio", &RunOptions{Set: []string{"image"}, TTLSecondsAfterFinished: -1})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("ParseArgs", func() {
+	It("should parse the flags between the positional arguments", func() {
+		fs := flag.NewFlagSet("run", flag.ContinueOnError)
+		name := fs.String("name", "", "")
+		positional, rest, err := ParseArgs(fs, []string{"fio", "--name", "test", "extra", "--", "--rw=read"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*name).To(Equal("test"))
+		Expect(positional).To(Equal([]string{"fio", "extra"}))
+		Expect(rest).To(Equal([]string{"--rw=read"}))
+	})
+})
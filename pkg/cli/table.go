@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// WriteMetrics writes the metrics as a table. When the metrics have
+// aggregates, i.e. the benchmark had iterations, their statistics are
+// shown next to the values.
+func WriteMetrics(w io.Writer, metrics []perfv1alpha1.BenchmarkMetric,
+	aggregates []perfv1alpha1.MetricAggregate) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if len(aggregates) == 0 {
+		fmt.Fprintln(table, "METRIC\tLABELS\tVALUE")
+	} else {
+		fmt.Fprintln(table, "METRIC\tLABELS\tVALUE\tSTDDEV\t95% CI\tMIN\tMAX\tN\tOUTLIERS")
+	}
+
+	for _, metric := range metrics {
+		labels := FormatLabels(metric.Labels)
+		if len(aggregates) == 0 {
+			fmt.Fprintf(table, "%v\t%v\t%v\n", metric.Name, labels, metric.Value)
+			continue
+		}
+
+		aggregate := findAggregate(aggregates, &metric)
+		if aggregate == nil {
+			fmt.Fprintf(table, "%v\t%v\t%v\t\t\t\t\t\t\n", metric.Name, labels, metric.Value)
+			continue
+		}
+		interval := ""
+		if aggregate.ConfidenceIntervalLower != "" {
+			interval = aggregate.ConfidenceIntervalLower + " - " + aggregate.ConfidenceIntervalUpper
+		}
+		outliers := make([]string, len(aggregate.Outliers))
+		for i, outlier := range aggregate.Outliers {
+			outliers[i] = fmt.Sprint(outlier)
+		}
+		fmt.Fprintf(table, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", metric.Name, labels, metric.Value,
+			aggregate.StdDev, interval, aggregate.Min, aggregate.Max, len(aggregate.Values),
+			strings.Join(outliers, ","))
+	}
+	return table.Flush()
+}
+
+// WriteComparisons writes the regression checks as a table
+func WriteComparisons(w io.Writer, comparisons []perfv1alpha1.MetricComparison) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "METRIC\tLABELS\tVALUE\tBASELINE\tDEVIATION %\tPASSED\tMESSAGE")
+	for _, comparison := range comparisons {
+		fmt.Fprintf(table, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n", comparison.Metric,
+			FormatLabels(comparison.Labels), comparison.Value, comparison.Baseline,
+			comparison.DeviationPercent, comparison.Passed, comparison.Message)
+	}
+	return table.Flush()
+}
+
+func findAggregate(aggregates []perfv1alpha1.MetricAggregate,
+	metric *perfv1alpha1.BenchmarkMetric) *perfv1alpha1.MetricAggregate {
+	for i := range aggregates {
+		if aggregates[i].Name == metric.Name &&
+			FormatLabels(aggregates[i].Labels) == FormatLabels(metric.Labels) {
+			return &aggregates[i]
+		}
+	}
+	return nil
+}
+
+// FormatLabels formats the labels as sorted name=value pairs
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for name, value := range labels {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("WriteMetrics", func() {
+	metrics := []perfv1alpha1.BenchmarkMetric{
+		{Name: "kubestone_fio_iops", Labels: map[string]string{"rw": "read", "job": "a"}, Value: "1000"},
+	}
+
+	It("should write the values", func() {
+		var out bytes.Buffer
+		Expect(WriteMetrics(&out, metrics, nil)).To(Succeed())
+		Expect(out.String()).To(Equal("METRIC              LABELS         VALUE\n" +
+			"kubestone_fio_iops  job=a,rw=read  1000\n"))
+	})
+
+	It("should write the statistics of the iterations", func() {
+		var out bytes.Buffer
+		Expect(WriteMetrics(&out, metrics, []perfv1alpha1.MetricAggregate{{
+			Name: "kubestone_fio_iops", Labels: map[string]string{"job": "a", "rw": "read"},
+			Values: []string{"900", "1000", "1100"}, StdDev: "100", Min: "900", Max: "1100",
+			ConfidenceIntervalLower: "751.6", ConfidenceIntervalUpper: "1248.4",
+		}})).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("1000   100     751.6 - 1248.4  900  1100  3"))
+	})
+})
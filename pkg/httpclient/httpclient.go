@@ -0,0 +1,47 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package httpclient contains the HTTP helpers shared by the result
+// sinks and the webhooks.
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// maxErrorBody limits the part of the error responses read into the
+// errors, so the endpoints cannot make the manager buffer large bodies
+const maxErrorBody = 4 << 10
+
+// Send sends the request and converts the non-2xx responses to errors,
+// which contain the first maxErrorBody bytes of the response body
+func Send(ctx context.Context, client *http.Client, request *http.Request) error {
+	response, err := client.Do(request.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 {
+		body, _ := ioutil.ReadAll(io.LimitReader(response.Body, maxErrorBody))
+		return fmt.Errorf("%v %v: %v %s", request.Method, request.URL, response.Status, body)
+	}
+	return nil
+}
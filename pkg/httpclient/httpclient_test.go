@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Send", func() {
+	var server *httptest.Server
+	var status int
+	var body string
+
+	BeforeEach(func() {
+		body = "details"
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should accept 2xx responses", func() {
+		status = http.StatusAccepted
+		request, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+		Expect(Send(context.Background(), server.Client(), request)).To(Succeed())
+	})
+
+	It("should report the other responses with their body", func() {
+		status = http.StatusBadRequest
+		request, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+		err := Send(context.Background(), server.Client(), request)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("400 Bad Request details"))
+	})
+
+	It("should limit the body of the error responses", func() {
+		status = http.StatusInternalServerError
+		body = strings.Repeat("x", 2*maxErrorBody)
+		request, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+		err := Send(context.Background(), server.Client(), request)
+		Expect(err).To(HaveOccurred())
+		Expect(strings.Count(err.Error(), "x")).To(Equal(maxErrorBody))
+	})
+})
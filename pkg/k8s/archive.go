@@ -0,0 +1,264 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;create
+
+const (
+	// ArchivePollInterval is the time between the checks of a running upload
+	ArchivePollInterval = 10 * time.Second
+	// DefaultArchiveImage is used to upload the results when the
+	// benchmark does not specify the image of the uploader
+	DefaultArchiveImage = "amazon/aws-cli:2.0.6"
+
+	// specFileName is the name of the object holding the spec of the benchmark
+	specFileName = "spec.yaml"
+	resultsDir   = "/results"
+	reportsDir   = "/reports"
+)
+
+// archiveScript copies the pod logs, the spec and the report files written
+// since the start of the benchmark to a single directory and uploads it
+const archiveScript = `set -e
+mkdir -p /tmp/archive/reports
+cp -L ` + resultsDir + `/* /tmp/archive/
+if [ -n "$REPORT_PATTERN" ]; then
+  find ` + reportsDir + ` -maxdepth 1 -type f -name "$REPORT_PATTERN" -newermt "@$REPORTS_SINCE" \
+    -exec cp {} /tmp/archive/reports/ \;
+fi
+aws ${ENDPOINT_URL:+--endpoint-url "$ENDPOINT_URL"} s3 cp --recursive --no-progress \
+  /tmp/archive "s3://$BUCKET/$KEY_PREFIX"
+`
+
+// uploadedObjectRegexp matches the objects in the output of aws s3 cp
+var uploadedObjectRegexp = regexp.MustCompile(`(?m)^upload: \S+ to s3://[^/]+/(\S+)$`)
+
+// ArchiveName returns the name of the ConfigMap and the Job
+// used to upload the results of the given benchmark
+func ArchiveName(cr metav1.Object) string {
+	return cr.GetName() + "-archive"
+}
+
+// startArchive creates the uploader job of the benchmark. The given pod
+// logs and the spec of the benchmark are passed to the uploader via a
+// ConfigMap, while the report files are read from the HostPath volume
+// of the node where the benchmark was running. Benchmarks running on
+// several nodes only archive the reports of their first node, the
+// reports of the other nodes are not uploaded. The ConfigMap and the job
+// which already exist (e.g. when the completion is retried) are kept.
+func (a *Access) startArchive(ctx context.Context, cr perfv1alpha1.Benchmark,
+	logs map[string]string, startTime *metav1.Time) error {
+	archive := cr.GetRunPolicy().Archive
+	spec, err := renderSpec(cr)
+	if err != nil {
+		return err
+	}
+
+	data := partialResultsData(logs, maxPartialResultsSize-len(spec))
+	data[specFileName] = spec
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ArchiveName(cr),
+			Namespace: cr.GetNamespace(),
+		},
+		Data: data,
+	}
+	if err := a.CreateWithReference(ctx, configMap, cr); err != nil {
+		return err
+	}
+
+	var logSpec *perfv1alpha1.LogSpec
+	if reportWriter, ok := cr.(perfv1alpha1.ReportWriter); ok && reportWriter.GetLogSpec().Enabled {
+		logSpec = reportWriter.GetLogSpec()
+	}
+	since := time.Now()
+	if startTime != nil {
+		since = startTime.Time
+	}
+	job := newArchiveJob(cr, archive, logSpec, cr.GetBenchmarkStatus().NodeNames, since)
+	return a.CreateWithReference(ctx, job, cr)
+}
+
+// checkArchive records the outcome of the uploader job in the status of
+// the benchmark. The returned duration is the time until the next check,
+// or zero if the upload is no longer pending.
+func (a *Access) checkArchive(ctx context.Context, cr perfv1alpha1.Benchmark) (time.Duration, error) {
+	status := cr.GetBenchmarkStatus()
+	if status.Archive == nil || status.Archive.Phase != perfv1alpha1.ExportPending {
+		return 0, nil
+	}
+
+	nn := types.NamespacedName{Namespace: cr.GetNamespace(), Name: ArchiveName(cr)}
+	var job batchv1.Job
+	if err := getObject(ctx, a.Clientset.BatchV1().RESTClient(), "jobs", nn, &job); err != nil {
+		return 0, err
+	}
+	finished, succeeded, _ := jobOutcome(&job)
+	if !finished {
+		return ArchivePollInterval, nil
+	}
+
+	if succeeded {
+		logs, err := a.GetJobLogs(ctx, nn)
+		if err != nil {
+			return 0, err
+		}
+		status.Archive.Phase = perfv1alpha1.ExportSucceeded
+		status.Archive.Objects = uploadedObjects(cr.GetRunPolicy().Archive, logs)
+	} else {
+		status.Archive.Phase = perfv1alpha1.ExportFailed
+		status.Archive.Message = fmt.Sprintf("Uploader job %v failed", job.Name)
+		_ = a.RecordEventf(cr, corev1.EventTypeWarning, ExportFailed,
+			"Unable to upload the results: job %v failed", job.Name)
+	}
+	return 0, a.Client.Status().Update(ctx, cr)
+}
+
+// renderSpec returns the benchmark without its status as YAML
+func renderSpec(cr perfv1alpha1.Benchmark) (string, error) {
+	rendered := cr.DeepCopyObject().(perfv1alpha1.Benchmark)
+	*rendered.GetBenchmarkStatus() = perfv1alpha1.BenchmarkStatus{}
+	rendered.SetManagedFields(nil)
+	out, err := yaml.Marshal(rendered)
+	return string(out), err
+}
+
+// archiveKeyPrefix returns the common prefix of the uploaded objects
+func archiveKeyPrefix(cr metav1.Object, archive *perfv1alpha1.ArchiveSpec) string {
+	return strings.TrimPrefix(path.Join(archive.Prefix, cr.GetNamespace(), cr.GetName()), "/") + "/"
+}
+
+// newArchiveJob creates the job uploading the results of the benchmark. When
+// logSpec is given, the job is scheduled to the (first) node of the benchmark
+// to access the report files on the HostPath volume.
+func newArchiveJob(cr perfv1alpha1.Benchmark, archive *perfv1alpha1.ArchiveSpec,
+	logSpec *perfv1alpha1.LogSpec, nodeNames []string, since time.Time) *batchv1.Job {
+	objectMeta := metav1.ObjectMeta{
+		Name:      ArchiveName(cr),
+		Namespace: cr.GetNamespace(),
+	}
+
+	image := archive.Image
+	if image == "" {
+		image = DefaultArchiveImage
+	}
+
+	podConfig := perfv1alpha1.PodConfigurationSpec{}
+	volumes := []corev1.Volume{
+		{
+			Name: "results",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: ArchiveName(cr),
+					},
+				},
+			},
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "results",
+			MountPath: resultsDir,
+		},
+	}
+	env := []corev1.EnvVar{
+		{Name: "BUCKET", Value: archive.Bucket},
+		{Name: "KEY_PREFIX", Value: archiveKeyPrefix(cr, archive)},
+		{Name: "ENDPOINT_URL", Value: archive.Endpoint},
+		{Name: "AWS_DEFAULT_REGION", Value: archive.Region},
+	}
+
+	if logSpec != nil && len(nodeNames) > 0 {
+		podConfig.PodScheduling.NodeName = nodeNames[0]
+		volumes = append(volumes, corev1.Volume{
+			Name: "reports",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: logSpec.Volume.Path,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "reports",
+			MountPath: reportsDir,
+			ReadOnly:  true,
+		})
+		env = append(env,
+			corev1.EnvVar{Name: "REPORT_PATTERN", Value: logSpec.FileName + "*" + logSpec.Extension},
+			corev1.EnvVar{Name: "REPORTS_SINCE", Value: strconv.FormatInt(since.Unix(), 10)})
+	}
+
+	job := NewPerfJob(objectMeta, "archive", perfv1alpha1.ImageSpec{Name: image}, podConfig)
+	backoffLimit := int32(2)
+	job.Spec.BackoffLimit = &backoffLimit
+	job.Spec.Template.Spec.Volumes = volumes
+	container := &job.Spec.Template.Spec.Containers[0]
+	container.Command = []string{"/bin/sh", "-c", archiveScript}
+	container.Env = env
+	container.EnvFrom = []corev1.EnvFromSource{
+		{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: archive.CredentialsSecret,
+				},
+			},
+		},
+	}
+	container.VolumeMounts = volumeMounts
+	return job
+}
+
+// uploadedObjects returns the URLs of the objects listed in the logs of
+// the uploader pods
+func uploadedObjects(archive *perfv1alpha1.ArchiveSpec, logs map[string]string) []string {
+	objects := []string{}
+	for _, log := range logs {
+		for _, match := range uploadedObjectRegexp.FindAllStringSubmatch(log, -1) {
+			objects = appendUnique(objects, archiveURL(archive, match[1]))
+		}
+	}
+	sort.Strings(objects)
+	return objects
+}
+
+// archiveURL returns the URL of the given key in the bucket of the archive
+func archiveURL(archive *perfv1alpha1.ArchiveSpec, key string) string {
+	if archive.Endpoint != "" {
+		return strings.TrimSuffix(archive.Endpoint, "/") + "/" + archive.Bucket + "/" + key
+	}
+	return "https://" + archive.Bucket + ".s3.amazonaws.com/" + key
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("archive", func() {
+	since := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	archive := perfv1alpha1.ArchiveSpec{
+		Endpoint:          "http://minio:9000/",
+		Bucket:            "results",
+		Prefix:            "nightly",
+		CredentialsSecret: "s3-credentials",
+	}
+	cr := perfv1alpha1.Iperf3{
+		ObjectMeta: metav1.ObjectMeta{Name: "iperf3-sample", Namespace: "kubestone"},
+		Spec: perfv1alpha1.Iperf3Spec{
+			Log: perfv1alpha1.LogSpec{
+				Enabled:   true,
+				FileName:  "iperf3-",
+				Extension: ".log",
+				Volume:    perfv1alpha1.VolumeInfo{Name: "logs", Path: "/var/log/kubestone"},
+			},
+		},
+	}
+
+	Describe("archiveKeyPrefix", func() {
+		It("should place the objects under namespace and name", func() {
+			Expect(archiveKeyPrefix(&cr, &archive)).To(Equal("nightly/kubestone/iperf3-sample/"))
+			Expect(archiveKeyPrefix(&cr, &perfv1alpha1.ArchiveSpec{})).To(Equal("kubestone/iperf3-sample/"))
+		})
+	})
+
+	Describe("newArchiveJob", func() {
+		envOf := func(container corev1.Container) map[string]string {
+			env := map[string]string{}
+			for _, variable := range container.Env {
+				env[variable.Name] = variable.Value
+			}
+			return env
+		}
+
+		It("should upload the reports from the node of the benchmark", func() {
+			job := newArchiveJob(&cr, &archive, cr.GetLogSpec(), []string{"node-1"}, since)
+			podSpec := job.Spec.Template.Spec
+			Expect(job.Name).To(Equal("iperf3-sample-archive"))
+			Expect(podSpec.NodeName).To(Equal("node-1"))
+			Expect(podSpec.Volumes).To(HaveLen(2))
+			Expect(podSpec.Volumes[1].HostPath.Path).To(Equal("/var/log/kubestone"))
+
+			container := podSpec.Containers[0]
+			Expect(container.Image).To(Equal(DefaultArchiveImage))
+			Expect(container.EnvFrom[0].SecretRef.Name).To(Equal("s3-credentials"))
+			Expect(envOf(container)).To(Equal(map[string]string{
+				"BUCKET":             "results",
+				"KEY_PREFIX":         "nightly/kubestone/iperf3-sample/",
+				"ENDPOINT_URL":       "http://minio:9000/",
+				"AWS_DEFAULT_REGION": "",
+				"REPORT_PATTERN":     "iperf3-*.log",
+				"REPORTS_SINCE":      "1569931200",
+			}))
+		})
+
+		It("should only upload the logs and the spec without report files", func() {
+			job := newArchiveJob(&cr, &archive, nil, []string{"node-1"}, since)
+			podSpec := job.Spec.Template.Spec
+			Expect(podSpec.NodeName).To(BeEmpty())
+			Expect(podSpec.Volumes).To(HaveLen(1))
+			Expect(envOf(podSpec.Containers[0])).NotTo(HaveKey("REPORT_PATTERN"))
+		})
+	})
+
+	Describe("uploadedObjects", func() {
+		logs := map[string]string{
+			"iperf3-sample-archive-x7k2p": "upload: /tmp/archive/spec.yaml to s3://results/nightly/kubestone/iperf3-sample/spec.yaml\n" +
+				"upload: /tmp/archive/reports/iperf3-1.log to s3://results/nightly/kubestone/iperf3-sample/reports/iperf3-1.log\n",
+		}
+
+		It("should return path style URLs for custom endpoints", func() {
+			Expect(uploadedObjects(&archive, logs)).To(Equal([]string{
+				"http://minio:9000/results/nightly/kubestone/iperf3-sample/reports/iperf3-1.log",
+				"http://minio:9000/results/nightly/kubestone/iperf3-sample/spec.yaml",
+			}))
+		})
+
+		It("should return virtual hosted URLs for AWS", func() {
+			Expect(uploadedObjects(&perfv1alpha1.ArchiveSpec{Bucket: "results"}, logs)).To(ContainElement(
+				"https://results.s3.amazonaws.com/nightly/kubestone/iperf3-sample/spec.yaml"))
+		})
+	})
+
+	Describe("renderSpec", func() {
+		It("should omit the status", func() {
+			completed := cr.DeepCopy()
+			completed.Status.Completed = true
+			completed.Status.NodeNames = []string{"node-1"}
+
+			spec, err := renderSpec(completed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec).To(ContainSubstring("name: iperf3-sample"))
+			Expect(spec).NotTo(ContainSubstring("node-1"))
+			Expect(completed.Status.Completed).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,122 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=create
+
+// maxPartialResultsSize keeps the partial results ConfigMap
+// below the 1MiB object size limit of Kubernetes
+const maxPartialResultsSize = 900 * 1024
+
+// PartialResultsName returns the name of the ConfigMap
+// holding the partial results of the given cancelled job
+func PartialResultsName(job *batchv1.Job) string {
+	return job.Name + "-partial-results"
+}
+
+// CancelBenchmark aborts the given benchmark. The logs of the jobs
+// found in objects are saved as partial results to a ConfigMap
+// before the objects are deleted. Finally the exclusivity locks are
+// released and the benchmark is moved to the Cancelled terminal state.
+func (a *Access) CancelBenchmark(ctx context.Context, cr perfv1alpha1.Benchmark, objects ...metav1.Object) error {
+	for _, object := range objects {
+		if job, ok := object.(*batchv1.Job); ok {
+			if err := a.savePartialResults(ctx, job, cr); err != nil {
+				return err
+			}
+		}
+
+		if err := a.DeleteObject(ctx, object, cr); err != nil {
+			return err
+		}
+	}
+
+	if err := a.ReleaseLock(ctx, cr); err != nil {
+		return err
+	}
+
+	status := cr.GetBenchmarkStatus()
+	status.Running = false
+	status.Cancelled = true
+	status.QueuePosition = 0
+	completionTime := metav1.Now()
+	status.CompletionTime = &completionTime
+	if err := a.Client.Status().Update(ctx, cr); err != nil {
+		return err
+	}
+
+	_ = a.RecordEventf(cr, corev1.EventTypeNormal, Cancelled, "Benchmark cancelled")
+
+	return nil
+}
+
+func (a *Access) savePartialResults(ctx context.Context, job *batchv1.Job, owner metav1.Object) error {
+	logs, err := a.GetJobLogs(ctx, types.NamespacedName{
+		Namespace: job.Namespace,
+		Name:      job.Name,
+	})
+	if IgnoreNotFound(err) != nil {
+		return err
+	}
+	if len(logs) == 0 {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PartialResultsName(job),
+			Namespace: job.Namespace,
+		},
+		Data: partialResultsData(logs, maxPartialResultsSize),
+	}
+	return a.CreateWithReference(ctx, configMap, owner)
+}
+
+// partialResultsData converts the pod logs to ConfigMap data, sharing
+// the given size limit between the pods. The end of the logs is kept
+// as that is where the benchmarks report their results. A negative
+// limit (e.g. when the archived spec alone exceeds the size of the
+// ConfigMap) keeps no logs.
+func partialResultsData(logs map[string]string, limit int) map[string]string {
+	data := map[string]string{}
+	if len(logs) == 0 {
+		return data
+	}
+	if limit < 0 {
+		limit = 0
+	}
+
+	podLimit := limit / len(logs)
+	for podName, log := range logs {
+		if len(log) > podLimit {
+			log = log[len(log)-podLimit:]
+		}
+		data[podName+".log"] = log
+	}
+	return data
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("partial results", func() {
+	Context("name", func() {
+		It("should be derived from the job name", func() {
+			job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "fio-sample"}}
+			Expect(PartialResultsName(job)).To(Equal("fio-sample-partial-results"))
+		})
+	})
+
+	Context("without logs", func() {
+		It("should be empty", func() {
+			Expect(partialResultsData(map[string]string{}, 10)).To(BeEmpty())
+		})
+	})
+
+	Context("with negative limit", func() {
+		It("should keep no logs", func() {
+			data := partialResultsData(map[string]string{"pod": "result"}, -10)
+			Expect(data).To(Equal(map[string]string{"pod.log": ""}))
+		})
+	})
+
+	Context("with logs below the limit", func() {
+		It("should keep the logs intact", func() {
+			data := partialResultsData(map[string]string{"pod": "result"}, 10)
+			Expect(data).To(Equal(map[string]string{"pod.log": "result"}))
+		})
+	})
+
+	Context("with logs above the limit", func() {
+		It("should keep the end of the logs of every pod", func() {
+			data := partialResultsData(map[string]string{
+				"pod-a": "0123456789",
+				"pod-b": "abcdefghij",
+			}, 8)
+			Expect(data).To(Equal(map[string]string{
+				"pod-a.log": "6789",
+				"pod-b.log": "ghij",
+			}))
+		})
+	})
+})
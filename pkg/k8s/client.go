@@ -19,18 +19,25 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	k8sclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/tools/reference"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/xridge/kubestone/pkg/sink"
 )
 
 // Access provides client related structs to access kubernetes
@@ -39,6 +46,55 @@ type Access struct {
 	Clientset     *k8sclient.Clientset
 	Scheme        *runtime.Scheme
 	EventRecorder record.EventRecorder
+
+	// DefaultTTLSecondsAfterFinished is used for the benchmarks which do
+	// not specify their own TTLSecondsAfterFinished. Nil disables the
+	// clean up of those benchmarks.
+	DefaultTTLSecondsAfterFinished *int32
+
+	// ResultSink receives the results of the finished benchmarks.
+	// Optional.
+	ResultSink sink.Sink
+
+	// WebhookConfig is the ConfigMap holding the global webhooks, which
+	// are notified about the lifecycle events of the benchmarks. Optional.
+	WebhookConfig types.NamespacedName
+
+	// WebhookHosts are the hosts the webhooks given in the benchmarks
+	// may notify. The webhooks of the benchmarks are rejected if empty,
+	// the global webhooks are not restricted.
+	WebhookHosts []string
+
+	// Namespaces restricts the controller to the benchmarks of the given
+	// namespaces. The benchmarks of every namespace watched by the
+	// manager are reconciled if empty.
+	Namespaces []string
+
+	// LockNamespace is the namespace of the leases used to serialize
+	// the benchmarks with exclusivity
+	LockNamespace string
+
+	// BaseContext is the parent of the reconcile contexts. It should be
+	// cancelled when the manager stops. Defaults to context.Background().
+	BaseContext context.Context
+
+	// ReconcileTimeout limits the duration of a single reconcile.
+	// Zero means no limit.
+	ReconcileTimeout time.Duration
+}
+
+// NewReconcileContext returns the context of a single reconcile, which
+// is cancelled when BaseContext is done or ReconcileTimeout passes.
+// The returned cancel function must be called once the reconcile ends.
+func (a *Access) NewReconcileContext() (context.Context, context.CancelFunc) {
+	ctx := a.BaseContext
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if a.ReconcileTimeout > 0 {
+		return context.WithTimeout(ctx, a.ReconcileTimeout)
+	}
+	return context.WithCancel(ctx)
 }
 
 // RecordEventf is a convenience function to create an event (via Access.EventRecorder)
@@ -63,7 +119,8 @@ func (a *Access) RecordEventf(object metav1.Object, eventtype, reason, messageFm
 // sets the owner reference to a given object. It provides basic
 // idempotency (by ignoring Already Exists errors).
 // Successful creation of the event is logged via EventRecorder
-// to the owner.
+// to the owner. The environment variables referenced by the resolved
+// templates are added to the pods of jobs and deployments.
 func (a *Access) CreateWithReference(ctx context.Context, object, owner metav1.Object) error {
 	runtimeObject, ok := object.(runtime.Object)
 	if !ok {
@@ -73,6 +130,7 @@ func (a *Access) CreateWithReference(ctx context.Context, object, owner metav1.O
 	if err := controllerutil.SetControllerReference(owner, object, a.Scheme); err != nil {
 		return err
 	}
+	AddDownwardEnv(object)
 
 	err := a.Client.Create(ctx, runtimeObject)
 	if IgnoreAlreadyExists(err) != nil {
@@ -110,7 +168,8 @@ func (a *Access) DeleteObject(ctx context.Context, object, owner metav1.Object)
 		return nil
 	}
 
-	err = a.Client.Delete(ctx, runtimeObject)
+	// Background propagation is required to remove the pods of jobs as well
+	err = a.Client.Delete(ctx, runtimeObject, client.PropagationPolicy(metav1.DeletePropagationBackground))
 	if IgnoreNotFound(err) != nil {
 		return err
 	}
@@ -123,22 +182,76 @@ func (a *Access) DeleteObject(ctx context.Context, object, owner metav1.Object)
 	return nil
 }
 
+// DeleteUncachedObject deletes a kubernetes resource like DeleteObject,
+// but without reading it first. The reads of the manager's client are
+// served from a cache watching every object of the kind, which is
+// undesired for Secrets and the like.
+func (a *Access) DeleteUncachedObject(ctx context.Context, object, owner metav1.Object) error {
+	runtimeObject, ok := object.(runtime.Object)
+	if !ok {
+		return fmt.Errorf("object (%T) is not a runtime.Object", object)
+	}
+
+	err := a.Client.Delete(ctx, runtimeObject, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	kind := fmt.Sprintf("%T", object)
+	if gvk, err := apiutil.GVKForObject(runtimeObject, a.Scheme); err == nil {
+		kind = gvk.Kind
+	}
+	_ = a.RecordEventf(owner, corev1.EventTypeNormal, Deleted,
+		"Deleted %v %v/%v", kind, object.GetNamespace(), object.GetName())
+
+	return nil
+}
+
+// getObject reads the named object via the given REST client. Unlike
+// the typed Clientset methods, the request is abandoned once ctx is done.
+func getObject(ctx context.Context, restClient rest.Interface, resource string,
+	namespacedName types.NamespacedName, into runtime.Object) error {
+	return restClient.Get().
+		Namespace(namespacedName.Namespace).
+		Resource(resource).
+		Name(namespacedName.Name).
+		Context(ctx).
+		Do().
+		Into(into)
+}
+
 // IsJobFinished returns true if the given job has already succeeded or failed
-func (a *Access) IsJobFinished(namespacedName types.NamespacedName) (finished bool, err error) {
-	job, err := a.Clientset.BatchV1().Jobs(namespacedName.Namespace).Get(
-		namespacedName.Name, metav1.GetOptions{})
-	if err != nil {
+func (a *Access) IsJobFinished(ctx context.Context, namespacedName types.NamespacedName) (finished bool, err error) {
+	var job batchv1.Job
+	if err := getObject(ctx, a.Clientset.BatchV1().RESTClient(), "jobs", namespacedName, &job); err != nil {
 		return false, err
 	}
 
-	finished = job.Status.CompletionTime != nil
+	finished, _, _ = jobOutcome(&job)
 	return finished, nil
 }
 
+// jobOutcome tells if the given job is finished, whether it succeeded
+// and when it finished. Failed jobs have no completion time, the time
+// of their Failed condition is returned instead.
+func jobOutcome(job *batchv1.Job) (finished, succeeded bool, finishTime *metav1.Time) {
+	if job.Status.CompletionTime != nil {
+		return true, true, job.Status.CompletionTime
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true, false, &condition.LastTransitionTime
+		}
+	}
+	return false, false, nil
+}
+
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list
 
 // IsEndpointReady returns true if the given endpoint is fully connected to at least one pod
-func (a *Access) IsEndpointReady(namespacedName types.NamespacedName) (finished bool, err error) {
+func (a *Access) IsEndpointReady(ctx context.Context, namespacedName types.NamespacedName) (finished bool, err error) {
 	// The Endpoint connection between the Service and the Pod is the final step before
 	// a service becomes reachable in Kubernetes. When the endpoint is bound, your
 	// service becomes connectable on vanilla k8s and azure, but not on GKE.
@@ -146,9 +259,8 @@ func (a *Access) IsEndpointReady(namespacedName types.NamespacedName) (finished
 	//
 	// Even though it is not enough to wait for the endpoints in certain cloud providers,
 	// it is still the closest we can get between service creation and connectibility.
-	endpoint, err := a.Clientset.CoreV1().Endpoints(namespacedName.Namespace).Get(
-		namespacedName.Name, metav1.GetOptions{})
-	if err != nil {
+	var endpoint corev1.Endpoints
+	if err := getObject(ctx, a.Clientset.CoreV1().RESTClient(), "endpoints", namespacedName, &endpoint); err != nil {
 		return false, err
 	}
 
@@ -165,20 +277,27 @@ func (a *Access) IsEndpointReady(namespacedName types.NamespacedName) (finished
 	return ready, nil
 }
 
-func (a *Access) GetEndpointAddress(namespacedName types.NamespacedName) string {
-	endpoint, err := a.Clientset.CoreV1().Endpoints(namespacedName.Namespace).Get(
-		namespacedName.Name, metav1.GetOptions{})
-	if err != nil {
-		return "nil"
+// GetEndpointAddress returns the IP address of the first pod behind the given endpoint.
+// An error is returned when the endpoint has no ready addresses.
+func (a *Access) GetEndpointAddress(ctx context.Context, namespacedName types.NamespacedName) (string, error) {
+	var endpoint corev1.Endpoints
+	if err := getObject(ctx, a.Clientset.CoreV1().RESTClient(), "endpoints", namespacedName, &endpoint); err != nil {
+		return "", err
 	}
 
-	return endpoint.Subsets[0].Addresses[0].IP
+	for _, subset := range endpoint.Subsets {
+		if len(subset.Addresses) > 0 {
+			return subset.Addresses[0].IP, nil
+		}
+	}
+	return "", fmt.Errorf("Endpoint %v has no ready addresses", namespacedName)
 }
+
 // IsDeploymentReady returns true if the given deployment's ready replicas matching with the desired replicas
-func (a *Access) IsDeploymentReady(namespacedName types.NamespacedName) (ready bool, err error) {
+func (a *Access) IsDeploymentReady(ctx context.Context, namespacedName types.NamespacedName) (ready bool, err error) {
 	ready, err = false, nil
-	deployment, err := a.Clientset.AppsV1().Deployments(namespacedName.Namespace).Get(
-		namespacedName.Name, metav1.GetOptions{})
+	var deployment appsv1.Deployment
+	err = getObject(ctx, a.Clientset.AppsV1().RESTClient(), "deployments", namespacedName, &deployment)
 	if err != nil {
 		return ready, err
 	}
@@ -187,3 +306,14 @@ func (a *Access) IsDeploymentReady(namespacedName types.NamespacedName) (ready b
 
 	return ready, err
 }
+
+// IsStatefulSetReady returns true if the given statefulset's ready replicas matching with the desired replicas
+func (a *Access) IsStatefulSetReady(ctx context.Context, namespacedName types.NamespacedName) (ready bool, err error) {
+	var statefulSet appsv1.StatefulSet
+	err = getObject(ctx, a.Clientset.AppsV1().RESTClient(), "statefulsets", namespacedName, &statefulSet)
+	if err != nil {
+		return false, err
+	}
+
+	return statefulSet.Status.ReadyReplicas == *statefulSet.Spec.Replicas, nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/metrics"
+)
+
+// ResultParser extracts the metrics of a benchmark from the logs of
+// its pods, keyed by the name of the pod
+type ResultParser func(logs map[string]string) ([]perfv1alpha1.BenchmarkMetric, error)
+
+const (
+	durationMetric  = "kubestone_benchmark_duration_seconds"
+	succeededMetric = "kubestone_benchmark_succeeded"
+)
+
+// CompleteBenchmark moves the given benchmark to the Completed state
+// once its jobs (given by their names) are finished. The benchmark
+// is marked as Failed if any of the jobs failed. The results of the
+// succeeded benchmarks are parsed from the logs of the jobs using
+// parse (if given) and stored in the status together with the nodes
+// of the job pods, the outcome and the run time. The environment of
+// the job pods is collected for the BenchmarkResult. The results of the
+// iterations are aggregated, and their mean values are used as the
+// results of the benchmark. The results are checked against the
+// regression thresholds, recorded in a BenchmarkResult, published as
+// metrics and queued for the result sink. The upload of the raw
+// output is started when an archive is requested. The exclusivity
+// locks are released, so the next queued benchmark can start.
+// Finally the webhooks are notified about the outcome.
+func (a *Access) CompleteBenchmark(ctx context.Context, cr perfv1alpha1.Benchmark,
+	parse ResultParser, jobNames ...string) error {
+	// The benchmark is usually read again before its completion
+	if err := a.ApplyProfile(ctx, cr); err != nil {
+		return err
+	}
+	if _, err := a.ResolveVariables(ctx, cr); err != nil {
+		return err
+	}
+
+	archive := cr.GetRunPolicy().Archive
+	if archive != nil {
+		if err := archive.Validate(); err != nil {
+			_ = a.RecordEventf(cr, corev1.EventTypeWarning, ExportFailed,
+				"Invalid archive: %v", err)
+			archive = nil
+		}
+	}
+
+	succeeded := true
+	var startTime, finishTime *metav1.Time
+	pods := []corev1.Pod{}
+	logs := map[string]string{}
+	for _, jobName := range jobNames {
+		nn := types.NamespacedName{Namespace: cr.GetNamespace(), Name: jobName}
+		var job batchv1.Job
+		err := getObject(ctx, a.Clientset.BatchV1().RESTClient(), "jobs", nn, &job)
+		if err != nil {
+			return err
+		}
+
+		_, jobSucceeded, jobFinishTime := jobOutcome(&job)
+		succeeded = succeeded && jobSucceeded
+		if job.Status.StartTime != nil && (startTime == nil || job.Status.StartTime.Before(startTime)) {
+			startTime = job.Status.StartTime
+		}
+		if jobFinishTime != nil && (finishTime == nil || finishTime.Before(jobFinishTime)) {
+			finishTime = jobFinishTime
+		}
+
+		jobPods, err := a.getJobPods(ctx, nn)
+		if err != nil {
+			return err
+		}
+		pods = append(pods, jobPods...)
+
+		if parse != nil || archive != nil {
+			jobLogs, err := a.GetJobLogs(ctx, nn)
+			if err != nil {
+				return err
+			}
+			for pod, log := range jobLogs {
+				logs[pod] = log
+			}
+		}
+	}
+
+	// The results of the iterations are parsed one by one
+	var iterations []map[string]string
+	if runPolicy := cr.GetRunPolicy(); succeeded && parse != nil && runPolicy.Iterations > 0 {
+		iterations = []map[string]string{}
+		for _, jobName := range jobNames {
+			jobIterations, err := a.getIterationLogs(ctx,
+				types.NamespacedName{Namespace: cr.GetNamespace(), Name: jobName},
+				runPolicy.WarmupIterations)
+			if err != nil {
+				return err
+			}
+			for i, iterationLogs := range jobIterations {
+				if i == len(iterations) {
+					iterations = append(iterations, map[string]string{})
+				}
+				for pod, log := range iterationLogs {
+					iterations[i][pod] = log
+				}
+			}
+		}
+	}
+
+	environment, err := a.getEnvironment(ctx, cr.GetNamespace(), pods)
+	if err != nil {
+		return err
+	}
+
+	var duration time.Duration
+	if startTime != nil && finishTime != nil {
+		duration = finishTime.Sub(startTime.Time)
+	}
+
+	status := cr.GetBenchmarkStatus()
+	status.Running = false
+	status.Completed = true
+	status.Failed = !succeeded
+	completionTime := metav1.Now()
+	status.CompletionTime = &completionTime
+	status.NodeNames = environment.NodeNames
+	status.Metrics = nil
+	status.Aggregates = nil
+	if succeeded && parse != nil {
+		var results []perfv1alpha1.BenchmarkMetric
+		var err error
+		if iterations == nil {
+			results, err = parse(logs)
+		} else {
+			results, status.Aggregates, err = aggregateIterations(parse, iterations)
+		}
+		if err != nil {
+			_ = a.RecordEventf(cr, corev1.EventTypeWarning, MetricsFailed,
+				"Unable to parse the results: %v", err)
+		}
+		status.Metrics = results
+	}
+	status.Metrics = append(status.Metrics,
+		perfv1alpha1.BenchmarkMetric{Name: durationMetric, Value: metrics.FormatValue(duration.Seconds())},
+		perfv1alpha1.BenchmarkMetric{Name: succeededMetric, Value: boolValue(succeeded)})
+	if a.ResultSink != nil {
+		status.Export = &perfv1alpha1.ExportStatus{Phase: perfv1alpha1.ExportPending}
+	}
+	if archive != nil {
+		if err := a.startArchive(ctx, cr, logs, startTime); err != nil {
+			return err
+		}
+		status.Archive = &perfv1alpha1.ArchiveStatus{Phase: perfv1alpha1.ExportPending}
+	}
+	if err := a.checkRegression(ctx, cr); err != nil {
+		return err
+	}
+
+	// The result is recorded first, as the benchmark is not completed
+	// again once its status is updated. When the update fails, the
+	// result is replaced and the archive objects created by this attempt
+	// are kept on the retry.
+	gvk, err := apiutil.GVKForObject(cr, a.Scheme)
+	if err != nil {
+		return err
+	}
+	if err := a.recordResult(ctx, gvk.Kind, cr, startTime, environment); err != nil {
+		return err
+	}
+	if err := a.ReleaseLock(ctx, cr); err != nil {
+		return err
+	}
+	if err := a.Client.Status().Update(ctx, cr); err != nil {
+		return err
+	}
+
+	metrics.RecordCompletion(gvk.Kind, duration, succeeded)
+	metrics.PublishResults(cr, status.Metrics)
+
+	if succeeded {
+		a.notify(ctx, cr, perfv1alpha1.SucceededEvent)
+	} else {
+		a.notify(ctx, cr, perfv1alpha1.FailedEvent)
+	}
+	if regressed := status.GetCondition(perfv1alpha1.RegressedCondition); regressed != nil &&
+		regressed.Status == corev1.ConditionTrue {
+		a.notify(ctx, cr, perfv1alpha1.RegressedEvent)
+	}
+
+	return nil
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+func boolValue(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("jobOutcome", func() {
+	now := metav1.NewTime(time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC))
+
+	Context("with running job", func() {
+		It("should not be finished", func() {
+			finished, _, _ := jobOutcome(&batchv1.Job{})
+			Expect(finished).To(BeFalse())
+		})
+	})
+
+	Context("with completed job", func() {
+		It("should be succeeded", func() {
+			job := &batchv1.Job{Status: batchv1.JobStatus{CompletionTime: &now}}
+			finished, succeeded, finishTime := jobOutcome(job)
+			Expect(finished).To(BeTrue())
+			Expect(succeeded).To(BeTrue())
+			Expect(*finishTime).To(Equal(now))
+		})
+	})
+
+	Context("with failed job", func() {
+		It("should be finished but not succeeded", func() {
+			job := &batchv1.Job{Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{
+					Type:               batchv1.JobFailed,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+				}},
+			}}
+			finished, succeeded, finishTime := jobOutcome(job)
+			Expect(finished).To(BeTrue())
+			Expect(succeeded).To(BeFalse())
+			Expect(*finishTime).To(Equal(now))
+		})
+	})
+})
@@ -0,0 +1,167 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("Context propagation", func() {
+	const timeout = 100 * time.Millisecond
+
+	var blockingServer *httptest.Server
+	var unblock chan struct{}
+	var access *Access
+
+	namespacedName := types.NamespacedName{Namespace: "fake-namespace", Name: "fake"}
+
+	BeforeEach(func() {
+		// The API server never answers until the test is finished
+		unblock = make(chan struct{})
+		blockingServer = httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-unblock:
+				case <-r.Context().Done():
+				}
+			}))
+
+		blockingClientset, err := kubernetes.NewForConfig(&rest.Config{Host: blockingServer.URL})
+		Expect(err).NotTo(HaveOccurred())
+
+		blockingScheme := runtime.NewScheme()
+		Expect(k8sscheme.AddToScheme(blockingScheme)).To(Succeed())
+		Expect(perfv1alpha1.AddToScheme(blockingScheme)).To(Succeed())
+
+		access = &Access{
+			Clientset:     blockingClientset,
+			Scheme:        blockingScheme,
+			LockNamespace: "kubestone-system",
+		}
+	})
+
+	AfterEach(func() {
+		close(unblock)
+		blockingServer.Close()
+	})
+
+	expectAbandoned := func(call func(ctx context.Context) error) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		start := time.Now()
+		err := call(ctx)
+		Expect(err).To(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	}
+
+	Context("with blocked API server", func() {
+		It("should abandon IsJobFinished", func() {
+			expectAbandoned(func(ctx context.Context) error {
+				_, err := access.IsJobFinished(ctx, namespacedName)
+				return err
+			})
+		})
+
+		It("should abandon IsEndpointReady", func() {
+			expectAbandoned(func(ctx context.Context) error {
+				_, err := access.IsEndpointReady(ctx, namespacedName)
+				return err
+			})
+		})
+
+		It("should abandon IsDeploymentReady", func() {
+			expectAbandoned(func(ctx context.Context) error {
+				_, err := access.IsDeploymentReady(ctx, namespacedName)
+				return err
+			})
+		})
+
+		It("should abandon GetJobLogs", func() {
+			expectAbandoned(func(ctx context.Context) error {
+				_, err := access.GetJobLogs(ctx, namespacedName)
+				return err
+			})
+		})
+
+		It("should abandon ReleaseLock", func() {
+			fio := &perfv1alpha1.Fio{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake", Namespace: "fake-namespace"},
+			}
+			fio.Spec.Exclusivity = &perfv1alpha1.ExclusivitySpec{
+				Scope: perfv1alpha1.ClusterExclusivity,
+			}
+			expectAbandoned(func(ctx context.Context) error {
+				return access.ReleaseLock(ctx, fio)
+			})
+		})
+
+		It("should abandon GetEndpointAddress", func() {
+			expectAbandoned(func(ctx context.Context) error {
+				_, err := access.GetEndpointAddress(ctx, namespacedName)
+				return err
+			})
+		})
+	})
+
+	Describe("NewReconcileContext", func() {
+		It("should time out after ReconcileTimeout", func() {
+			access := &Access{ReconcileTimeout: timeout}
+			ctx, cancel := access.NewReconcileContext()
+			defer cancel()
+
+			_, hasDeadline := ctx.Deadline()
+			Expect(hasDeadline).To(BeTrue())
+			Eventually(ctx.Done()).Should(BeClosed())
+		})
+
+		It("should not have deadline without ReconcileTimeout", func() {
+			access := &Access{}
+			ctx, cancel := access.NewReconcileContext()
+			defer cancel()
+
+			_, hasDeadline := ctx.Deadline()
+			Expect(hasDeadline).To(BeFalse())
+		})
+
+		It("should be cancelled with BaseContext", func() {
+			baseContext, cancelBase := context.WithCancel(context.Background())
+			access := &Access{BaseContext: baseContext}
+			ctx, cancel := access.NewReconcileContext()
+			defer cancel()
+
+			Expect(ctx.Done()).NotTo(BeClosed())
+			cancelBase()
+			Eventually(ctx.Done()).Should(BeClosed())
+		})
+	})
+})
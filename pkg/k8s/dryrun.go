@@ -0,0 +1,163 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=create
+
+// DryRunBenchmark submits the given objects of the benchmark with
+// server-side dry-run instead of creating them. The pods of the jobs,
+// the deployments and the statefulsets are submitted as well, because
+// pod security admission and resource quotas are enforced on pods only.
+// The outcome is recorded in the status and the benchmark is completed,
+// failed if an object was rejected.
+func (a *Access) DryRunBenchmark(ctx context.Context, cr perfv1alpha1.Benchmark, objects ...metav1.Object) error {
+	var submitted []perfv1alpha1.DryRunObject
+	for _, object := range objects {
+		result, err := a.dryRunCreate(ctx, object, cr)
+		if err != nil {
+			return err
+		}
+		submitted = append(submitted, result)
+
+		if pod := dryRunPod(object); pod != nil {
+			result, err := a.dryRunCreate(ctx, pod, cr)
+			if err != nil {
+				return err
+			}
+			submitted = append(submitted, result)
+		}
+	}
+
+	status := cr.GetBenchmarkStatus()
+	status.DryRun = &perfv1alpha1.DryRunStatus{Objects: submitted}
+	status.Running = false
+	status.Completed = true
+	status.Failed = false
+	for _, object := range submitted {
+		if !object.Accepted {
+			status.Failed = true
+			_ = a.RecordEventf(cr, corev1.EventTypeWarning, DryRunFailed,
+				"%v %v rejected: %v", object.Kind, object.Name, object.Message)
+		}
+	}
+	completionTime := metav1.Now()
+	status.CompletionTime = &completionTime
+	if err := a.Client.Status().Update(ctx, cr); err != nil {
+		return err
+	}
+
+	if !status.Failed {
+		_ = a.RecordEventf(cr, corev1.EventTypeNormal, DryRunSucceeded,
+			"Dry-run accepted %v objects", len(submitted))
+	}
+	return nil
+}
+
+// dryRunCreate submits the object with server-side dry-run. Rejections
+// by the API server are returned in the result, other errors (e.g.
+// timeouts) are returned so that the dry-run is retried.
+func (a *Access) dryRunCreate(ctx context.Context, object, owner metav1.Object) (perfv1alpha1.DryRunObject, error) {
+	runtimeObject, ok := object.(runtime.Object)
+	if !ok {
+		return perfv1alpha1.DryRunObject{}, fmt.Errorf("object (%T) is not a runtime.Object", object)
+	}
+	gvk, err := apiutil.GVKForObject(runtimeObject, a.Scheme)
+	if err != nil {
+		return perfv1alpha1.DryRunObject{}, err
+	}
+	if err := controllerutil.SetControllerReference(owner, object, a.Scheme); err != nil {
+		return perfv1alpha1.DryRunObject{}, err
+	}
+	AddDownwardEnv(object)
+
+	err = a.Client.Create(ctx, runtimeObject, client.DryRunAll)
+	if err != nil && !isRejection(err) {
+		return perfv1alpha1.DryRunObject{}, err
+	}
+	return dryRunResult(gvk.Kind, object, err), nil
+}
+
+// dryRunPod returns the pod of the job or the deployment. Its name is
+// generated by the API server.
+func dryRunPod(object metav1.Object) *corev1.Pod {
+	template := podTemplate(object)
+	if template == nil {
+		return nil
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: *template.ObjectMeta.DeepCopy(),
+		Spec:       *template.Spec.DeepCopy(),
+	}
+	pod.Name = ""
+	pod.GenerateName = object.GetName() + "-"
+	pod.Namespace = object.GetNamespace()
+	return pod
+}
+
+// podTemplate returns the pod template of the job or the deployment,
+// nil for other objects
+func podTemplate(object metav1.Object) *corev1.PodTemplateSpec {
+	switch object := object.(type) {
+	case *batchv1.Job:
+		return &object.Spec.Template
+	case *appsv1.Deployment:
+		return &object.Spec.Template
+	case *appsv1.StatefulSet:
+		return &object.Spec.Template
+	default:
+		return nil
+	}
+}
+
+// dryRunResult describes the outcome of submitting the object
+func dryRunResult(kind string, object metav1.Object, err error) perfv1alpha1.DryRunObject {
+	name := object.GetName()
+	if name == "" {
+		name = object.GetGenerateName()
+	}
+	result := perfv1alpha1.DryRunObject{Kind: kind, Name: name, Accepted: err == nil}
+	if err != nil {
+		result.Reason = string(errors.ReasonForError(err))
+		result.Message = err.Error()
+	}
+	return result
+}
+
+// isRejection tells if the API server refused the object itself, as
+// opposed to failing to process the request
+func isRejection(err error) bool {
+	return errors.IsForbidden(err) || errors.IsInvalid(err) || errors.IsAlreadyExists(err) ||
+		errors.IsBadRequest(err) || errors.IsNotFound(err) || errors.IsUnauthorized(err)
+}
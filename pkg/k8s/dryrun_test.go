@@ -0,0 +1,79 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("dry-run", func() {
+	Context("pod of a job", func() {
+		It("should be created from the pod template", func() {
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "fio-sample", Namespace: "kubestone"},
+				Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{AppLabel: "fio"}},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "fio"}}},
+				}},
+			}
+			pod := dryRunPod(job)
+			Expect(pod.Name).To(BeEmpty())
+			Expect(pod.GenerateName).To(Equal("fio-sample-"))
+			Expect(pod.Namespace).To(Equal("kubestone"))
+			Expect(pod.Labels).To(HaveKeyWithValue(AppLabel, "fio"))
+			Expect(pod.Spec.Containers).To(HaveLen(1))
+		})
+
+		It("should not be created for other objects", func() {
+			Expect(dryRunPod(&corev1.ConfigMap{})).To(BeNil())
+		})
+	})
+
+	Context("result", func() {
+		It("should show the accepted objects", func() {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "fio-sample-x7k2p", GenerateName: "fio-sample-"}}
+			Expect(dryRunResult("Pod", pod, nil)).To(Equal(perfv1alpha1.DryRunObject{
+				Kind: "Pod", Name: "fio-sample-x7k2p", Accepted: true,
+			}))
+		})
+
+		It("should show the reason of the rejection", func() {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{GenerateName: "fio-sample-"}}
+			err := errors.NewForbidden(schema.GroupResource{Resource: "pods"}, "fio-sample-",
+				errors.NewBadRequest("exceeded quota: compute, requested: cpu=4, used: cpu=0, limited: cpu=2"))
+			result := dryRunResult("Pod", pod, err)
+			Expect(result.Name).To(Equal("fio-sample-"))
+			Expect(result.Accepted).To(BeFalse())
+			Expect(result.Reason).To(Equal("Forbidden"))
+			Expect(result.Message).To(ContainSubstring("exceeded quota"))
+			Expect(isRejection(err)).To(BeTrue())
+		})
+
+		It("should not treat server errors as rejections", func() {
+			Expect(isRejection(errors.NewServerTimeout(schema.GroupResource{Resource: "pods"}, "create", 1))).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,175 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	apiversion "k8s.io/apimachinery/pkg/version"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/version"
+)
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get
+// +kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get
+
+// provisionedByAnnotation holds the provisioner of dynamically
+// provisioned persistent volumes
+const provisionedByAnnotation = "pv.kubernetes.io/provisioned-by"
+
+// getEnvironment collects the environment of the given benchmark pods
+// running in the namespace:
+// their nodes, images and persistent volumes, together with the version
+// of Kubernetes and kubestone. Nodes and volumes deleted in the meantime
+// are skipped.
+func (a *Access) getEnvironment(ctx context.Context, namespace string,
+	pods []corev1.Pod) (perfv1alpha1.EnvironmentSpec, error) {
+	environment := perfv1alpha1.EnvironmentSpec{
+		NodeNames:        []string{},
+		Images:           podImages(pods),
+		KubestoneVersion: version.Version,
+	}
+
+	claimNames := []string{}
+	for _, pod := range pods {
+		environment.NodeNames = appendUnique(environment.NodeNames, pod.Spec.NodeName)
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				claimNames = appendUnique(claimNames, volume.PersistentVolumeClaim.ClaimName)
+			}
+		}
+	}
+	sort.Strings(environment.NodeNames)
+	sort.Strings(claimNames)
+
+	restClient := a.Clientset.CoreV1().RESTClient()
+	for _, nodeName := range environment.NodeNames {
+		var node corev1.Node
+		err := getObject(ctx, restClient, "nodes", types.NamespacedName{Name: nodeName}, &node)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return environment, err
+		}
+		environment.Nodes = append(environment.Nodes, nodeEnvironment(&node))
+	}
+
+	for _, claimName := range claimNames {
+		var pvc corev1.PersistentVolumeClaim
+		err := getObject(ctx, restClient, "persistentvolumeclaims",
+			types.NamespacedName{Namespace: namespace, Name: claimName}, &pvc)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return environment, err
+		}
+
+		var pv *corev1.PersistentVolume
+		if pvc.Spec.VolumeName != "" {
+			pv = &corev1.PersistentVolume{}
+			err := getObject(ctx, restClient, "persistentvolumes",
+				types.NamespacedName{Name: pvc.Spec.VolumeName}, pv)
+			if errors.IsNotFound(err) {
+				pv = nil
+			} else if err != nil {
+				return environment, err
+			}
+		}
+		environment.Volumes = append(environment.Volumes, volumeEnvironment(&pvc, pv))
+	}
+
+	raw, err := a.Clientset.Discovery().RESTClient().Get().AbsPath("/version").Context(ctx).Do().Raw()
+	if err != nil {
+		return environment, err
+	}
+	var serverVersion apiversion.Info
+	if err := json.Unmarshal(raw, &serverVersion); err != nil {
+		return environment, err
+	}
+	environment.KubernetesVersion = serverVersion.GitVersion
+
+	return environment, nil
+}
+
+// nodeEnvironment describes the given node
+func nodeEnvironment(node *corev1.Node) perfv1alpha1.NodeEnvironment {
+	info := node.Status.NodeInfo
+	return perfv1alpha1.NodeEnvironment{
+		Name:                    node.Name,
+		Labels:                  node.Labels,
+		Allocatable:             node.Status.Allocatable,
+		KernelVersion:           info.KernelVersion,
+		OSImage:                 info.OSImage,
+		Architecture:            info.Architecture,
+		ContainerRuntimeVersion: info.ContainerRuntimeVersion,
+		KubeletVersion:          info.KubeletVersion,
+	}
+}
+
+// podImages returns the distinct images of the containers of the pods
+// together with their resolved IDs, which contain the digests
+func podImages(pods []corev1.Pod) []perfv1alpha1.ImageEnvironment {
+	images := []perfv1alpha1.ImageEnvironment{}
+	seen := map[perfv1alpha1.ImageEnvironment]bool{}
+	for _, pod := range pods {
+		containers := [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses}
+		for _, statuses := range containers {
+			for _, status := range statuses {
+				image := perfv1alpha1.ImageEnvironment{Image: status.Image, ImageID: status.ImageID}
+				if !seen[image] {
+					seen[image] = true
+					images = append(images, image)
+				}
+			}
+		}
+	}
+	sort.Slice(images, func(i, j int) bool {
+		if images[i].Image != images[j].Image {
+			return images[i].Image < images[j].Image
+		}
+		return images[i].ImageID < images[j].ImageID
+	})
+	return images
+}
+
+// volumeEnvironment describes the given claim and its bound volume (if any)
+func volumeEnvironment(pvc *corev1.PersistentVolumeClaim, pv *corev1.PersistentVolume) perfv1alpha1.VolumeEnvironment {
+	volume := perfv1alpha1.VolumeEnvironment{
+		ClaimName:  pvc.Name,
+		VolumeName: pvc.Spec.VolumeName,
+	}
+	if pvc.Spec.StorageClassName != nil {
+		volume.StorageClassName = *pvc.Spec.StorageClassName
+	}
+	if pv != nil {
+		if volume.StorageClassName == "" {
+			volume.StorageClassName = pv.Spec.StorageClassName
+		}
+		volume.Driver = pv.Annotations[provisionedByAnnotation]
+		if pv.Spec.CSI != nil {
+			volume.Driver = pv.Spec.CSI.Driver
+		}
+	}
+	return volume
+}
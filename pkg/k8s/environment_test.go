@@ -0,0 +1,131 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("Environment", func() {
+	Describe("nodeEnvironment", func() {
+		It("should describe the node", func() {
+			node := corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "node-1",
+					Labels: map[string]string{"node.kubernetes.io/instance-type": "m5.xlarge"},
+				},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3920m"),
+					},
+					NodeInfo: corev1.NodeSystemInfo{
+						KernelVersion:           "4.19.0-6-amd64",
+						OSImage:                 "Debian GNU/Linux 10 (buster)",
+						Architecture:            "amd64",
+						ContainerRuntimeVersion: "containerd://1.2.10",
+						KubeletVersion:          "v1.16.2",
+					},
+				},
+			}
+			Expect(nodeEnvironment(&node)).To(Equal(perfv1alpha1.NodeEnvironment{
+				Name:                    "node-1",
+				Labels:                  node.Labels,
+				Allocatable:             node.Status.Allocatable,
+				KernelVersion:           "4.19.0-6-amd64",
+				OSImage:                 "Debian GNU/Linux 10 (buster)",
+				Architecture:            "amd64",
+				ContainerRuntimeVersion: "containerd://1.2.10",
+				KubeletVersion:          "v1.16.2",
+			}))
+		})
+	})
+
+	Describe("podImages", func() {
+		It("should list the distinct images with their digests", func() {
+			fio := corev1.ContainerStatus{
+				Image:   "xridge/fio:3.13",
+				ImageID: "docker-pullable://xridge/fio@sha256:3f1a",
+			}
+			busybox := corev1.ContainerStatus{
+				Image:   "busybox:1.31",
+				ImageID: "docker-pullable://busybox@sha256:1a2b",
+			}
+			pods := []corev1.Pod{
+				{Status: corev1.PodStatus{
+					InitContainerStatuses: []corev1.ContainerStatus{busybox},
+					ContainerStatuses:     []corev1.ContainerStatus{fio},
+				}},
+				{Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{fio},
+				}},
+			}
+			Expect(podImages(pods)).To(Equal([]perfv1alpha1.ImageEnvironment{
+				{Image: "busybox:1.31", ImageID: "docker-pullable://busybox@sha256:1a2b"},
+				{Image: "xridge/fio:3.13", ImageID: "docker-pullable://xridge/fio@sha256:3f1a"},
+			}))
+		})
+	})
+
+	Describe("volumeEnvironment", func() {
+		storageClassName := "fast"
+		pvc := corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "fio-sample"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClassName,
+				VolumeName:       "pvc-6f1c2a4e",
+			},
+		}
+
+		It("should describe the claim without its volume", func() {
+			Expect(volumeEnvironment(&pvc, nil)).To(Equal(perfv1alpha1.VolumeEnvironment{
+				ClaimName:        "fio-sample",
+				StorageClassName: "fast",
+				VolumeName:       "pvc-6f1c2a4e",
+			}))
+		})
+
+		It("should prefer the CSI driver of the volume", func() {
+			pv := corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{provisionedByAnnotation: "external-provisioner"},
+				},
+				Spec: corev1.PersistentVolumeSpec{
+					PersistentVolumeSource: corev1.PersistentVolumeSource{
+						CSI: &corev1.CSIPersistentVolumeSource{Driver: "ebs.csi.aws.com"},
+					},
+				},
+			}
+			Expect(volumeEnvironment(&pvc, &pv).Driver).To(Equal("ebs.csi.aws.com"))
+		})
+
+		It("should fall back to the provisioner of the volume", func() {
+			pv := corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{provisionedByAnnotation: "kubernetes.io/aws-ebs"},
+				},
+			}
+			Expect(volumeEnvironment(&pvc, &pv).Driver).To(Equal("kubernetes.io/aws-ebs"))
+		})
+	})
+})
@@ -32,6 +32,26 @@ const (
 	Created = "Created"
 	// Deleted is an event provided via EventRecorder
 	Deleted = "Deleted"
+	// Cancelled is an event provided via EventRecorder
+	Cancelled = "Cancelled"
+	// Expired is an event provided via EventRecorder
+	Expired = "Expired"
+	// Queued is an event provided via EventRecorder
+	Queued = "Queued"
+	// MetricsFailed is an event provided via EventRecorder
+	MetricsFailed = "MetricsFailed"
+	// ExportFailed is an event provided via EventRecorder
+	ExportFailed = "ExportFailed"
+	// Regressed is an event provided via EventRecorder
+	Regressed = "Regressed"
+	// WebhookFailed is an event provided via EventRecorder
+	WebhookFailed = "WebhookFailed"
+	// DryRunSucceeded is an event provided via EventRecorder
+	DryRunSucceeded = "DryRunSucceeded"
+	// DryRunFailed is an event provided via EventRecorder
+	DryRunFailed = "DryRunFailed"
+	// ProfileFailed is an event provided via EventRecorder
+	ProfileFailed = "ProfileFailed"
 )
 
 // NewEventRecorder creates a new event recorder
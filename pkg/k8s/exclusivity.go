@@ -0,0 +1,231 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=list
+
+// defaultStorageClassAnnotations mark the default storage class of the cluster
+var defaultStorageClassAnnotations = []string{
+	"storageclass.kubernetes.io/is-default-class",
+	"storageclass.beta.kubernetes.io/is-default-class",
+}
+
+// specResources are the nodes and the storage used by the pods of a
+// benchmark, as far as they can be told from its spec
+type specResources struct {
+	// Nodes are the names of the nodes the pods are bound to
+	Nodes []string
+	// StorageClasses are the classes of the generated volumes
+	StorageClasses []string
+	// DefaultStorageClass is set when a generated volume uses the
+	// default storage class
+	DefaultStorageClass bool
+	// Claims are the names of the existing PVCs mounted by the pods
+	Claims []string
+}
+
+// lockKeys returns the keys of the exclusivity leases of the given
+// benchmark. The nodes and the storage classes are derived from the spec:
+// the podScheduling settings (nodeName, the kubernetes.io/hostname node
+// selector or required node affinity) and the volumes (generated PVCs
+// and existing claims). The key of the exclusivity spec is used when
+// nothing can be derived.
+func (a *Access) lockKeys(ctx context.Context, cr perfv1alpha1.Benchmark,
+	exclusivity *perfv1alpha1.ExclusivitySpec) ([]string, error) {
+	if exclusivity.Scope == perfv1alpha1.ClusterExclusivity {
+		return []string{""}, nil
+	}
+
+	resources := findSpecResources(cr)
+	var keys []string
+	switch exclusivity.Scope {
+	case perfv1alpha1.NodeExclusivity:
+		keys = resources.Nodes
+	case perfv1alpha1.StorageClassExclusivity:
+		keys = resources.StorageClasses
+		for _, claim := range resources.Claims {
+			class, err := a.claimStorageClass(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: claim})
+			if err != nil {
+				return nil, err
+			}
+			if class == nil {
+				resources.DefaultStorageClass = true
+			} else if *class != "" {
+				keys = append(keys, *class)
+			}
+		}
+		if resources.DefaultStorageClass {
+			class, err := a.defaultStorageClass(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if class != "" {
+				keys = append(keys, class)
+			}
+		}
+	}
+
+	if len(keys) == 0 {
+		if exclusivity.Key == "" {
+			return nil, invalidExclusivityError{fmt.Errorf("The %v of the benchmark cannot be derived "+
+				"from its spec, exclusivity key must be set", exclusivity.Scope)}
+		}
+		keys = []string{exclusivity.Key}
+	}
+	return uniqueSorted(keys), nil
+}
+
+// claimStorageClass returns the storage class of the given PVC
+func (a *Access) claimStorageClass(ctx context.Context, namespacedName types.NamespacedName) (*string, error) {
+	var pvc corev1.PersistentVolumeClaim
+	err := getObject(ctx, a.Clientset.CoreV1().RESTClient(), "persistentvolumeclaims", namespacedName, &pvc)
+	if err != nil {
+		return nil, err
+	}
+	return pvc.Spec.StorageClassName, nil
+}
+
+// defaultStorageClass returns the name of the default storage class of
+// the cluster or an empty string if there is none
+func (a *Access) defaultStorageClass(ctx context.Context) (string, error) {
+	var classes storagev1.StorageClassList
+	err := a.Clientset.StorageV1().RESTClient().Get().
+		Resource("storageclasses").
+		Context(ctx).
+		Do().
+		Into(&classes)
+	if err != nil {
+		return "", err
+	}
+	for _, class := range classes.Items {
+		for _, annotation := range defaultStorageClassAnnotations {
+			if class.Annotations[annotation] == "true" {
+				return class.Name, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// findSpecResources collects the nodes and the storage of every pod
+// configuration found in the spec of the given benchmark
+func findSpecResources(cr perfv1alpha1.Benchmark) specResources {
+	var resources specResources
+	spec := reflect.Indirect(reflect.ValueOf(cr)).FieldByName("Spec")
+	if !spec.IsValid() {
+		return resources
+	}
+
+	walkSpec(spec, func(value interface{}) {
+		switch value := value.(type) {
+		case perfv1alpha1.PodSchedulingSpec:
+			resources.Nodes = append(resources.Nodes, scheduledNodes(&value)...)
+		case corev1.PersistentVolumeClaimSpec:
+			if value.StorageClassName == nil {
+				resources.DefaultStorageClass = true
+			} else if *value.StorageClassName != "" {
+				resources.StorageClasses = append(resources.StorageClasses, *value.StorageClassName)
+			}
+		case corev1.PersistentVolumeClaimVolumeSource:
+			if value.ClaimName != perfv1alpha1.GeneratedPVC && value.ClaimName != "" {
+				resources.Claims = append(resources.Claims, value.ClaimName)
+			}
+		case perfv1alpha1.PostgresProvisionSpec:
+			if value.StorageClassName == nil {
+				resources.DefaultStorageClass = true
+			} else if *value.StorageClassName != "" {
+				resources.StorageClasses = append(resources.StorageClasses, *value.StorageClassName)
+			}
+		}
+	})
+	resources.Nodes = uniqueSorted(resources.Nodes)
+	resources.Claims = uniqueSorted(resources.Claims)
+	return resources
+}
+
+// scheduledNodes returns the nodes the pods are bound to by the given
+// scheduling settings. Pods allowed on multiple nodes return all of them.
+func scheduledNodes(scheduling *perfv1alpha1.PodSchedulingSpec) []string {
+	if scheduling.NodeName != "" {
+		return []string{scheduling.NodeName}
+	}
+	if node, ok := scheduling.NodeSelector[corev1.LabelHostname]; ok {
+		return []string{node}
+	}
+
+	var nodes []string
+	affinity := scheduling.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil ||
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return nil
+	}
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, expression := range term.MatchExpressions {
+			if expression.Key == corev1.LabelHostname && expression.Operator == corev1.NodeSelectorOpIn {
+				nodes = append(nodes, expression.Values...)
+			}
+		}
+	}
+	return nodes
+}
+
+// walkSpec calls visit with every struct found in the given value
+func walkSpec(value reflect.Value, visit func(value interface{})) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			walkSpec(value.Elem(), visit)
+		}
+	case reflect.Struct:
+		visit(value.Interface())
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).PkgPath == "" {
+				walkSpec(value.Field(i), visit)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			walkSpec(value.Index(i), visit)
+		}
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			walkSpec(value.MapIndex(key), visit)
+		}
+	}
+}
+
+func uniqueSorted(values []string) []string {
+	unique := []string{}
+	for _, value := range values {
+		unique = appendUnique(unique, value)
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/sink"
+)
+
+const (
+	// MaxExportAttempts is the number of attempts to push the results
+	// of a benchmark to the result sink before the export is failed
+	MaxExportAttempts = 5
+	// ExportRetryInterval is the wait time after the first failed
+	// export attempt. It is doubled after each further attempt.
+	ExportRetryInterval = 10 * time.Second
+)
+
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// exportResults pushes the results of the given finished benchmark to
+// the ResultSink if the export is pending. Failed attempts are recorded
+// in the status and retried with exponential backoff. The returned
+// duration is the time until the next attempt, or zero if the export
+// is no longer pending.
+func (a *Access) exportResults(ctx context.Context, cr perfv1alpha1.Benchmark) (time.Duration, error) {
+	status := cr.GetBenchmarkStatus()
+	if a.ResultSink == nil || status.Export == nil || status.Export.Phase != perfv1alpha1.ExportPending {
+		return 0, nil
+	}
+
+	if remaining := exportBackoff(status.Export, time.Now()); remaining > 0 {
+		return remaining, nil
+	}
+
+	gvk, err := apiutil.GVKForObject(cr, a.Scheme)
+	if err != nil {
+		return 0, err
+	}
+
+	now := metav1.Now()
+	status.Export.LastAttemptTime = &now
+	pushErr := a.ResultSink.Push(ctx, resultGroup(gvk.Kind, cr))
+	if pushErr == nil {
+		status.Export.Phase = perfv1alpha1.ExportSucceeded
+		status.Export.Message = ""
+	} else {
+		status.Export.Attempts++
+		status.Export.Message = pushErr.Error()
+		if status.Export.Attempts >= MaxExportAttempts {
+			status.Export.Phase = perfv1alpha1.ExportFailed
+			_ = a.RecordEventf(cr, corev1.EventTypeWarning, ExportFailed,
+				"Unable to push results after %v attempts: %v", status.Export.Attempts, pushErr)
+		}
+	}
+	if err := a.Client.Status().Update(ctx, cr); err != nil {
+		return 0, err
+	}
+
+	if status.Export.Phase != perfv1alpha1.ExportPending {
+		return 0, nil
+	}
+	return exportBackoff(status.Export, now.Time), nil
+}
+
+// exportBackoff returns the time left until the next export attempt
+func exportBackoff(export *perfv1alpha1.ExportStatus, now time.Time) time.Duration {
+	if export.Attempts == 0 || export.LastAttemptTime == nil {
+		return 0
+	}
+
+	backoff := ExportRetryInterval << uint(export.Attempts-1)
+	return export.LastAttemptTime.Add(backoff).Sub(now)
+}
+
+// resultGroup converts the metrics in the status of the benchmark to
+// samples for the result sink. The samples are labelled with the kind,
+// the namespace and the name (cr) of the benchmark, the nodes where the
+// benchmark was running and the labels of the benchmark prefixed with
+// label_.
+func resultGroup(kind string, cr perfv1alpha1.Benchmark) sink.Group {
+	status := cr.GetBenchmarkStatus()
+
+	group := sink.Group{
+		Key: map[string]string{
+			"kind":      kind,
+			"namespace": cr.GetNamespace(),
+			"cr":        cr.GetName(),
+		},
+	}
+	if status.CompletionTime != nil {
+		group.Timestamp = status.CompletionTime.Time
+	}
+
+	for _, metric := range status.Metrics {
+		value, err := strconv.ParseFloat(metric.Value, 64)
+		if err != nil {
+			continue
+		}
+
+		labels := map[string]string{}
+		for name, labelValue := range cr.GetLabels() {
+			labels["label_"+invalidLabelChars.ReplaceAllString(name, "_")] = labelValue
+		}
+		if len(status.NodeNames) > 0 {
+			labels["node"] = strings.Join(status.NodeNames, ",")
+		}
+		for name, labelValue := range metric.Labels {
+			labels[name] = labelValue
+		}
+
+		group.Samples = append(group.Samples, sink.Sample{
+			Name:   metric.Name,
+			Labels: labels,
+			Value:  value,
+		})
+	}
+
+	return group
+}
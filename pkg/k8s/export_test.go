@@ -0,0 +1,85 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/sink"
+)
+
+var _ = Describe("exportBackoff", func() {
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	It("should not wait before the first attempt", func() {
+		export := perfv1alpha1.ExportStatus{Phase: perfv1alpha1.ExportPending}
+		Expect(exportBackoff(&export, now)).To(BeZero())
+	})
+
+	It("should double the wait time after each failed attempt", func() {
+		lastAttempt := metav1.NewTime(now)
+		export := perfv1alpha1.ExportStatus{LastAttemptTime: &lastAttempt}
+
+		export.Attempts = 1
+		Expect(exportBackoff(&export, now)).To(Equal(ExportRetryInterval))
+		export.Attempts = 3
+		Expect(exportBackoff(&export, now.Add(5*time.Second))).To(Equal(4*ExportRetryInterval - 5*time.Second))
+	})
+})
+
+var _ = Describe("resultGroup", func() {
+	completionTime := metav1.NewTime(time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC))
+	cr := perfv1alpha1.Fio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fio-sample",
+			Namespace: "kubestone",
+			Labels:    map[string]string{"app.kubernetes.io/name": "fio", "team": "storage"},
+		},
+		Status: perfv1alpha1.BenchmarkStatus{
+			CompletionTime: &completionTime,
+			NodeNames:      []string{"node-1", "node-2"},
+			Metrics: []perfv1alpha1.BenchmarkMetric{
+				{Name: "kubestone_fio_iops", Labels: map[string]string{"rw": "read"}, Value: "1500"},
+				{Name: "kubestone_broken", Value: "n/a"},
+			},
+		},
+	}
+
+	It("should label the samples with the benchmark, its nodes and its labels", func() {
+		group := resultGroup("Fio", &cr)
+		Expect(group.Key).To(Equal(map[string]string{
+			"kind": "Fio", "namespace": "kubestone", "cr": "fio-sample",
+		}))
+		Expect(group.Timestamp).To(Equal(completionTime.Time))
+		Expect(group.Samples).To(Equal([]sink.Sample{{
+			Name: "kubestone_fio_iops",
+			Labels: map[string]string{
+				"rw":                           "read",
+				"node":                         "node-1,node-2",
+				"label_app_kubernetes_io_name": "fio",
+				"label_team":                   "storage",
+			},
+			Value: 1500,
+		}}))
+	})
+})
@@ -0,0 +1,173 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/metrics"
+	"github.com/xridge/kubestone/pkg/stats"
+)
+
+// ApplyIterations makes the given benchmark job run the warm-up and the
+// measured iterations of the benchmark one after the other, one pod per
+// iteration. The job controller replaces the finished pods as they
+// complete and retries the failed ones, so the pods of jobs running in
+// parallel cannot be assigned to iterations: the parallelism of the job
+// is set to 1. The job is not modified when the benchmark has no
+// iterations.
+func ApplyIterations(job *batchv1.Job, runPolicy *perfv1alpha1.RunPolicySpec) {
+	if runPolicy.Iterations <= 0 {
+		return
+	}
+
+	parallelism := int32(1)
+	completions := runPolicy.WarmupIterations + runPolicy.Iterations
+	job.Spec.Parallelism = &parallelism
+	job.Spec.Completions = &completions
+}
+
+// getIterationLogs returns the logs of the succeeded pods of the given job
+// by iteration, in the order of execution. The pods of the warm-up
+// iterations are skipped.
+func (a *Access) getIterationLogs(ctx context.Context, namespacedName types.NamespacedName,
+	warmupIterations int32) ([]map[string]string, error) {
+	pods, err := a.getJobPods(ctx, namespacedName)
+	if err != nil {
+		return nil, err
+	}
+	pods = iterationPods(pods, int(warmupIterations))
+
+	var iterations []map[string]string
+	for i := range pods {
+		log, err := a.getPodLog(ctx, &pods[i])
+		if err != nil {
+			return nil, err
+		}
+		iterations = append(iterations, map[string]string{pods[i].Name: log})
+	}
+	return iterations, nil
+}
+
+// iterationPods returns the succeeded pods in the order of their creation
+// without the first skip pods
+func iterationPods(pods []corev1.Pod, skip int) []corev1.Pod {
+	succeeded := []corev1.Pod{}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded && len(pod.Spec.Containers) > 0 {
+			succeeded = append(succeeded, pod)
+		}
+	}
+	sort.SliceStable(succeeded, func(i, j int) bool {
+		ti, tj := succeeded[i].CreationTimestamp, succeeded[j].CreationTimestamp
+		if ti.Equal(&tj) {
+			return succeeded[i].Name < succeeded[j].Name
+		}
+		return ti.Before(&tj)
+	})
+
+	if skip > len(succeeded) {
+		skip = len(succeeded)
+	}
+	return succeeded[skip:]
+}
+
+// aggregateIterations parses the results of every iteration and summarizes
+// the values of each metric. The returned metrics hold the mean values.
+// Iterations without parsable results are skipped.
+func aggregateIterations(parse ResultParser, iterations []map[string]string) (
+	[]perfv1alpha1.BenchmarkMetric, []perfv1alpha1.MetricAggregate, error) {
+	var aggregates []perfv1alpha1.MetricAggregate
+	var values [][]float64
+	// iterationNumbers are the 1-based numbers of the iterations of the
+	// values, which differ from their positions when iterations are skipped
+	var iterationNumbers [][]int32
+	var lastErr error
+	for iteration, logs := range iterations {
+		results, err := parse(logs)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		for _, result := range results {
+			value, err := strconv.ParseFloat(result.Value, 64)
+			if err != nil {
+				continue
+			}
+			index := -1
+			for i := range aggregates {
+				if aggregates[i].Name == result.Name && equalLabels(aggregates[i].Labels, result.Labels) {
+					index = i
+					break
+				}
+			}
+			if index < 0 {
+				aggregates = append(aggregates, perfv1alpha1.MetricAggregate{
+					Name:   result.Name,
+					Labels: result.Labels,
+				})
+				values = append(values, nil)
+				iterationNumbers = append(iterationNumbers, nil)
+				index = len(aggregates) - 1
+			}
+			aggregates[index].Values = append(aggregates[index].Values, result.Value)
+			values[index] = append(values[index], value)
+			iterationNumbers[index] = append(iterationNumbers[index], int32(iteration+1))
+		}
+	}
+
+	if len(aggregates) == 0 {
+		if lastErr == nil {
+			lastErr = errors.New("No iterations to aggregate")
+		}
+		return nil, nil, lastErr
+	}
+
+	var means []perfv1alpha1.BenchmarkMetric
+	for i := range aggregates {
+		summary := stats.Summarize(values[i])
+		aggregate := &aggregates[i]
+		aggregate.Mean = metrics.FormatValue(summary.Mean)
+		aggregate.Median = metrics.FormatValue(summary.Median)
+		aggregate.StdDev = metrics.FormatValue(summary.StdDev)
+		aggregate.Min = metrics.FormatValue(summary.Min)
+		aggregate.Max = metrics.FormatValue(summary.Max)
+		if summary.N > 1 {
+			aggregate.ConfidenceIntervalLower = metrics.FormatValue(summary.CILower)
+			aggregate.ConfidenceIntervalUpper = metrics.FormatValue(summary.CIUpper)
+		}
+		for _, outlier := range summary.Outliers {
+			aggregate.Outliers = append(aggregate.Outliers, iterationNumbers[i][outlier])
+		}
+
+		means = append(means, perfv1alpha1.BenchmarkMetric{
+			Name:   aggregate.Name,
+			Labels: aggregate.Labels,
+			Value:  aggregate.Mean,
+		})
+	}
+	return means, aggregates, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("ApplyIterations", func() {
+	It("should keep the job without iterations", func() {
+		job := batchv1.Job{}
+		ApplyIterations(&job, &perfv1alpha1.RunPolicySpec{WarmupIterations: 2})
+		Expect(job.Spec.Completions).To(BeNil())
+	})
+
+	It("should run the iterations one pod at a time", func() {
+		parallelism := int32(3)
+		job := batchv1.Job{Spec: batchv1.JobSpec{Parallelism: &parallelism}}
+		ApplyIterations(&job, &perfv1alpha1.RunPolicySpec{Iterations: 4, WarmupIterations: 1})
+		Expect(*job.Spec.Parallelism).To(Equal(int32(1)))
+		Expect(*job.Spec.Completions).To(Equal(int32(5)))
+	})
+})
+
+var _ = Describe("iterationPods", func() {
+	start := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	pod := func(name string, created time.Duration, phase corev1.PodPhase) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(start.Add(created)),
+			},
+			Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: "benchmark"}}},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	It("should order the succeeded pods and skip the warm-up", func() {
+		pods := iterationPods([]corev1.Pod{
+			pod("c", 3*time.Minute, corev1.PodSucceeded),
+			pod("a", time.Minute, corev1.PodSucceeded),
+			pod("f", 2*time.Minute, corev1.PodFailed),
+			pod("b", 2*time.Minute, corev1.PodSucceeded),
+		}, 1)
+		Expect(pods).To(HaveLen(2))
+		Expect(pods[0].Name).To(Equal("b"))
+		Expect(pods[1].Name).To(Equal("c"))
+	})
+})
+
+var _ = Describe("aggregateIterations", func() {
+	// parse returns the log as the value of the metric
+	parse := func(logs map[string]string) ([]perfv1alpha1.BenchmarkMetric, error) {
+		for _, log := range logs {
+			if log == "" {
+				return nil, errors.New("No results")
+			}
+			return []perfv1alpha1.BenchmarkMetric{
+				{Name: "kubestone_iops", Labels: map[string]string{"rw": "read"}, Value: log},
+			}, nil
+		}
+		return nil, errors.New("No logs")
+	}
+
+	It("should summarize the iterations", func() {
+		iterations := []map[string]string{}
+		for _, value := range []string{"100", "102", "", "98", "101", "40"} {
+			iterations = append(iterations, map[string]string{"pod": value})
+		}
+
+		means, aggregates, err := aggregateIterations(parse, iterations)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(means).To(Equal([]perfv1alpha1.BenchmarkMetric{
+			{Name: "kubestone_iops", Labels: map[string]string{"rw": "read"}, Value: "88.2"},
+		}))
+		Expect(aggregates).To(HaveLen(1))
+		Expect(aggregates[0].Values).To(Equal([]string{"100", "102", "98", "101", "40"}))
+		Expect(aggregates[0].Median).To(Equal("100"))
+		Expect(aggregates[0].Min).To(Equal("40"))
+		Expect(aggregates[0].Max).To(Equal("102"))
+		Expect(aggregates[0].ConfidenceIntervalLower).NotTo(BeEmpty())
+		// The third iteration has no results, the outlier is the sixth one
+		Expect(aggregates[0].Outliers).To(Equal([]int32{6}))
+	})
+
+	It("should fail without parsable iterations", func() {
+		_, _, err := aggregateIterations(parse, []map[string]string{{"pod": ""}})
+		Expect(err).To(HaveOccurred())
+	})
+})
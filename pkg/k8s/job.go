@@ -31,8 +31,8 @@ func NewPerfJob(objectMeta metav1.ObjectMeta, app string, imageSpec perfv1alpha1
 	backoffLimit := int32(0)
 
 	labels := map[string]string{
-		"kubestone.xridge.io/app":     app,
-		"kubestone.xridge.io/cr-name": objectMeta.Name,
+		AppLabel:    app,
+		CrNameLabel: objectMeta.Name,
 	}
 	for key, value := range podConfig.PodLabels {
 		labels[key] = value
@@ -99,3 +99,14 @@ func NewPerfJob(objectMeta metav1.ObjectMeta, app string, imageSpec perfv1alpha1
 
 	return &job
 }
+
+// ApplyTimeout limits the run time of the given benchmark job to the
+// timeout of the benchmark. The job is not modified when the benchmark
+// has no timeout.
+func ApplyTimeout(job *batchv1.Job, runPolicy *perfv1alpha1.RunPolicySpec) {
+	if runPolicy.TimeoutSeconds == nil {
+		return
+	}
+	timeout := *runPolicy.TimeoutSeconds
+	job.Spec.ActiveDeadlineSeconds = &timeout
+}
@@ -167,3 +167,18 @@ var _ = Describe("perf job", func() {
 		})
 	})
 })
+
+var _ = Describe("ApplyTimeout", func() {
+	It("should keep the job without timeout", func() {
+		job := batchv1.Job{}
+		ApplyTimeout(&job, &perfv1alpha1.RunPolicySpec{})
+		Expect(job.Spec.ActiveDeadlineSeconds).To(BeNil())
+	})
+
+	It("should set the deadline of the job", func() {
+		timeout := int64(600)
+		job := batchv1.Job{}
+		ApplyTimeout(&job, &perfv1alpha1.RunPolicySpec{TimeoutSeconds: &timeout})
+		Expect(*job.Spec.ActiveDeadlineSeconds).To(Equal(int64(600)))
+	})
+})
@@ -0,0 +1,349 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;update
+
+// LockRetryInterval is the time after a queued benchmark should
+// try to acquire its exclusivity lock again
+const LockRetryInterval = 10 * time.Second
+
+// lockQueueAnnotation holds the identities of the benchmarks
+// waiting for the lock in the order of their arrival
+const lockQueueAnnotation = "perf.kubestone.xridge.io/queue"
+
+// LockName returns the name of the Lease guarding the given exclusivity scope
+func LockName(exclusivity *perfv1alpha1.ExclusivitySpec) string {
+	name := "kubestone-" + strings.ToLower(string(exclusivity.Scope))
+	if exclusivity.Scope != perfv1alpha1.ClusterExclusivity {
+		name += "-" + exclusivity.Key
+	}
+	return name
+}
+
+// AcquireLock tries to acquire the exclusivity locks of the given benchmark.
+// Benchmarks without exclusivity always acquire the lock. Otherwise the
+// benchmark is queued until the benchmarks queued earlier for the same
+// scope are finished. A benchmark using several nodes or storage classes
+// takes their locks one by one, in the order of their names, so that
+// benchmarks waiting for each other cannot deadlock. The queue position
+// is stored in the status of the benchmark while it waits for a lock.
+// Queued benchmarks should call AcquireLock again after LockRetryInterval.
+func (a *Access) AcquireLock(ctx context.Context, cr perfv1alpha1.Benchmark) (bool, error) {
+	exclusivity := cr.GetRunPolicy().Exclusivity
+	if exclusivity == nil {
+		return true, nil
+	}
+	names, err := a.lockNames(ctx, cr, exclusivity)
+	if err != nil {
+		_ = a.RecordEventf(cr, corev1.EventTypeWarning, CreateFailed,
+			"CR validation failed: %v", err)
+		return false, err
+	}
+
+	self, err := a.lockIdentity(cr)
+	if err != nil {
+		return false, err
+	}
+
+	status := cr.GetBenchmarkStatus()
+	for _, name := range names {
+		holder, position, err := a.acquireLease(ctx, name, self)
+		if err != nil {
+			return false, err
+		}
+		if position == 0 {
+			continue
+		}
+
+		if status.QueuePosition != position {
+			if status.QueuePosition == 0 {
+				_ = a.RecordEventf(cr, corev1.EventTypeNormal, Queued,
+					"Waiting for lease %v held by %v", name, holder)
+			}
+			status.QueuePosition = position
+			if err := a.Client.Status().Update(ctx, cr); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+
+	status.QueuePosition = 0
+	return true, nil
+}
+
+// acquireLease requests the named lease for self. The holder of the lease
+// and the 1-based queue position of self are returned, the position is 0
+// if self holds the lease.
+func (a *Access) acquireLease(ctx context.Context, name, self string) (string, int32, error) {
+	lease, err := a.getLease(ctx, name)
+	if errors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: a.LockNamespace,
+			},
+		}
+	} else if err != nil {
+		return "", 0, err
+	}
+
+	holder := ""
+	if lease.Spec.HolderIdentity != nil {
+		holder = *lease.Spec.HolderIdentity
+	}
+	var queue []string
+	if value, ok := lease.Annotations[lockQueueAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), &queue); err != nil {
+			return "", 0, fmt.Errorf("Invalid queue on lease %v: %v", lease.Name, err)
+		}
+	}
+
+	isStale := func(identity string) bool {
+		finished, err := a.isLockHolderFinished(ctx, identity)
+		return err == nil && finished
+	}
+	newHolder, newQueue, position := nextLockState(holder, queue, self, isStale)
+
+	if newHolder != holder || !equalQueues(newQueue, queue) {
+		encodedQueue, err := json.Marshal(newQueue)
+		if err != nil {
+			return "", 0, err
+		}
+		if lease.Annotations == nil {
+			lease.Annotations = map[string]string{}
+		}
+		lease.Annotations[lockQueueAnnotation] = string(encodedQueue)
+		if newHolder != holder {
+			now := metav1.NewMicroTime(time.Now())
+			lease.Spec.HolderIdentity = &newHolder
+			lease.Spec.AcquireTime = &now
+			lease.Spec.RenewTime = &now
+		}
+
+		// The loser of a concurrent modification is retried by the caller
+		if err := a.saveLease(ctx, lease); err != nil {
+			return "", 0, err
+		}
+	}
+
+	return newHolder, position, nil
+}
+
+// ReleaseLock releases the exclusivity locks of the given benchmark,
+// so that the next benchmark of the queue can acquire them. Locks which
+// are not held by the benchmark are left intact. Locks which could not
+// be released are taken over by the next benchmark once it finds this
+// benchmark finished. Errors are returned, so the release is retried
+// instead of keeping the queue waiting. Benchmarks with invalid
+// exclusivity never acquire their locks, so there is nothing to release.
+func (a *Access) ReleaseLock(ctx context.Context, cr perfv1alpha1.Benchmark) error {
+	exclusivity := cr.GetRunPolicy().Exclusivity
+	if exclusivity == nil {
+		return nil
+	}
+	names, err := a.lockNames(ctx, cr, exclusivity)
+	if _, invalid := err.(invalidExclusivityError); invalid {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	self, err := a.lockIdentity(cr)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		lease, err := a.getLease(ctx, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != self {
+			continue
+		}
+
+		lease.Spec.HolderIdentity = nil
+		lease.Spec.AcquireTime = nil
+		lease.Spec.RenewTime = nil
+		if err := a.saveLease(ctx, lease); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// invalidExclusivityError is returned by lockNames when the exclusivity
+// of the benchmark is invalid, as opposed to the errors of the API server
+type invalidExclusivityError struct {
+	error
+}
+
+// lockNames returns the names of the leases guarding the resources
+// of the given benchmark in the order of their acquisition
+func (a *Access) lockNames(ctx context.Context, cr perfv1alpha1.Benchmark,
+	exclusivity *perfv1alpha1.ExclusivitySpec) ([]string, error) {
+	if err := exclusivity.Validate(); err != nil {
+		return nil, invalidExclusivityError{err}
+	}
+	keys, err := a.lockKeys(ctx, cr, exclusivity)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, key := range keys {
+		names = append(names, LockName(&perfv1alpha1.ExclusivitySpec{Scope: exclusivity.Scope, Key: key}))
+	}
+	return names, nil
+}
+
+func (a *Access) getLease(ctx context.Context, name string) (*coordinationv1.Lease, error) {
+	lease := &coordinationv1.Lease{}
+	err := getObject(ctx, a.Clientset.CoordinationV1().RESTClient(), "leases",
+		types.NamespacedName{Namespace: a.LockNamespace, Name: name}, lease)
+	return lease, err
+}
+
+// saveLease creates the lease if it does not exist yet or updates it
+// otherwise. Concurrent updates are rejected based on the resource version.
+func (a *Access) saveLease(ctx context.Context, lease *coordinationv1.Lease) error {
+	request := a.Clientset.CoordinationV1().RESTClient().Post().
+		Namespace(lease.Namespace).
+		Resource("leases")
+	if lease.ResourceVersion != "" {
+		request = a.Clientset.CoordinationV1().RESTClient().Put().
+			Namespace(lease.Namespace).
+			Resource("leases").
+			Name(lease.Name)
+	}
+	return request.Body(lease).Context(ctx).Do().Into(lease)
+}
+
+// lockIdentity identifies the benchmark in the lease as
+// Kind/Namespace/Name, so that the benchmark can be looked up
+// when its liveness is checked
+func (a *Access) lockIdentity(cr perfv1alpha1.Benchmark) (string, error) {
+	gvk, err := apiutil.GVKForObject(cr, a.Scheme)
+	if err != nil {
+		return "", err
+	}
+	return gvk.Kind + "/" + cr.GetNamespace() + "/" + cr.GetName(), nil
+}
+
+// isLockHolderFinished checks if the benchmark with the given lock
+// identity is either deleted or finished its execution
+func (a *Access) isLockHolderFinished(ctx context.Context, identity string) (bool, error) {
+	parts := strings.SplitN(identity, "/", 3)
+	if len(parts) != 3 {
+		return true, nil
+	}
+
+	object, err := a.Scheme.New(perfv1alpha1.GroupVersion.WithKind(parts[0]))
+	if err != nil {
+		return true, nil
+	}
+	cr, ok := object.(perfv1alpha1.Benchmark)
+	if !ok {
+		return true, nil
+	}
+
+	err = a.Client.Get(ctx, types.NamespacedName{Namespace: parts[1], Name: parts[2]}, cr)
+	if errors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	status := cr.GetBenchmarkStatus()
+	return status.Completed || status.Cancelled, nil
+}
+
+// nextLockState computes the holder and queue of a lock after self
+// requested it. Finished benchmarks are dropped from the lock and the
+// first live benchmark of the queue becomes the holder when the lock
+// is free. The returned position is the 1-based position of self in
+// the queue, or 0 if self holds the lock.
+func nextLockState(holder string, queue []string, self string,
+	isStale func(identity string) bool) (string, []string, int32) {
+	if holder != "" && holder != self && isStale(holder) {
+		holder = ""
+	}
+
+	newQueue := []string{}
+	queued := false
+	for _, identity := range queue {
+		if identity == holder {
+			continue
+		}
+		if identity == self {
+			queued = true
+		} else if isStale(identity) {
+			continue
+		}
+		newQueue = append(newQueue, identity)
+	}
+	if holder != self && !queued {
+		newQueue = append(newQueue, self)
+	}
+
+	if holder == "" {
+		holder = newQueue[0]
+		newQueue = newQueue[1:]
+	}
+	if holder == self {
+		return holder, newQueue, 0
+	}
+
+	for i, identity := range newQueue {
+		if identity == self {
+			return holder, newQueue, int32(i + 1)
+		}
+	}
+	return holder, newQueue, 0
+}
+
+func equalQueues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("exclusivity lock", func() {
+	noneStale := func(string) bool { return false }
+	staleOf := func(identities ...string) func(string) bool {
+		return func(identity string) bool {
+			for _, stale := range identities {
+				if identity == stale {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	Context("name", func() {
+		It("should include the key for node scope", func() {
+			Expect(LockName(&perfv1alpha1.ExclusivitySpec{
+				Scope: perfv1alpha1.NodeExclusivity,
+				Key:   "worker-1",
+			})).To(Equal("kubestone-node-worker-1"))
+		})
+
+		It("should ignore the key for cluster scope", func() {
+			Expect(LockName(&perfv1alpha1.ExclusivitySpec{
+				Scope: perfv1alpha1.ClusterExclusivity,
+				Key:   "ignored",
+			})).To(Equal("kubestone-cluster"))
+		})
+	})
+
+	Context("release", func() {
+		It("should skip benchmarks with invalid exclusivity", func() {
+			cr := perfv1alpha1.Fio{}
+			cr.Spec.Exclusivity = &perfv1alpha1.ExclusivitySpec{Scope: perfv1alpha1.NodeExclusivity}
+			Expect((&Access{}).ReleaseLock(context.Background(), &cr)).To(Succeed())
+		})
+	})
+
+	Context("when the lock is free", func() {
+		It("should be acquired", func() {
+			holder, queue, position := nextLockState("", nil, "Fio/ns/a", noneStale)
+			Expect(holder).To(Equal("Fio/ns/a"))
+			Expect(queue).To(BeEmpty())
+			Expect(position).To(Equal(int32(0)))
+		})
+
+		It("should be handed to the first queued benchmark", func() {
+			holder, queue, position := nextLockState("", []string{"Fio/ns/a"}, "Fio/ns/b", noneStale)
+			Expect(holder).To(Equal("Fio/ns/a"))
+			Expect(queue).To(Equal([]string{"Fio/ns/b"}))
+			Expect(position).To(Equal(int32(1)))
+		})
+	})
+
+	Context("when the lock is held", func() {
+		It("should keep the holder", func() {
+			holder, queue, position := nextLockState("Fio/ns/a", nil, "Fio/ns/a", noneStale)
+			Expect(holder).To(Equal("Fio/ns/a"))
+			Expect(queue).To(BeEmpty())
+			Expect(position).To(Equal(int32(0)))
+		})
+
+		It("should queue the new benchmark at the end", func() {
+			holder, queue, position := nextLockState("Fio/ns/a", []string{"Fio/ns/b"},
+				"Iperf3/ns/c", noneStale)
+			Expect(holder).To(Equal("Fio/ns/a"))
+			Expect(queue).To(Equal([]string{"Fio/ns/b", "Iperf3/ns/c"}))
+			Expect(position).To(Equal(int32(2)))
+		})
+
+		It("should keep the position of queued benchmarks", func() {
+			holder, queue, position := nextLockState("Fio/ns/a", []string{"Fio/ns/b", "Fio/ns/c"},
+				"Fio/ns/b", noneStale)
+			Expect(holder).To(Equal("Fio/ns/a"))
+			Expect(queue).To(Equal([]string{"Fio/ns/b", "Fio/ns/c"}))
+			Expect(position).To(Equal(int32(1)))
+		})
+	})
+
+	Context("with finished benchmarks", func() {
+		It("should take over the lock of a finished holder", func() {
+			holder, queue, position := nextLockState("Fio/ns/a", []string{"Fio/ns/b"},
+				"Fio/ns/b", staleOf("Fio/ns/a"))
+			Expect(holder).To(Equal("Fio/ns/b"))
+			Expect(queue).To(BeEmpty())
+			Expect(position).To(Equal(int32(0)))
+		})
+
+		It("should drop finished benchmarks from the queue", func() {
+			holder, queue, position := nextLockState("Fio/ns/a", []string{"Fio/ns/b", "Fio/ns/c"},
+				"Fio/ns/c", staleOf("Fio/ns/b"))
+			Expect(holder).To(Equal("Fio/ns/a"))
+			Expect(queue).To(Equal([]string{"Fio/ns/c"}))
+			Expect(position).To(Equal(int32(1)))
+		})
+	})
+
+	Context("resources derived from the spec", func() {
+		storageClass := "fast"
+
+		It("should find the node and the storage class of fio", func() {
+			fio := &perfv1alpha1.Fio{}
+			fio.Spec.PodConfig.PodScheduling.NodeName = "worker-1"
+			fio.Spec.Volume.PersistentVolumeClaimSpec = &corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClass,
+			}
+			resources := findSpecResources(fio)
+			Expect(resources.Nodes).To(Equal([]string{"worker-1"}))
+			Expect(resources.StorageClasses).To(Equal([]string{"fast"}))
+			Expect(resources.DefaultStorageClass).To(BeFalse())
+		})
+
+		It("should find existing claims and the default storage class", func() {
+			fio := &perfv1alpha1.Fio{}
+			fio.Spec.Volume.VolumeSource.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "data",
+			}
+			Expect(findSpecResources(fio).Claims).To(Equal([]string{"data"}))
+
+			fio.Spec.Volume.VolumeSource.PersistentVolumeClaim.ClaimName = perfv1alpha1.GeneratedPVC
+			fio.Spec.Volume.PersistentVolumeClaimSpec = &corev1.PersistentVolumeClaimSpec{}
+			resources := findSpecResources(fio)
+			Expect(resources.Claims).To(BeEmpty())
+			Expect(resources.DefaultStorageClass).To(BeTrue())
+		})
+
+		It("should find the nodes of both the client and the server", func() {
+			iperf3 := &perfv1alpha1.Iperf3{}
+			iperf3.Spec.ServerConfiguration.PodScheduling.NodeSelector = map[string]string{
+				corev1.LabelHostname: "worker-2",
+			}
+			iperf3.Spec.ClientConfiguration.PodScheduling.Affinity = &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+							MatchExpressions: []corev1.NodeSelectorRequirement{{
+								Key:      corev1.LabelHostname,
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   []string{"worker-1", "worker-2"},
+							}},
+						}},
+					},
+				},
+			}
+			Expect(findSpecResources(iperf3).Nodes).To(Equal([]string{"worker-1", "worker-2"}))
+		})
+
+		It("should find nothing for unscheduled pods", func() {
+			Expect(findSpecResources(&perfv1alpha1.Iperf3{}).Nodes).To(BeEmpty())
+		})
+	})
+})
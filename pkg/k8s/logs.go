@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"sort"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+
+// GetJobLogs returns the logs of the pods created by the given job,
+// keyed by the name of the pod. Pods which are not yet started
+// are skipped.
+func (a *Access) GetJobLogs(ctx context.Context, namespacedName types.NamespacedName) (map[string]string, error) {
+	pods, err := a.getJobPods(ctx, namespacedName)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := map[string]string{}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodPending || len(pod.Spec.Containers) == 0 {
+			continue
+		}
+
+		log, err := a.getPodLog(ctx, &pod)
+		if err != nil {
+			return nil, err
+		}
+		logs[pod.Name] = log
+	}
+
+	return logs, nil
+}
+
+// PodNames returns the names of the pods of the given logs in
+// alphabetical order, so the logs are processed in a stable order
+func PodNames(logs map[string]string) []string {
+	names := make([]string, 0, len(logs))
+	for name := range logs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getPodLog returns the log of the first container of the pod
+func (a *Access) getPodLog(ctx context.Context, pod *corev1.Pod) (string, error) {
+	raw, err := a.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: pod.Spec.Containers[0].Name,
+	}).Context(ctx).DoRaw()
+	return string(raw), err
+}
+
+// getJobPods lists the pods created by the given job
+func (a *Access) getJobPods(ctx context.Context, namespacedName types.NamespacedName) ([]corev1.Pod, error) {
+	var job batchv1.Job
+	if err := getObject(ctx, a.Clientset.BatchV1().RESTClient(), "jobs", namespacedName, &job); err != nil {
+		return nil, err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var pods corev1.PodList
+	err = a.Clientset.CoreV1().RESTClient().Get().
+		Namespace(namespacedName.Namespace).
+		Resource("pods").
+		VersionedParams(&metav1.ListOptions{LabelSelector: selector.String()}, k8sscheme.ParameterCodec).
+		Context(ctx).
+		Do().
+		Into(&pods)
+	if err != nil {
+		return nil, err
+	}
+
+	return pods.Items, nil
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// NamespacePredicate filters the events of the benchmarks outside of
+// the Namespaces of the controller. Every event passes when the
+// controller has no namespaces of its own.
+func (a *Access) NamespacePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return a.watches(e.Meta.GetNamespace()) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return a.watches(e.MetaNew.GetNamespace()) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return a.watches(e.Meta.GetNamespace()) },
+		GenericFunc: func(e event.GenericEvent) bool { return a.watches(e.Meta.GetNamespace()) },
+	}
+}
+
+func (a *Access) watches(namespace string) bool {
+	if len(a.Namespaces) == 0 {
+		return true
+	}
+	for _, watched := range a.Namespaces {
+		if watched == namespace {
+			return true
+		}
+	}
+	return false
+}
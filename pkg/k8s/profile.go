@@ -0,0 +1,213 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=benchmarkprofiles,verbs=get;list;watch
+
+// ApplyProfile merges the BenchmarkProfile referenced by the benchmark
+// into its spec and records the version of the profile in the status.
+// The fields set in the spec of the benchmark override the profile, even
+// when they are set to false or 0. The profile is not required for finished benchmarks, so that they can
+// be cleaned up after the profile is deleted.
+func (a *Access) ApplyProfile(ctx context.Context, cr perfv1alpha1.Benchmark) error {
+	name := cr.GetRunPolicy().Profile
+	if name == "" {
+		return nil
+	}
+	status := cr.GetBenchmarkStatus()
+
+	profile, err := a.getProfile(ctx, name)
+	var specFields map[string]interface{}
+	if err == nil {
+		specFields, err = a.getSpecFields(ctx, cr)
+	}
+	if err == nil {
+		err = MergeProfile(cr, specFields, profile, a.Scheme)
+	}
+	if err != nil {
+		if status.Completed || status.Cancelled {
+			return nil
+		}
+		_ = a.RecordEventf(cr, corev1.EventTypeWarning, ProfileFailed,
+			"Applying profile %v failed: %v", name, err)
+		return err
+	}
+
+	status.Profile = &perfv1alpha1.ProfileReference{Name: profile.Name, Version: profile.Spec.Version}
+	return nil
+}
+
+// getProfile reads the profile from the API server, as the cache of the
+// manager may be restricted to namespaces while profiles are cluster
+// scoped
+func (a *Access) getProfile(ctx context.Context, name string) (*perfv1alpha1.BenchmarkProfile, error) {
+	raw, err := a.Clientset.Discovery().RESTClient().Get().
+		AbsPath("/apis", perfv1alpha1.GroupVersion.Group, perfv1alpha1.GroupVersion.Version,
+			"benchmarkprofiles", name).
+		Context(ctx).Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+	var profile perfv1alpha1.BenchmarkProfile
+	if err := json.Unmarshal(raw, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// getSpecFields reads the fields set in the spec of the benchmark from the
+// API server, as the typed benchmark cannot tell the fields set to false
+// or 0 apart from the unset ones. Unstructured objects are not cached.
+func (a *Access) getSpecFields(ctx context.Context, cr perfv1alpha1.Benchmark) (map[string]interface{}, error) {
+	gvk, err := apiutil.GVKForObject(cr, a.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(gvk)
+	nn := types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}
+	if err := a.Client.Get(ctx, nn, object); err != nil {
+		return nil, err
+	}
+	return SpecFields(object.Object), nil
+}
+
+// SpecFields returns the spec of the JSON representation of a benchmark,
+// or nil if the benchmark was converted from an other version (see
+// perfv1alpha1.ConvertedFromAnnotation)
+func SpecFields(object map[string]interface{}) map[string]interface{} {
+	metadata, _ := object["metadata"].(map[string]interface{})
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	if _, found := annotations[perfv1alpha1.ConvertedFromAnnotation]; found {
+		return nil
+	}
+	spec, _ := object["spec"].(map[string]interface{})
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+	return spec
+}
+
+// MergeProfile merges the spec of the benchmark into the spec of the
+// profile, and stores the result in the benchmark. specFields are the
+// fields set in the spec of the benchmark (see SpecFields), which
+// override the profile. When nil, they are taken from the spec of the
+// benchmark, where the fields with zero value are treated as unset.
+func MergeProfile(cr perfv1alpha1.Benchmark, specFields map[string]interface{},
+	profile *perfv1alpha1.BenchmarkProfile, s *runtime.Scheme) error {
+	gvk, err := apiutil.GVKForObject(cr, s)
+	if err != nil {
+		return err
+	}
+	if profile.Spec.Kind != gvk.Kind {
+		return fmt.Errorf("the profile is for %v benchmarks", profile.Spec.Kind)
+	}
+
+	object, err := toObject(cr)
+	if err != nil {
+		return err
+	}
+
+	base := map[string]interface{}{}
+	if len(profile.Spec.Spec.Raw) > 0 {
+		if err := json.Unmarshal(profile.Spec.Spec.Raw, &base); err != nil {
+			return fmt.Errorf("invalid spec: %v", err)
+		}
+	}
+	delete(base, "profile")
+	if specFields == nil {
+		specFields, _ = pruneZeroValues(object["spec"]).(map[string]interface{})
+	}
+	object["spec"] = mergeValues(base, specFields)
+	return fromObject(object, cr)
+}
+
+// pruneZeroValues removes the fields with zero value from the objects,
+// those are the fields not set in the spec (or set to false or 0, which
+// cannot be told apart). Lists are kept intact.
+func pruneZeroValues(value interface{}) interface{} {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	pruned := map[string]interface{}{}
+	for key, field := range object {
+		field = pruneZeroValues(field)
+		switch field := field.(type) {
+		case nil:
+			continue
+		case string:
+			if field == "" {
+				continue
+			}
+		case float64:
+			if field == 0 {
+				continue
+			}
+		case bool:
+			if !field {
+				continue
+			}
+		case map[string]interface{}:
+			if len(field) == 0 {
+				continue
+			}
+		case []interface{}:
+			if len(field) == 0 {
+				continue
+			}
+		}
+		pruned[key] = field
+	}
+	return pruned
+}
+
+// mergeValues merges the override into the base. Objects are merged
+// field by field, other values (including lists) are replaced.
+func mergeValues(base, override interface{}) interface{} {
+	baseObject, baseOk := base.(map[string]interface{})
+	overrideObject, overrideOk := override.(map[string]interface{})
+	if !baseOk || !overrideOk {
+		if override == nil {
+			return base
+		}
+		return override
+	}
+
+	merged := map[string]interface{}{}
+	for key, value := range baseObject {
+		merged[key] = value
+	}
+	for key, value := range overrideObject {
+		merged[key] = mergeValues(merged[key], value)
+	}
+	return merged
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("MergeProfile", func() {
+	var profile perfv1alpha1.BenchmarkProfile
+
+	BeforeEach(func() {
+		Expect(perfv1alpha1.AddToScheme(k8sscheme.Scheme)).To(Succeed())
+		profile = perfv1alpha1.BenchmarkProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "iperf3-tcp-8-streams"},
+			Spec: perfv1alpha1.BenchmarkProfileSpec{
+				Kind:    "Iperf3",
+				Version: "1",
+				Spec: runtime.RawExtension{Raw: []byte(`{
+					"image": {"name": "xridge/iperf3:3.7.0"},
+					"clientConfiguration": {"cmdLineArgs": "--parallel 8", "hostNetwork": true},
+					"completions": 3,
+					"profile": "other"
+				}`)},
+			},
+		}
+	})
+
+	It("should keep the fields set in the benchmark", func() {
+		cr := perfv1alpha1.Iperf3{
+			ObjectMeta: metav1.ObjectMeta{Name: "iperf3", Namespace: "default"},
+			Spec: perfv1alpha1.Iperf3Spec{
+				Image:               perfv1alpha1.ImageSpec{Name: "xridge/iperf3:latest"},
+				ClientConfiguration: perfv1alpha1.Iperf3ConfigurationSpec{CmdLineArgs: "--parallel 2"},
+				RunPolicySpec:       perfv1alpha1.RunPolicySpec{Profile: "iperf3-tcp-8-streams"},
+			},
+		}
+		Expect(MergeProfile(&cr, nil, &profile, k8sscheme.Scheme)).To(Succeed())
+		Expect(cr.Name).To(Equal("iperf3"))
+		Expect(cr.Spec.Image.Name).To(Equal("xridge/iperf3:latest"))
+		Expect(cr.Spec.ClientConfiguration.CmdLineArgs).To(Equal("--parallel 2"))
+		Expect(cr.Spec.ClientConfiguration.HostNetwork).To(BeTrue())
+		Expect(cr.Spec.Completions).To(Equal(int32(3)))
+		Expect(cr.Spec.Profile).To(Equal("iperf3-tcp-8-streams"))
+	})
+
+	It("should override the profile with the fields set to false or 0", func() {
+		cr := perfv1alpha1.Iperf3{
+			Spec: perfv1alpha1.Iperf3Spec{
+				RunPolicySpec: perfv1alpha1.RunPolicySpec{Profile: "iperf3-tcp-8-streams"},
+			},
+		}
+		specFields := map[string]interface{}{
+			"clientConfiguration": map[string]interface{}{"hostNetwork": false},
+			"completions":         int64(0),
+			"profile":             "iperf3-tcp-8-streams",
+		}
+		Expect(MergeProfile(&cr, specFields, &profile, k8sscheme.Scheme)).To(Succeed())
+		Expect(cr.Spec.Image.Name).To(Equal("xridge/iperf3:3.7.0"))
+		Expect(cr.Spec.ClientConfiguration.CmdLineArgs).To(Equal("--parallel 8"))
+		Expect(cr.Spec.ClientConfiguration.HostNetwork).To(BeFalse())
+		Expect(cr.Spec.Completions).To(BeZero())
+		Expect(cr.Spec.Profile).To(Equal("iperf3-tcp-8-streams"))
+	})
+
+	It("should reject profiles of other kinds", func() {
+		cr := perfv1alpha1.Fio{}
+		Expect(MergeProfile(&cr, nil, &profile, k8sscheme.Scheme)).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("SpecFields", func() {
+	It("should return the spec of the benchmark", func() {
+		Expect(SpecFields(map[string]interface{}{
+			"spec": map[string]interface{}{"completions": int64(0)},
+		})).To(Equal(map[string]interface{}{"completions": int64(0)}))
+		Expect(SpecFields(map[string]interface{}{})).To(BeEmpty())
+	})
+
+	It("should skip the converted benchmarks", func() {
+		Expect(SpecFields(map[string]interface{}{
+			"metadata": map[string]interface{}{"annotations": map[string]interface{}{
+				perfv1alpha1.ConvertedFromAnnotation: "v1beta1"}},
+			"spec": map[string]interface{}{"completions": int64(0)},
+		})).To(BeNil())
+	})
+})
+
+var _ = Describe("pruneZeroValues", func() {
+	It("should remove the unset fields", func() {
+		pruned := pruneZeroValues(map[string]interface{}{
+			"name":    "",
+			"count":   float64(0),
+			"enabled": false,
+			"labels":  map[string]interface{}{},
+			"args":    []interface{}{},
+			"nested":  map[string]interface{}{"value": nil},
+			"list":    []interface{}{"", false},
+			"image":   map[string]interface{}{"name": "fio", "pullPolicy": ""},
+		})
+		Expect(pruned).To(Equal(map[string]interface{}{
+			"list":  []interface{}{"", false},
+			"image": map[string]interface{}{"name": "fio"},
+		}))
+	})
+})
+
+var _ = Describe("mergeValues", func() {
+	It("should merge objects and replace other values", func() {
+		merged := mergeValues(
+			map[string]interface{}{
+				"args":  []interface{}{"a", "b"},
+				"image": map[string]interface{}{"name": "fio", "pullPolicy": "Always"},
+				"size":  "1G",
+			},
+			map[string]interface{}{
+				"args":  []interface{}{"c"},
+				"image": map[string]interface{}{"name": "fio:3.13"},
+			})
+		Expect(merged).To(Equal(map[string]interface{}{
+			"args":  []interface{}{"c"},
+			"image": map[string]interface{}{"name": "fio:3.13", "pullPolicy": "Always"},
+			"size":  "1G",
+		}))
+	})
+})
@@ -19,6 +19,8 @@ package k8s
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
 )
 
 // NewPersistentVolumeClaim creates a PVC based on the provided pvcSpec, name and namespace
@@ -33,3 +35,15 @@ func NewPersistentVolumeClaim(pvcSpec corev1.PersistentVolumeClaimSpec, name, na
 
 	return &pvc
 }
+
+// GeneratedPersistentVolumeClaims returns the PVC of the volume if it is
+// generated from its PersistentVolumeClaimSpec, and points the volume to
+// the PVC like the controllers do when they create it
+func GeneratedPersistentVolumeClaims(volume *perfv1alpha1.VolumeSpec, name, namespace string) []metav1.Object {
+	if volume.PersistentVolumeClaimSpec == nil {
+		return nil
+	}
+	pvc := NewPersistentVolumeClaim(*volume.PersistentVolumeClaimSpec, name, namespace)
+	volume.VolumeSource.PersistentVolumeClaim.ClaimName = name
+	return []metav1.Object{pvc}
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/metrics"
+)
+
+// Reasons of the Passed and Regressed conditions
+const (
+	ThresholdsMetReason    = "ThresholdsMet"
+	ThresholdsMissedReason = "ThresholdsMissed"
+	BenchmarkFailedReason  = "BenchmarkFailed"
+	BaselineNotFoundReason = "BaselineNotFound"
+)
+
+// checkRegression compares the metrics in the status of the given finished
+// benchmark to the thresholds of its RegressionSpec. The comparisons are
+// stored in the status and the Passed and Regressed conditions are set
+// accordingly. Regressed is Unknown when the results could not be checked.
+func (a *Access) checkRegression(ctx context.Context, cr perfv1alpha1.Benchmark) error {
+	regression := cr.GetRunPolicy().Regression
+	if regression == nil {
+		return nil
+	}
+
+	status := cr.GetBenchmarkStatus()
+	if status.Failed {
+		setRegressionConditions(status, corev1.ConditionUnknown, BenchmarkFailedReason,
+			"The benchmark failed, its results are not checked")
+		return nil
+	}
+
+	var baseline []perfv1alpha1.BenchmarkMetric
+	if regression.BaselineResult != "" {
+		var result perfv1alpha1.BenchmarkResult
+		err := a.Client.Get(ctx, types.NamespacedName{
+			Namespace: cr.GetNamespace(),
+			Name:      regression.BaselineResult,
+		}, &result)
+		if errors.IsNotFound(err) {
+			setRegressionConditions(status, corev1.ConditionUnknown, BaselineNotFoundReason,
+				fmt.Sprintf("BenchmarkResult %v not found", regression.BaselineResult))
+			return nil
+		} else if err != nil {
+			return err
+		}
+		baseline = result.Spec.Metrics
+	}
+
+	comparisons, passed := compareMetrics(regression.Thresholds, status.Metrics, baseline)
+	status.Comparisons = comparisons
+	if passed {
+		setRegressionConditions(status, corev1.ConditionFalse, ThresholdsMetReason,
+			"Every result is within its threshold")
+		return nil
+	}
+
+	var missed []string
+	for _, comparison := range comparisons {
+		if !comparison.Passed {
+			missed = append(missed, comparison.Metric)
+		}
+	}
+	message := "Results outside of their thresholds: " + strings.Join(missed, ", ")
+	setRegressionConditions(status, corev1.ConditionTrue, ThresholdsMissedReason, message)
+	_ = a.RecordEventf(cr, corev1.EventTypeWarning, Regressed, message)
+	return nil
+}
+
+// setRegressionConditions sets the Regressed condition to the given status
+// and the Passed condition to its opposite. Passed is False when the
+// regression is Unknown.
+func setRegressionConditions(status *perfv1alpha1.BenchmarkStatus, regressed corev1.ConditionStatus,
+	reason, message string) {
+	passed := corev1.ConditionFalse
+	if regressed == corev1.ConditionFalse {
+		passed = corev1.ConditionTrue
+	}
+
+	now := metav1.Now()
+	status.SetCondition(perfv1alpha1.BenchmarkCondition{
+		Type:               perfv1alpha1.PassedCondition,
+		Status:             passed,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: now,
+	})
+	status.SetCondition(perfv1alpha1.BenchmarkCondition{
+		Type:               perfv1alpha1.RegressedCondition,
+		Status:             regressed,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: now,
+	})
+}
+
+// compareMetrics checks every value of the metrics matching the thresholds.
+// Thresholds without matching metric are failed.
+func compareMetrics(thresholds []perfv1alpha1.MetricThreshold,
+	results, baseline []perfv1alpha1.BenchmarkMetric) ([]perfv1alpha1.MetricComparison, bool) {
+	comparisons := []perfv1alpha1.MetricComparison{}
+	passed := true
+	for _, threshold := range thresholds {
+		matched := false
+		for _, result := range results {
+			if result.Name != threshold.Metric || !hasLabels(result.Labels, threshold.Labels) {
+				continue
+			}
+			matched = true
+
+			comparison := compareMetric(threshold, result, baseline)
+			passed = passed && comparison.Passed
+			comparisons = append(comparisons, comparison)
+		}
+
+		if !matched {
+			passed = false
+			comparisons = append(comparisons, perfv1alpha1.MetricComparison{
+				Metric:  threshold.Metric,
+				Labels:  threshold.Labels,
+				Message: "Metric not found in the results",
+			})
+		}
+	}
+	return comparisons, passed
+}
+
+// compareMetric checks a single value against the threshold
+func compareMetric(threshold perfv1alpha1.MetricThreshold, result perfv1alpha1.BenchmarkMetric,
+	baseline []perfv1alpha1.BenchmarkMetric) perfv1alpha1.MetricComparison {
+	comparison := perfv1alpha1.MetricComparison{
+		Metric: result.Name,
+		Labels: result.Labels,
+		Value:  result.Value,
+	}
+	fail := func(format string, args ...interface{}) perfv1alpha1.MetricComparison {
+		comparison.Message = fmt.Sprintf(format, args...)
+		return comparison
+	}
+
+	value, err := strconv.ParseFloat(result.Value, 64)
+	if err != nil {
+		return fail("Invalid value: %v", result.Value)
+	}
+
+	if threshold.Min != "" {
+		min, err := strconv.ParseFloat(threshold.Min, 64)
+		if err != nil {
+			return fail("Invalid min: %v", threshold.Min)
+		}
+		if value < min {
+			return fail("%v is below the minimum %v", result.Value, threshold.Min)
+		}
+	}
+	if threshold.Max != "" {
+		max, err := strconv.ParseFloat(threshold.Max, 64)
+		if err != nil {
+			return fail("Invalid max: %v", threshold.Max)
+		}
+		if value > max {
+			return fail("%v is above the maximum %v", result.Value, threshold.Max)
+		}
+	}
+
+	comparison.Baseline = threshold.Baseline
+	if comparison.Baseline == "" {
+		for _, metric := range baseline {
+			if metric.Name == result.Name && equalLabels(metric.Labels, result.Labels) {
+				comparison.Baseline = metric.Value
+				break
+			}
+		}
+	}
+	if threshold.TolerancePercent == "" {
+		comparison.Passed = true
+		return comparison
+	}
+	if comparison.Baseline == "" {
+		return fail("Baseline not found")
+	}
+
+	baselineValue, err := strconv.ParseFloat(comparison.Baseline, 64)
+	if err != nil || baselineValue == 0 {
+		return fail("Invalid baseline: %v", comparison.Baseline)
+	}
+	tolerance, err := strconv.ParseFloat(threshold.TolerancePercent, 64)
+	if err != nil {
+		return fail("Invalid tolerancePercent: %v", threshold.TolerancePercent)
+	}
+
+	deviation := (value - baselineValue) / baselineValue * 100
+	comparison.DeviationPercent = metrics.FormatValue(deviation)
+	direction := threshold.Direction
+	if direction == "" {
+		direction = perfv1alpha1.HigherIsBetter
+	}
+	if direction != perfv1alpha1.LowerIsBetter && deviation < -tolerance {
+		return fail("%v is more than %v%% below the baseline %v", result.Value, tolerance, comparison.Baseline)
+	}
+	if direction != perfv1alpha1.HigherIsBetter && deviation > tolerance {
+		return fail("%v is more than %v%% above the baseline %v", result.Value, tolerance, comparison.Baseline)
+	}
+
+	comparison.Passed = true
+	return comparison
+}
+
+// hasLabels checks that labels contain every selector label
+func hasLabels(labels, selector map[string]string) bool {
+	for name, value := range selector {
+		if labels[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func equalLabels(a, b map[string]string) bool {
+	return len(a) == len(b) && hasLabels(a, b)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("compareMetrics", func() {
+	results := []perfv1alpha1.BenchmarkMetric{
+		{Name: "kubestone_fio_iops", Labels: map[string]string{"job": "randread", "rw": "read"}, Value: "9000"},
+		{Name: "kubestone_iperf3_bits_per_second", Labels: map[string]string{"role": "receiver"}, Value: "9.4e+09"},
+	}
+	baseline := []perfv1alpha1.BenchmarkMetric{
+		{Name: "kubestone_fio_iops", Labels: map[string]string{"job": "randread", "rw": "read"}, Value: "10000"},
+	}
+
+	It("should pass values within the tolerance of the baseline result", func() {
+		comparisons, passed := compareMetrics([]perfv1alpha1.MetricThreshold{{
+			Metric:           "kubestone_fio_iops",
+			Labels:           map[string]string{"rw": "read"},
+			TolerancePercent: "10",
+		}}, results, baseline)
+		Expect(passed).To(BeTrue())
+		Expect(comparisons).To(Equal([]perfv1alpha1.MetricComparison{{
+			Metric:           "kubestone_fio_iops",
+			Labels:           map[string]string{"job": "randread", "rw": "read"},
+			Value:            "9000",
+			Baseline:         "10000",
+			DeviationPercent: "-10",
+			Passed:           true,
+		}}))
+	})
+
+	It("should fail values below the tolerance", func() {
+		comparisons, passed := compareMetrics([]perfv1alpha1.MetricThreshold{{
+			Metric:           "kubestone_fio_iops",
+			TolerancePercent: "5",
+		}}, results, baseline)
+		Expect(passed).To(BeFalse())
+		Expect(comparisons[0].Message).To(ContainSubstring("below the baseline"))
+	})
+
+	It("should use the inline baseline and direction", func() {
+		threshold := perfv1alpha1.MetricThreshold{
+			Metric:           "kubestone_fio_iops",
+			Baseline:         "8000",
+			TolerancePercent: "10",
+		}
+		_, passed := compareMetrics([]perfv1alpha1.MetricThreshold{threshold}, results, baseline)
+		Expect(passed).To(BeTrue())
+
+		threshold.Direction = perfv1alpha1.Both
+		_, passed = compareMetrics([]perfv1alpha1.MetricThreshold{threshold}, results, baseline)
+		Expect(passed).To(BeFalse())
+	})
+
+	It("should check the absolute bounds", func() {
+		_, passed := compareMetrics([]perfv1alpha1.MetricThreshold{{
+			Metric: "kubestone_iperf3_bits_per_second",
+			Min:    "9e9",
+		}}, results, nil)
+		Expect(passed).To(BeTrue())
+
+		comparisons, passed := compareMetrics([]perfv1alpha1.MetricThreshold{{
+			Metric: "kubestone_iperf3_bits_per_second",
+			Min:    "10e9",
+		}}, results, nil)
+		Expect(passed).To(BeFalse())
+		Expect(comparisons[0].Message).To(Equal("9.4e+09 is below the minimum 10e9"))
+	})
+
+	It("should fail thresholds without results or baseline", func() {
+		comparisons, passed := compareMetrics([]perfv1alpha1.MetricThreshold{
+			{Metric: "kubestone_missing", Min: "1"},
+			{Metric: "kubestone_iperf3_bits_per_second", TolerancePercent: "10"},
+		}, results, nil)
+		Expect(passed).To(BeFalse())
+		Expect(comparisons).To(HaveLen(2))
+		Expect(comparisons[0].Message).To(Equal("Metric not found in the results"))
+		Expect(comparisons[1].Message).To(Equal("Baseline not found"))
+	})
+})
+
+var _ = Describe("setRegressionConditions", func() {
+	It("should set Passed to the opposite of Regressed", func() {
+		status := perfv1alpha1.BenchmarkStatus{}
+		setRegressionConditions(&status, corev1.ConditionTrue, ThresholdsMissedReason, "")
+		Expect(status.GetCondition(perfv1alpha1.PassedCondition).Status).To(Equal(corev1.ConditionFalse))
+		Expect(status.GetCondition(perfv1alpha1.RegressedCondition).Status).To(Equal(corev1.ConditionTrue))
+
+		setRegressionConditions(&status, corev1.ConditionFalse, ThresholdsMetReason, "")
+		Expect(status.Conditions).To(HaveLen(2))
+		Expect(status.GetCondition(perfv1alpha1.PassedCondition).Status).To(Equal(corev1.ConditionTrue))
+	})
+
+	It("should not pass unknown regressions", func() {
+		status := perfv1alpha1.BenchmarkStatus{}
+		setRegressionConditions(&status, corev1.ConditionUnknown, BaselineNotFoundReason, "")
+		Expect(status.GetCondition(perfv1alpha1.PassedCondition).Status).To(Equal(corev1.ConditionFalse))
+	})
+})
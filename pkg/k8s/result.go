@@ -0,0 +1,153 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=benchmarkresults,verbs=get;list;watch;create;update
+
+const (
+	// AppLabel holds the lowercase kind of the benchmark
+	AppLabel = "kubestone.xridge.io/app"
+	// CrNameLabel holds the name of the benchmark
+	CrNameLabel = "kubestone.xridge.io/cr-name"
+
+	// TemplatedAnnotation marks the BenchmarkResults of templated
+	// benchmarks: their benchmarkSpec holds the values of the variables
+	TemplatedAnnotation = "kubestone.xridge.io/templated"
+)
+
+// BenchmarkResultName returns the name of the BenchmarkResult recording
+// the run of the given benchmark. The UID distinguishes the runs of the
+// benchmarks re-created with the same name.
+func BenchmarkResultName(cr metav1.Object) string {
+	uid := string(cr.GetUID())
+	if len(uid) > 8 {
+		uid = uid[:8]
+	}
+	if uid == "" {
+		return cr.GetName()
+	}
+	return cr.GetName() + "-" + uid
+}
+
+// recordResult creates the BenchmarkResult of the given finished benchmark
+// with the environment where it was running.
+// The result is not owned by the benchmark, so it outlives the benchmark.
+// The result is recorded before the status of the benchmark is updated.
+// When that update fails, the completion is retried and the existing
+// result is replaced, so the result matches the final status.
+func (a *Access) recordResult(ctx context.Context, kind string, cr perfv1alpha1.Benchmark,
+	startTime *metav1.Time, environment perfv1alpha1.EnvironmentSpec) error {
+	result, err := newBenchmarkResult(kind, cr, startTime, environment)
+	if err != nil {
+		return err
+	}
+	err = a.Client.Create(ctx, result)
+	if !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	var existing perfv1alpha1.BenchmarkResult
+	key := types.NamespacedName{Namespace: result.Namespace, Name: result.Name}
+	if err := a.Client.Get(ctx, key, &existing); err != nil {
+		return err
+	}
+	existing.Labels = result.Labels
+	existing.Annotations = result.Annotations
+	existing.Spec = result.Spec
+	return a.Client.Update(ctx, &existing)
+}
+
+// newBenchmarkResult creates the record of the given finished benchmark. The
+// labels of the benchmark are copied to the result, extended with the app
+// and cr-name labels used by the benchmark pods.
+func newBenchmarkResult(kind string, cr perfv1alpha1.Benchmark, startTime *metav1.Time,
+	environment perfv1alpha1.EnvironmentSpec) (*perfv1alpha1.BenchmarkResult, error) {
+	spec, err := benchmarkSpec(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{}
+	for key, value := range cr.GetLabels() {
+		labels[key] = value
+	}
+	labels[AppLabel] = strings.ToLower(kind)
+	labels[CrNameLabel] = cr.GetName()
+
+	status := cr.GetBenchmarkStatus()
+	outcome := perfv1alpha1.BenchmarkSucceeded
+	if status.Failed {
+		outcome = perfv1alpha1.BenchmarkFailed
+	}
+
+	var annotations map[string]string
+	if status.ResolvedSpec != "" {
+		annotations = map[string]string{TemplatedAnnotation: "true"}
+	}
+
+	return &perfv1alpha1.BenchmarkResult{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        BenchmarkResultName(cr),
+			Namespace:   cr.GetNamespace(),
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: perfv1alpha1.BenchmarkResultSpec{
+			Benchmark: perfv1alpha1.BenchmarkReference{
+				Kind: kind,
+				Name: cr.GetName(),
+				UID:  string(cr.GetUID()),
+			},
+			BenchmarkSpec:  spec,
+			Outcome:        outcome,
+			StartTime:      startTime,
+			CompletionTime: status.CompletionTime,
+			Metrics:        status.Metrics,
+			Aggregates:     status.Aggregates,
+			Environment:    environment,
+		},
+	}, nil
+}
+
+// benchmarkSpec returns the spec of the given benchmark as raw JSON
+func benchmarkSpec(cr perfv1alpha1.Benchmark) (runtime.RawExtension, error) {
+	encoded, err := json.Marshal(cr)
+	if err != nil {
+		return runtime.RawExtension{}, err
+	}
+
+	var object struct {
+		Spec json.RawMessage `json:"spec"`
+	}
+	if err := json.Unmarshal(encoded, &object); err != nil {
+		return runtime.RawExtension{}, err
+	}
+	return runtime.RawExtension{Raw: object.Spec}, nil
+}
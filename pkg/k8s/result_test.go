@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("newBenchmarkResult", func() {
+	startTime := metav1.NewTime(time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC))
+	completionTime := metav1.NewTime(startTime.Add(time.Minute))
+	cr := perfv1alpha1.Fio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fio-sample",
+			Namespace: "kubestone",
+			UID:       "6f1c2a4e-3b5d-4c7e-9f80-1a2b3c4d5e6f",
+			Labels:    map[string]string{"team": "storage"},
+		},
+		Spec: perfv1alpha1.FioSpec{
+			CmdLineArgs: "--runtime=60",
+		},
+		Status: perfv1alpha1.BenchmarkStatus{
+			Completed:      true,
+			Failed:         true,
+			CompletionTime: &completionTime,
+			NodeNames:      []string{"node-1"},
+			Metrics: []perfv1alpha1.BenchmarkMetric{
+				{Name: "kubestone_benchmark_succeeded", Value: "0"},
+			},
+		},
+	}
+
+	It("should be named after the benchmark run", func() {
+		Expect(BenchmarkResultName(&cr)).To(Equal("fio-sample-6f1c2a4e"))
+	})
+
+	It("should record the run of the benchmark", func() {
+		environment := perfv1alpha1.EnvironmentSpec{
+			NodeNames:        []string{"node-1"},
+			KubestoneVersion: "v0.5.0",
+		}
+		result, err := newBenchmarkResult("Fio", &cr, &startTime, environment)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(result.Namespace).To(Equal("kubestone"))
+		Expect(result.OwnerReferences).To(BeEmpty())
+		Expect(result.Labels).To(Equal(map[string]string{
+			"team":                        "storage",
+			"kubestone.xridge.io/app":     "fio",
+			"kubestone.xridge.io/cr-name": "fio-sample",
+		}))
+		Expect(result.Spec.Benchmark).To(Equal(perfv1alpha1.BenchmarkReference{
+			Kind: "Fio",
+			Name: "fio-sample",
+			UID:  "6f1c2a4e-3b5d-4c7e-9f80-1a2b3c4d5e6f",
+		}))
+		Expect(string(result.Spec.BenchmarkSpec.Raw)).To(ContainSubstring(`"cmdLineArgs":"--runtime=60"`))
+		Expect(result.Spec.Outcome).To(Equal(perfv1alpha1.BenchmarkFailed))
+		Expect(result.Spec.StartTime).To(Equal(&startTime))
+		Expect(result.Spec.CompletionTime).To(Equal(&completionTime))
+		Expect(result.Spec.Metrics).To(Equal(cr.Status.Metrics))
+		Expect(result.Spec.Environment).To(Equal(environment))
+		Expect(result.Annotations).NotTo(HaveKey(TemplatedAnnotation))
+	})
+
+	It("should mark the results of templated benchmarks", func() {
+		templated := cr.DeepCopy()
+		templated.Status.ResolvedSpec = `{"cmdLineArgs":"--runtime=60"}`
+		result, err := newBenchmarkResult("Fio", templated, &startTime, perfv1alpha1.EnvironmentSpec{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Annotations).To(HaveKeyWithValue(TemplatedAnnotation, "true"))
+	})
+})
+
+var _ = Describe("recordResult", func() {
+	It("should replace the result of a previous attempt", func() {
+		Expect(perfv1alpha1.AddToScheme(k8sscheme.Scheme)).To(Succeed())
+		access := &Access{Client: fake.NewFakeClientWithScheme(k8sscheme.Scheme)}
+		cr := perfv1alpha1.Fio{
+			ObjectMeta: metav1.ObjectMeta{Name: "fio", Namespace: "kubestone", UID: "6f1c2a4e"},
+			Status:     perfv1alpha1.BenchmarkStatus{Completed: true, Failed: true},
+		}
+		Expect(access.recordResult(context.Background(), "Fio", &cr, nil,
+			perfv1alpha1.EnvironmentSpec{})).To(Succeed())
+
+		cr.Status.Failed = false
+		Expect(access.recordResult(context.Background(), "Fio", &cr, nil,
+			perfv1alpha1.EnvironmentSpec{})).To(Succeed())
+
+		var result perfv1alpha1.BenchmarkResult
+		Expect(access.Client.Get(context.Background(),
+			types.NamespacedName{Namespace: "kubestone", Name: "fio-6f1c2a4e"}, &result)).To(Succeed())
+		Expect(result.Spec.Outcome).To(Equal(perfv1alpha1.BenchmarkSucceeded))
+	})
+})
@@ -0,0 +1,40 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// StartBenchmark moves the given benchmark to the Running state and
+// notifies the webhooks about the start. It is a no-op for benchmarks
+// which are already running.
+func (a *Access) StartBenchmark(ctx context.Context, cr perfv1alpha1.Benchmark) error {
+	if cr.GetBenchmarkStatus().Running {
+		return nil
+	}
+
+	cr.GetBenchmarkStatus().Running = true
+	if err := a.Client.Status().Update(ctx, cr); err != nil {
+		return err
+	}
+
+	a.notify(ctx, cr, perfv1alpha1.StartedEvent)
+	return nil
+}
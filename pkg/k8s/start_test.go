@@ -0,0 +1,36 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("StartBenchmark", func() {
+	It("should not restart a running benchmark", func() {
+		// The nil client would panic if the status was updated again
+		access := &Access{}
+		cr := perfv1alpha1.Fio{Status: perfv1alpha1.BenchmarkStatus{Running: true}}
+		Expect(access.StartBenchmark(context.Background(), &cr)).To(Succeed())
+		Expect(cr.Status.Running).To(BeTrue())
+	})
+})
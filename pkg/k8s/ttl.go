@@ -0,0 +1,93 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/metrics"
+)
+
+// CleanupFinished deletes the given finished benchmark once its
+// TTLSecondsAfterFinished (or the manager wide default) expires.
+// The benchmark is kept until its results are pushed to the
+// ResultSink and uploaded to the archive, or until either fails.
+// The objects created for the benchmark are removed by the garbage
+// collector via the owner references set in CreateWithReference.
+// A requeue is requested for benchmarks which are not yet expired.
+func (a *Access) CleanupFinished(ctx context.Context, cr perfv1alpha1.Benchmark) (ctrl.Result, error) {
+	if retryAfter, err := a.exportResults(ctx, cr); err != nil || retryAfter > 0 {
+		return ctrl.Result{RequeueAfter: retryAfter}, err
+	}
+	if checkAfter, err := a.checkArchive(ctx, cr); err != nil || checkAfter > 0 {
+		return ctrl.Result{RequeueAfter: checkAfter}, err
+	}
+
+	ttl := cr.GetRunPolicy().TTLSecondsAfterFinished
+	if ttl == nil {
+		ttl = a.DefaultTTLSecondsAfterFinished
+	}
+
+	remaining, expirable := timeToExpiry(cr.GetBenchmarkStatus().CompletionTime, ttl, time.Now())
+	if !expirable {
+		return ctrl.Result{}, nil
+	}
+	if remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	_ = a.RecordEventf(cr, corev1.EventTypeNormal, Expired,
+		"Deleting finished benchmark as its TTL (%vs) expired", *ttl)
+
+	err := a.Client.Delete(ctx, cr, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err = IgnoreNotFound(err); err == nil {
+		metrics.ForgetResults(cr.GetNamespace(), cr.GetName())
+	}
+	return ctrl.Result{}, err
+}
+
+// IgnoreDeleted returns nil on k8s Not Found type of errors, but
+// returns the error as-is otherwise, like IgnoreNotFound. When the
+// benchmark with the given name is not found, the result metrics
+// published for it are forgotten, as it has been deleted.
+func IgnoreDeleted(name types.NamespacedName, err error) error {
+	if errors.IsNotFound(err) {
+		metrics.ForgetResults(name.Namespace, name.Name)
+	}
+	return IgnoreNotFound(err)
+}
+
+// timeToExpiry returns the time left from the ttl of a benchmark finished
+// at completionTime. The benchmark is not expirable when either the ttl or
+// the completionTime is missing.
+func timeToExpiry(completionTime *metav1.Time, ttl *int32, now time.Time) (remaining time.Duration, expirable bool) {
+	if completionTime == nil || ttl == nil || *ttl < 0 {
+		return 0, false
+	}
+
+	expireAt := completionTime.Add(time.Duration(*ttl) * time.Second)
+	return expireAt.Sub(now), true
+}
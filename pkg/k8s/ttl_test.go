@@ -0,0 +1,81 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("timeToExpiry", func() {
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	ttl := func(seconds int32) *int32 { return &seconds }
+
+	Context("without ttl", func() {
+		It("should not be expirable", func() {
+			completionTime := metav1.NewTime(now)
+			_, expirable := timeToExpiry(&completionTime, nil, now)
+			Expect(expirable).To(BeFalse())
+		})
+	})
+
+	Context("with negative ttl", func() {
+		It("should not be expirable", func() {
+			completionTime := metav1.NewTime(now)
+			_, expirable := timeToExpiry(&completionTime, ttl(-1), now)
+			Expect(expirable).To(BeFalse())
+		})
+	})
+
+	Context("without completion time", func() {
+		It("should not be expirable", func() {
+			_, expirable := timeToExpiry(nil, ttl(60), now)
+			Expect(expirable).To(BeFalse())
+		})
+	})
+
+	Context("with ttl not yet expired", func() {
+		It("should return the remaining time", func() {
+			completionTime := metav1.NewTime(now.Add(-20 * time.Second))
+			remaining, expirable := timeToExpiry(&completionTime, ttl(60), now)
+			Expect(expirable).To(BeTrue())
+			Expect(remaining).To(Equal(40 * time.Second))
+		})
+	})
+
+	Context("with expired ttl", func() {
+		It("should not have remaining time", func() {
+			completionTime := metav1.NewTime(now.Add(-2 * time.Minute))
+			remaining, expirable := timeToExpiry(&completionTime, ttl(60), now)
+			Expect(expirable).To(BeTrue())
+			Expect(remaining).To(BeNumerically("<=", 0))
+		})
+	})
+
+	Context("with zero ttl", func() {
+		It("should expire immediately", func() {
+			completionTime := metav1.NewTime(now)
+			remaining, expirable := timeToExpiry(&completionTime, ttl(0), now)
+			Expect(expirable).To(BeTrue())
+			Expect(remaining).To(BeNumerically("<=", 0))
+		})
+	})
+})
@@ -0,0 +1,355 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+const (
+	// VariablesAnnotationPrefix is the prefix of the annotations passing
+	// variables to a benchmark, e.g. vars.kubestone.xridge.io/pgHost
+	VariablesAnnotationPrefix = "vars.kubestone.xridge.io/"
+
+	// NodeNameEnv is the environment variable holding the name of the
+	// node of the pod, provided via the downward API
+	NodeNameEnv = "KUBESTONE_NODE_NAME"
+	// NodeIPEnv is the environment variable holding the IP address of
+	// the node of the pod, provided via the downward API
+	NodeIPEnv = "KUBESTONE_NODE_IP"
+	// PodNameEnv is the environment variable holding the name of the pod,
+	// provided via the downward API
+	PodNameEnv = "KUBESTONE_POD_NAME"
+	// PodIPEnv is the environment variable holding the IP address of the
+	// pod, provided via the downward API
+	PodIPEnv = "KUBESTONE_POD_IP"
+)
+
+// downwardEnv are the environment variables added to the containers
+// referencing them
+var downwardEnv = []corev1.EnvVar{
+	{Name: NodeNameEnv, ValueFrom: fieldRef("spec.nodeName")},
+	{Name: NodeIPEnv, ValueFrom: fieldRef("status.hostIP")},
+	{Name: PodNameEnv, ValueFrom: fieldRef("metadata.name")},
+	{Name: PodIPEnv, ValueFrom: fieldRef("status.podIP")},
+}
+
+func fieldRef(fieldPath string) *corev1.EnvVarSource {
+	return &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: fieldPath}}
+}
+
+// TemplateData is the data available in the templated fields of the spec
+type TemplateData struct {
+	// Vars are the variables of the benchmark
+	Vars map[string]string
+	// Node is the node of the benchmark pod
+	Node struct{ Name, IP string }
+	// Pod is the benchmark pod
+	Pod struct{ Name, IP string }
+	// Run identifies the run of the benchmark
+	Run struct{ ID, Name, Namespace string }
+}
+
+// NewTemplateData returns the data of the templates of the benchmark.
+// The fields of the node and the pod are not known before the pod is
+// scheduled, they are references to the environment variables provided
+// via the downward API, which are expanded in the command line and the
+// environment of the containers by the kubelet. The ID of the run is the
+// name of its BenchmarkResult.
+func NewTemplateData(cr metav1.Object, vars map[string]string) TemplateData {
+	data := TemplateData{Vars: vars}
+	data.Node.Name = "$(" + NodeNameEnv + ")"
+	data.Node.IP = "$(" + NodeIPEnv + ")"
+	data.Pod.Name = "$(" + PodNameEnv + ")"
+	data.Pod.IP = "$(" + PodIPEnv + ")"
+	data.Run.ID = BenchmarkResultName(cr)
+	data.Run.Name = cr.GetName()
+	data.Run.Namespace = cr.GetNamespace()
+	return data
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+
+// ResolveVariables resolves the templates in the string fields of the
+// spec on the first entry and stores the resolved spec in the status.
+// Later the stored spec is used, so the benchmark runs with the same
+// values even if the variables change (the liveSpecFields excepted). Resolution errors (e.g. undefined
+// variables or a missing ConfigMap) are validation failures: they are
+// reported in an event and false is returned. Errors returned should be
+// retried. As the image is optional in the CRDs so that profiles can
+// provide it, a benchmark without image is invalid as well.
+func (a *Access) ResolveVariables(ctx context.Context, cr perfv1alpha1.Benchmark) (valid bool, err error) {
+	status := cr.GetBenchmarkStatus()
+	if status.ResolvedSpec != "" {
+		return true, restoreResolvedSpec(cr)
+	}
+	if status.Running || status.Completed || status.Cancelled {
+		return true, nil
+	}
+	if !hasImage(cr) {
+		_ = a.RecordEventf(cr, corev1.EventTypeWarning, CreateFailed,
+			"CR validation failed: image.name is set neither in the benchmark nor in its profile")
+		return false, nil
+	}
+
+	var configMapData map[string]string
+	if variables := cr.GetRunPolicy().Variables; variables != nil && variables.ConfigMap != "" {
+		var configMap corev1.ConfigMap
+		err := getObject(ctx, a.Clientset.CoreV1().RESTClient(), "configmaps",
+			types.NamespacedName{Namespace: cr.GetNamespace(), Name: variables.ConfigMap}, &configMap)
+		if errors.IsNotFound(err) {
+			_ = a.RecordEventf(cr, corev1.EventTypeWarning, CreateFailed,
+				"CR validation failed: ConfigMap %v of the variables not found", variables.ConfigMap)
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		configMapData = configMap.Data
+	}
+
+	templated, err := ResolveSpec(cr, NewTemplateData(cr, Variables(cr, configMapData)))
+	if err != nil {
+		_ = a.RecordEventf(cr, corev1.EventTypeWarning, CreateFailed,
+			"CR validation failed: %v", err)
+		return false, nil
+	}
+	if templated {
+		object, err := toObject(cr)
+		if err != nil {
+			return false, err
+		}
+		resolvedSpec, err := json.Marshal(object["spec"])
+		if err != nil {
+			return false, err
+		}
+		status.ResolvedSpec = string(resolvedSpec)
+	}
+	return true, nil
+}
+
+// hasImage checks if the image of the benchmark is set. Benchmarks
+// without image field are accepted.
+func hasImage(cr perfv1alpha1.Benchmark) bool {
+	spec := reflect.ValueOf(cr).Elem().FieldByName("Spec")
+	if !spec.IsValid() {
+		return true
+	}
+	field := spec.FieldByName("Image")
+	if !field.IsValid() {
+		return true
+	}
+	image, ok := field.Interface().(perfv1alpha1.ImageSpec)
+	return !ok || image.Name != ""
+}
+
+// Variables returns the variables of the benchmark: the data of its
+// ConfigMap (given in configMapData), overridden by the values given
+// inline, overridden by the annotations of the benchmark
+func Variables(cr perfv1alpha1.Benchmark, configMapData map[string]string) map[string]string {
+	vars := map[string]string{}
+	for name, value := range configMapData {
+		vars[name] = value
+	}
+	if variables := cr.GetRunPolicy().Variables; variables != nil {
+		for name, value := range variables.Values {
+			vars[name] = value
+		}
+	}
+	for key, value := range cr.GetAnnotations() {
+		if strings.HasPrefix(key, VariablesAnnotationPrefix) {
+			vars[strings.TrimPrefix(key, VariablesAnnotationPrefix)] = value
+		}
+	}
+	return vars
+}
+
+// ResolveSpec executes the templates in the string fields of the spec
+// of the benchmark. Returns whether the spec had any templates.
+func ResolveSpec(cr perfv1alpha1.Benchmark, data TemplateData) (templated bool, err error) {
+	object, err := toObject(cr)
+	if err != nil {
+		return false, err
+	}
+	resolver := templateResolver{data: data}
+	spec, err := resolver.resolve("spec", object["spec"])
+	if err != nil || !resolver.templated {
+		return false, err
+	}
+	object["spec"] = spec
+	return true, fromObject(object, cr)
+}
+
+// liveSpecFields are the fields of the run policy which control the
+// lifecycle of a started benchmark. They are taken from the live spec
+// instead of the resolved one, so that e.g. a running templated
+// benchmark can still be cancelled.
+var liveSpecFields = []string{"cancel", "suspend", "ttlSecondsAfterFinished"}
+
+// restoreResolvedSpec replaces the spec of the benchmark with the
+// resolved spec stored in its status, keeping the liveSpecFields
+func restoreResolvedSpec(cr perfv1alpha1.Benchmark) error {
+	var spec map[string]interface{}
+	if err := json.Unmarshal([]byte(cr.GetBenchmarkStatus().ResolvedSpec), &spec); err != nil {
+		return err
+	}
+	object, err := toObject(cr)
+	if err != nil {
+		return err
+	}
+	liveSpec, _ := object["spec"].(map[string]interface{})
+	for _, field := range liveSpecFields {
+		if value, found := liveSpec[field]; found {
+			spec[field] = value
+		} else {
+			delete(spec, field)
+		}
+	}
+	object["spec"] = spec
+	return fromObject(object, cr)
+}
+
+type templateResolver struct {
+	data      TemplateData
+	templated bool
+}
+
+// resolve executes the templates in the strings of the value, path is
+// the location of the value used in the errors
+func (r *templateResolver) resolve(path string, value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case string:
+		if !strings.Contains(value, "{{") {
+			return value, nil
+		}
+		r.templated = true
+		tmpl, err := template.New(path).Option("missingkey=error").Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		var resolved bytes.Buffer
+		if err := tmpl.Execute(&resolved, r.data); err != nil {
+			return nil, err
+		}
+		return resolved.String(), nil
+	case map[string]interface{}:
+		resolved := map[string]interface{}{}
+		for key, field := range value {
+			field, err := r.resolve(path+"."+key, field)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = field
+		}
+		return resolved, nil
+	case []interface{}:
+		resolved := make([]interface{}, len(value))
+		for i, item := range value {
+			item, err := r.resolve(fmt.Sprintf("%v[%v]", path, i), item)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = item
+		}
+		return resolved, nil
+	default:
+		return value, nil
+	}
+}
+
+// AddDownwardEnv adds the environment variables referenced by the
+// templates of the node and the pod to the containers of the job or
+// the deployment, so that the kubelet can expand the references
+func AddDownwardEnv(object metav1.Object) {
+	template := podTemplate(object)
+	if template == nil {
+		return
+	}
+	for i := range template.Spec.InitContainers {
+		addDownwardEnv(&template.Spec.InitContainers[i])
+	}
+	for i := range template.Spec.Containers {
+		addDownwardEnv(&template.Spec.Containers[i])
+	}
+}
+
+func addDownwardEnv(container *corev1.Container) {
+	references := append(append([]string{}, container.Command...), container.Args...)
+	for _, env := range container.Env {
+		references = append(references, env.Value)
+	}
+	joined := strings.Join(references, " ")
+
+	var added []corev1.EnvVar
+	for _, env := range downwardEnv {
+		if strings.Contains(joined, "$("+env.Name+")") && !hasEnv(container, env.Name) {
+			added = append(added, env)
+		}
+	}
+	// References in the environment are expanded only to the variables
+	// defined before them
+	container.Env = append(added, container.Env...)
+}
+
+func hasEnv(container *corev1.Container, name string) bool {
+	for _, env := range container.Env {
+		if env.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// toObject converts the benchmark to its JSON representation
+func toObject(cr perfv1alpha1.Benchmark) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(cr)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(encoded, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// fromObject replaces the benchmark with the given JSON representation.
+// The fields missing from the representation are cleared.
+func fromObject(object map[string]interface{}, cr perfv1alpha1.Benchmark) error {
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	decoded := reflect.New(reflect.TypeOf(cr).Elem())
+	if err := json.Unmarshal(encoded, decoded.Interface()); err != nil {
+		return err
+	}
+	reflect.ValueOf(cr).Elem().Set(decoded.Elem())
+	return nil
+}
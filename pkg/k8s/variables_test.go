@@ -0,0 +1,171 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("Variables", func() {
+	It("should override the ConfigMap with the values and the annotations", func() {
+		cr := perfv1alpha1.Pgbench{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					VariablesAnnotationPrefix + "scale": "100",
+					"other":                             "ignored",
+				},
+			},
+			Spec: perfv1alpha1.PgbenchSpec{
+				RunPolicySpec: perfv1alpha1.RunPolicySpec{
+					Variables: &perfv1alpha1.VariablesSpec{
+						Values: map[string]string{"pgHost": "db2", "scale": "10"},
+					},
+				},
+			},
+		}
+		vars := Variables(&cr, map[string]string{"pgHost": "db1", "user": "admin"})
+		Expect(vars).To(Equal(map[string]string{"pgHost": "db2", "user": "admin", "scale": "100"}))
+	})
+})
+
+var _ = Describe("ResolveSpec", func() {
+	var cr perfv1alpha1.Pgbench
+
+	BeforeEach(func() {
+		cr = perfv1alpha1.Pgbench{
+			ObjectMeta: metav1.ObjectMeta{Name: "pgbench", Namespace: "bench", UID: "0123456789"},
+			Spec: perfv1alpha1.PgbenchSpec{
+				Postgres: perfv1alpha1.PostgresSpec{Host: "{{ .Vars.pgHost }}", Port: 5432},
+				InitArgs: "-s 5",
+				Args:     "--tag={{ .Run.ID }} --node={{ .Node.Name }}",
+			},
+		}
+	})
+
+	It("should resolve the templates", func() {
+		templated, err := ResolveSpec(&cr, NewTemplateData(&cr, map[string]string{"pgHost": "db1"}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(templated).To(BeTrue())
+		Expect(cr.Spec.Postgres.Host).To(Equal("db1"))
+		Expect(cr.Spec.Postgres.Port).To(Equal(5432))
+		Expect(cr.Spec.InitArgs).To(Equal("-s 5"))
+		Expect(cr.Spec.Args).To(Equal("--tag=pgbench-01234567 --node=$(KUBESTONE_NODE_NAME)"))
+		Expect(cr.Name).To(Equal("pgbench"))
+	})
+
+	It("should fail on undefined variables", func() {
+		_, err := ResolveSpec(&cr, NewTemplateData(&cr, map[string]string{}))
+		Expect(err).To(MatchError(ContainSubstring("spec.postgres.host")))
+	})
+
+	It("should fail on invalid templates", func() {
+		cr.Spec.InitArgs = "{{ .Vars.scale"
+		_, err := ResolveSpec(&cr, NewTemplateData(&cr, map[string]string{"pgHost": "db1"}))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should report specs without templates", func() {
+		cr.Spec.Postgres.Host = "db1"
+		cr.Spec.Args = ""
+		templated, err := ResolveSpec(&cr, NewTemplateData(&cr, nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(templated).To(BeFalse())
+	})
+})
+
+var _ = Describe("hasImage", func() {
+	It("should require the name of the image", func() {
+		Expect(hasImage(&perfv1alpha1.Fio{})).To(BeFalse())
+		Expect(hasImage(&perfv1alpha1.Fio{Spec: perfv1alpha1.FioSpec{
+			Image: perfv1alpha1.ImageSpec{Name: "xridge/fio:3.13"}}})).To(BeTrue())
+	})
+})
+
+var _ = Describe("restoreResolvedSpec", func() {
+	It("should replace the spec with the resolved one", func() {
+		cr := perfv1alpha1.Pgbench{
+			ObjectMeta: metav1.ObjectMeta{Name: "pgbench"},
+			Spec: perfv1alpha1.PgbenchSpec{
+				Postgres: perfv1alpha1.PostgresSpec{Host: "{{ .Vars.pgHost }}"},
+				Args:     "--client=4",
+			},
+			Status: perfv1alpha1.BenchmarkStatus{
+				Running:      true,
+				ResolvedSpec: `{"postgres":{"host":"db1","port":5432}}`,
+			},
+		}
+		Expect(restoreResolvedSpec(&cr)).To(Succeed())
+		Expect(cr.Spec.Postgres.Host).To(Equal("db1"))
+		Expect(cr.Spec.Args).To(BeEmpty())
+		Expect(cr.Status.Running).To(BeTrue())
+	})
+
+	It("should keep the cancellation of a running benchmark", func() {
+		cr := perfv1alpha1.Pgbench{
+			ObjectMeta: metav1.ObjectMeta{Name: "pgbench"},
+			Spec: perfv1alpha1.PgbenchSpec{
+				RunPolicySpec: perfv1alpha1.RunPolicySpec{Cancel: true},
+				Postgres:      perfv1alpha1.PostgresSpec{Host: "{{ .Vars.pgHost }}"},
+			},
+			Status: perfv1alpha1.BenchmarkStatus{
+				Running:      true,
+				ResolvedSpec: `{"postgres":{"host":"db1","port":5432},"suspend":true}`,
+			},
+		}
+		Expect(restoreResolvedSpec(&cr)).To(Succeed())
+		Expect(cr.Spec.Postgres.Host).To(Equal("db1"))
+		Expect(cr.Spec.Cancel).To(BeTrue())
+		Expect(cr.Spec.Suspend).To(BeFalse())
+	})
+})
+
+var _ = Describe("AddDownwardEnv", func() {
+	It("should add the referenced environment variables", func() {
+		job := &batchv1.Job{
+			Spec: batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Args: []string{"--node=$(KUBESTONE_NODE_NAME)"},
+								Env:  []corev1.EnvVar{{Name: "HOST", Value: "$(KUBESTONE_POD_IP)"}},
+							},
+							{Args: []string{"--node=node-1"}},
+						},
+					},
+				},
+			},
+		}
+		AddDownwardEnv(job)
+		AddDownwardEnv(job)
+
+		env := job.Spec.Template.Spec.Containers[0].Env
+		Expect(env).To(HaveLen(3))
+		Expect(env[0].Name).To(Equal(NodeNameEnv))
+		Expect(env[0].ValueFrom.FieldRef.FieldPath).To(Equal("spec.nodeName"))
+		Expect(env[1].Name).To(Equal(PodIPEnv))
+		Expect(env[2].Name).To(Equal("HOST"))
+		Expect(job.Spec.Template.Spec.Containers[1].Env).To(BeEmpty())
+	})
+})
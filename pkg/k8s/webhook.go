@@ -0,0 +1,150 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/webhook"
+)
+
+// webhookClient sends the notifications. Its timeout keeps unresponsive
+// webhooks from blocking the reconciles. Redirects are not followed, so
+// the webhooks cannot forward the payload to hosts which are not in
+// WebhookHosts: the redirect responses are reported as failures.
+var webhookClient = &http.Client{
+	Timeout: 10 * time.Second,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+
+// notify sends the given event of the benchmark to its webhooks, or to
+// the global webhooks when the benchmark has none. The webhooks of the
+// benchmark are only notified when their hosts are in WebhookHosts.
+// Notifications are best effort: failures are recorded as events and
+// are not retried.
+func (a *Access) notify(ctx context.Context, cr perfv1alpha1.Benchmark, event perfv1alpha1.WebhookEvent) {
+	webhooks, secretNamespace, err := a.getWebhooks(ctx, cr)
+	fromBenchmark := len(cr.GetRunPolicy().Webhooks) > 0
+	if err != nil {
+		_ = a.RecordEventf(cr, corev1.EventTypeWarning, WebhookFailed,
+			"Unable to load the webhooks: %v", err)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	gvk, err := apiutil.GVKForObject(cr, a.Scheme)
+	if err != nil {
+		return
+	}
+	body, err := json.Marshal(newPayload(gvk.Kind, cr, event, time.Now()))
+	if err != nil {
+		return
+	}
+
+	for i := range webhooks {
+		if !webhook.Subscribed(&webhooks[i], event) {
+			continue
+		}
+		if fromBenchmark && !webhook.Allowed(webhooks[i].URL, a.WebhookHosts) {
+			_ = a.RecordEventf(cr, corev1.EventTypeWarning, WebhookFailed,
+				"Webhook %v is not allowed by the operator", webhooks[i].URL)
+			continue
+		}
+
+		var key []byte
+		if selector := webhooks[i].SigningSecret; selector != nil {
+			var secret corev1.Secret
+			err := getObject(ctx, a.Clientset.CoreV1().RESTClient(), "secrets",
+				types.NamespacedName{Namespace: secretNamespace, Name: selector.Name}, &secret)
+			if err != nil {
+				_ = a.RecordEventf(cr, corev1.EventTypeWarning, WebhookFailed,
+					"Unable to get the signing secret of %v: %v", webhooks[i].URL, err)
+				continue
+			}
+			key = secret.Data[selector.Key]
+		}
+
+		if err := webhook.Send(ctx, webhookClient, webhooks[i].URL, event, body, key); err != nil {
+			_ = a.RecordEventf(cr, corev1.EventTypeWarning, WebhookFailed,
+				"Unable to send %v event: %v", event, err)
+		}
+	}
+}
+
+// getWebhooks returns the webhooks of the benchmark together with the
+// namespace of their signing secrets
+func (a *Access) getWebhooks(ctx context.Context, cr perfv1alpha1.Benchmark) (
+	[]perfv1alpha1.WebhookSpec, string, error) {
+	if webhooks := cr.GetRunPolicy().Webhooks; len(webhooks) > 0 {
+		return webhooks, cr.GetNamespace(), nil
+	}
+	if a.WebhookConfig.Name == "" {
+		return nil, "", nil
+	}
+
+	var configMap corev1.ConfigMap
+	err := getObject(ctx, a.Clientset.CoreV1().RESTClient(), "configmaps", a.WebhookConfig, &configMap)
+	if err != nil {
+		return nil, "", IgnoreNotFound(err)
+	}
+	webhooks, err := webhook.ParseConfig(configMap.Data)
+	return webhooks, a.WebhookConfig.Namespace, err
+}
+
+// newPayload creates the webhook payload of the given event. The payloads
+// of the finished benchmarks contain their results and a link to their
+// BenchmarkResult.
+func newPayload(kind string, cr perfv1alpha1.Benchmark, event perfv1alpha1.WebhookEvent,
+	now time.Time) *webhook.Payload {
+	payload := &webhook.Payload{
+		Event:     event,
+		Kind:      kind,
+		Name:      cr.GetName(),
+		Namespace: cr.GetNamespace(),
+		Timestamp: now,
+	}
+	if event == perfv1alpha1.StartedEvent {
+		return payload
+	}
+
+	status := cr.GetBenchmarkStatus()
+	payload.Results = status.Metrics
+	payload.Comparisons = status.Comparisons
+	payload.Links = map[string]string{
+		"benchmarkResult": "/apis/" + perfv1alpha1.GroupVersion.String() + "/namespaces/" +
+			cr.GetNamespace() + "/benchmarkresults/" + BenchmarkResultName(cr),
+	}
+	if archive := cr.GetRunPolicy().Archive; archive != nil {
+		payload.Links["archive"] = archiveURL(archive, archiveKeyPrefix(cr, archive))
+	}
+	return payload
+}
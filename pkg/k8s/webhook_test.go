@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/webhook"
+)
+
+var _ = Describe("webhookClient", func() {
+	It("should not follow the redirects of the webhooks", func() {
+		redirected := false
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			redirected = true
+		}))
+		defer target.Close()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL, http.StatusFound)
+		}))
+		defer server.Close()
+
+		Expect(webhook.Send(context.Background(), webhookClient, server.URL,
+			perfv1alpha1.StartedEvent, []byte("{}"), nil)).NotTo(Succeed())
+		Expect(redirected).To(BeFalse())
+	})
+})
+
+var _ = Describe("newPayload", func() {
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	var cr perfv1alpha1.Iperf3
+
+	BeforeEach(func() {
+		cr = perfv1alpha1.Iperf3{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "iperf3-sample",
+				Namespace: "kubestone",
+				UID:       "0d6b1f3e-8c2a-4e5f-9a7b-6c5d4e3f2a1b",
+			},
+			Status: perfv1alpha1.BenchmarkStatus{
+				Metrics: []perfv1alpha1.BenchmarkMetric{
+					{Name: "kubestone_iperf3_bits_per_second", Value: "9.4e+09"},
+				},
+				Comparisons: []perfv1alpha1.MetricComparison{
+					{Metric: "kubestone_iperf3_bits_per_second", Value: "9.4e+09", Passed: true},
+				},
+			},
+		}
+	})
+
+	It("should identify the benchmark", func() {
+		payload := newPayload("Iperf3", &cr, perfv1alpha1.StartedEvent, now)
+		Expect(payload.Event).To(Equal(perfv1alpha1.StartedEvent))
+		Expect(payload.Kind).To(Equal("Iperf3"))
+		Expect(payload.Name).To(Equal("iperf3-sample"))
+		Expect(payload.Namespace).To(Equal("kubestone"))
+		Expect(payload.Timestamp).To(Equal(now))
+	})
+
+	It("should not contain results when the benchmark starts", func() {
+		payload := newPayload("Iperf3", &cr, perfv1alpha1.StartedEvent, now)
+		Expect(payload.Results).To(BeEmpty())
+		Expect(payload.Links).To(BeEmpty())
+	})
+
+	It("should contain the results and the BenchmarkResult when the benchmark finishes", func() {
+		payload := newPayload("Iperf3", &cr, perfv1alpha1.SucceededEvent, now)
+		Expect(payload.Results).To(Equal(cr.Status.Metrics))
+		Expect(payload.Comparisons).To(Equal(cr.Status.Comparisons))
+		Expect(payload.Links).To(Equal(map[string]string{
+			"benchmarkResult": "/apis/perf.kubestone.xridge.io/v1alpha1/namespaces/kubestone/" +
+				"benchmarkresults/iperf3-sample-0d6b1f3e",
+		}))
+	})
+
+	It("should link the archive when the output is uploaded", func() {
+		cr.Spec.Archive = &perfv1alpha1.ArchiveSpec{
+			Endpoint: "http://minio:9000",
+			Bucket:   "results",
+			Prefix:   "ci",
+		}
+		payload := newPayload("Iperf3", &cr, perfv1alpha1.FailedEvent, now)
+		Expect(payload.Links).To(HaveKeyWithValue("archive",
+			"http://minio:9000/results/"+archiveKeyPrefix(&cr, cr.Spec.Archive)))
+	})
+})
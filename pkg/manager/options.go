@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manager contains the helpers used to configure the
+// controller manager from its command line flags.
+package manager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SplitList splits a comma separated list, dropping the empty items
+func SplitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// SelectControllers returns the set of enabled controllers from the
+// comma separated selection. '*' enables all the known controllers,
+// 'name' enables the given controller and '-name' disables it. The
+// items are applied in order, so '*,-kafkabench' enables everything
+// but the kafkabench controller.
+func SelectControllers(selection string, known []string) (map[string]bool, error) {
+	enabled := map[string]bool{}
+	for _, item := range SplitList(selection) {
+		if item == "*" {
+			for _, name := range known {
+				enabled[name] = true
+			}
+			continue
+		}
+
+		name := strings.TrimPrefix(item, "-")
+		if !contains(known, name) {
+			return nil, fmt.Errorf("Unknown controller: %v", name)
+		}
+		enabled[name] = !strings.HasPrefix(item, "-")
+	}
+	return enabled, nil
+}
+
+// ParseMaxConcurrentReconciles parses the per controller concurrency
+// overrides given as comma separated name=count pairs
+func ParseMaxConcurrentReconciles(overrides string, known []string) (map[string]int, error) {
+	concurrency := map[string]int{}
+	for _, item := range SplitList(overrides) {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid concurrency override %q, expected name=count", item)
+		}
+
+		name := strings.TrimSpace(parts[0])
+		if !contains(known, name) {
+			return nil, fmt.Errorf("Unknown controller: %v", name)
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || count < 1 {
+			return nil, fmt.Errorf("Invalid concurrency for %v: %v", name, parts[1])
+		}
+		concurrency[name] = count
+	}
+	return concurrency, nil
+}
+
+// ParseControllerNamespaces parses the namespaces watched by the given
+// controllers from comma separated name=namespace pairs. A controller
+// watching several namespaces is listed once for each of them, e.g.
+// 'iperf3=net-a,iperf3=net-b'. The namespaces must be watched by the
+// manager, so they have to be in watchNamespaces unless that is empty.
+func ParseControllerNamespaces(list string, known, watchNamespaces []string) (map[string][]string, error) {
+	namespaces := map[string][]string{}
+	for _, item := range SplitList(list) {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("Invalid controller namespace %q, expected name=namespace", item)
+		}
+
+		name := strings.TrimSpace(parts[0])
+		if !contains(known, name) {
+			return nil, fmt.Errorf("Unknown controller: %v", name)
+		}
+		namespace := strings.TrimSpace(parts[1])
+		if len(watchNamespaces) > 0 && !contains(watchNamespaces, namespace) {
+			return nil, fmt.Errorf("Namespace %v of %v is not watched by the manager", namespace, name)
+		}
+		if !contains(namespaces[name], namespace) {
+			namespaces[name] = append(namespaces[name], namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+func contains(list []string, item string) bool {
+	for _, element := range list {
+		if element == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager options", func() {
+	known := []string{"fio", "iperf3", "ntttcp"}
+
+	Describe("SplitList", func() {
+		It("should drop the empty items", func() {
+			Expect(SplitList(" a, ,b,")).To(Equal([]string{"a", "b"}))
+		})
+	})
+
+	Describe("SelectControllers", func() {
+		It("should enable all controllers with *", func() {
+			enabled, err := SelectControllers("*", known)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(enabled).To(Equal(map[string]bool{
+				"fio": true, "iperf3": true, "ntttcp": true}))
+		})
+
+		It("should enable only the listed controllers", func() {
+			enabled, err := SelectControllers("iperf3,ntttcp", known)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(enabled["fio"]).To(BeFalse())
+			Expect(enabled["iperf3"]).To(BeTrue())
+			Expect(enabled["ntttcp"]).To(BeTrue())
+		})
+
+		It("should disable the controllers prefixed with -", func() {
+			enabled, err := SelectControllers("*,-fio", known)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(enabled["fio"]).To(BeFalse())
+			Expect(enabled["iperf3"]).To(BeTrue())
+		})
+
+		It("should reject unknown controllers", func() {
+			_, err := SelectControllers("*,-nosuch", known)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ParseMaxConcurrentReconciles", func() {
+		It("should parse the overrides", func() {
+			concurrency, err := ParseMaxConcurrentReconciles("fio=4, iperf3=2", known)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(concurrency).To(Equal(map[string]int{"fio": 4, "iperf3": 2}))
+		})
+
+		It("should reject malformed overrides", func() {
+			_, err := ParseMaxConcurrentReconciles("fio", known)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject non-positive counts", func() {
+			_, err := ParseMaxConcurrentReconciles("fio=0", known)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject unknown controllers", func() {
+			_, err := ParseMaxConcurrentReconciles("nosuch=2", known)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ParseControllerNamespaces", func() {
+		It("should collect the namespaces of each controller", func() {
+			namespaces, err := ParseControllerNamespaces("iperf3=net-a, iperf3=net-b,fio=storage", known, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(namespaces).To(Equal(map[string][]string{
+				"iperf3": {"net-a", "net-b"}, "fio": {"storage"}}))
+		})
+
+		It("should reject namespaces not watched by the manager", func() {
+			_, err := ParseControllerNamespaces("fio=storage", known, []string{"net-a"})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject malformed items and unknown controllers", func() {
+			_, err := ParseControllerNamespaces("fio", known, nil)
+			Expect(err).To(HaveOccurred())
+			_, err = ParseControllerNamespaces("nosuch=ns", known, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
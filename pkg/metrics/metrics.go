@@ -0,0 +1,72 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains the Prometheus metrics published by kubestone
+// on the metrics endpoint of the manager (--metrics-addr).
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Namespace is the prefix of every kubestone metric
+const Namespace = "kubestone"
+
+const (
+	// Succeeded is the outcome of the benchmarks whose jobs succeeded
+	Succeeded = "succeeded"
+	// Failed is the outcome of the benchmarks with failed jobs
+	Failed = "failed"
+)
+
+var (
+	// BenchmarkRuns counts the finished benchmarks by kind and outcome
+	BenchmarkRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "benchmark_runs_total",
+		Help:      "Number of finished benchmarks by kind and outcome.",
+	}, []string{"kind", "outcome"})
+
+	// BenchmarkDuration observes the run time of the benchmark jobs by kind
+	BenchmarkDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "benchmark_duration_seconds",
+		Help:      "Run time of the benchmark jobs by kind.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+	}, []string{"kind"})
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(BenchmarkRuns, BenchmarkDuration)
+}
+
+// RecordCompletion updates the run counters and the duration histogram
+// of the given benchmark kind. A zero duration is not observed.
+func RecordCompletion(kind string, duration time.Duration, succeeded bool) {
+	kind = strings.ToLower(kind)
+	outcome := Succeeded
+	if !succeeded {
+		outcome = Failed
+	}
+	BenchmarkRuns.WithLabelValues(kind, outcome).Inc()
+	if duration > 0 {
+		BenchmarkDuration.WithLabelValues(kind).Observe(duration.Seconds())
+	}
+}
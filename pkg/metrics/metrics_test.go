@@ -0,0 +1,61 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("RecordCompletion", func() {
+	It("should count the runs by lowercase kind and outcome", func() {
+		RecordCompletion("Fio", time.Minute, true)
+		RecordCompletion("Fio", time.Minute, false)
+		RecordCompletion("Fio", 0, false)
+
+		Expect(testutil.ToFloat64(BenchmarkRuns.WithLabelValues("fio", Succeeded))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(BenchmarkRuns.WithLabelValues("fio", Failed))).To(Equal(2.0))
+	})
+})
+
+var _ = Describe("ForgetResults", func() {
+	It("should delete the result series of the given benchmark only", func() {
+		RegisterResult("kubestone_test_forget_results", "Test result.", "direction")
+		results := []perfv1alpha1.BenchmarkMetric{
+			{Name: "kubestone_test_forget_results", Value: "42", Labels: map[string]string{"direction": "up"}},
+		}
+
+		PublishResults(&metav1.ObjectMeta{Namespace: "ns", Name: "deleted"}, results)
+		PublishResults(&metav1.ObjectMeta{Namespace: "ns", Name: "kept"}, results)
+		ForgetResults("ns", "deleted")
+
+		expected := `
+# HELP kubestone_test_forget_results Test result.
+# TYPE kubestone_test_forget_results gauge
+kubestone_test_forget_results{cr="kept",direction="up",namespace="ns"} 42
+`
+		Expect(testutil.CollectAndCompare(resultGauges["kubestone_test_forget_results"],
+			strings.NewReader(expected))).To(Succeed())
+	})
+})
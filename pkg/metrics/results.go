@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// resultGauges holds the gauges of the benchmark results by metric name
+var resultGauges = map[string]*prometheus.GaugeVec{}
+
+// publishedSeries is a gauge series set by PublishResults
+type publishedSeries struct {
+	gauge  *prometheus.GaugeVec
+	labels prometheus.Labels
+}
+
+var (
+	publishedMutex sync.Mutex
+	// published holds the series set by PublishResults by benchmark,
+	// so ForgetResults can delete them once the benchmark is deleted
+	published = map[string][]publishedSeries{}
+)
+
+// RegisterResult registers a gauge for the given benchmark result, which
+// is published by PublishResults. The gauge is labelled with the name and
+// the namespace of the benchmark (cr, namespace) and the given labels.
+// It should be called from the init function of the benchmark package.
+func RegisterResult(name, help string, labels ...string) {
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: name,
+		Help: help,
+	}, append([]string{"cr", "namespace"}, labels...))
+	crmetrics.Registry.MustRegister(gauge)
+	resultGauges[name] = gauge
+}
+
+// PublishResults sets the result gauges from the metrics of the given
+// benchmark. Metrics without registered gauge are skipped.
+// The series are kept until ForgetResults is called for the benchmark.
+func PublishResults(cr metav1.Object, results []perfv1alpha1.BenchmarkMetric) {
+	publishedMutex.Lock()
+	defer publishedMutex.Unlock()
+
+	key := benchmarkKey(cr.GetNamespace(), cr.GetName())
+	for _, result := range results {
+		gauge, ok := resultGauges[result.Name]
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(result.Value, 64)
+		if err != nil {
+			continue
+		}
+
+		labels := prometheus.Labels{"cr": cr.GetName(), "namespace": cr.GetNamespace()}
+		for name, labelValue := range result.Labels {
+			labels[name] = labelValue
+		}
+		if series, err := gauge.GetMetricWith(labels); err == nil {
+			series.Set(value)
+			published[key] = append(published[key], publishedSeries{gauge: gauge, labels: labels})
+		}
+	}
+}
+
+// ForgetResults deletes the result gauge series published for the
+// benchmark with the given namespace and name. It should be called
+// once the benchmark is deleted, so the series of benchmarks which
+// no longer exist are not exported.
+func ForgetResults(namespace, name string) {
+	publishedMutex.Lock()
+	defer publishedMutex.Unlock()
+
+	key := benchmarkKey(namespace, name)
+	for _, series := range published[key] {
+		series.gauge.Delete(series.labels)
+	}
+	delete(published, key)
+}
+
+func benchmarkKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// FormatValue formats the given value for BenchmarkMetric.Value
+func FormatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
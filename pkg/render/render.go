@@ -0,0 +1,269 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render builds the Kubernetes objects of a benchmark without a
+// cluster, using the same builders as the controllers.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/yaml"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/controllers/drill"
+	"github.com/xridge/kubestone/controllers/ethr"
+	"github.com/xridge/kubestone/controllers/fio"
+	"github.com/xridge/kubestone/controllers/ioping"
+	"github.com/xridge/kubestone/controllers/iperf2"
+	"github.com/xridge/kubestone/controllers/iperf3"
+	"github.com/xridge/kubestone/controllers/kafkabench"
+	"github.com/xridge/kubestone/controllers/ntttcp"
+	"github.com/xridge/kubestone/controllers/ocplogtest"
+	"github.com/xridge/kubestone/controllers/pgbench"
+	"github.com/xridge/kubestone/controllers/ping"
+	"github.com/xridge/kubestone/controllers/qperf"
+	"github.com/xridge/kubestone/controllers/s3bench"
+	"github.com/xridge/kubestone/controllers/sysbench"
+	"github.com/xridge/kubestone/controllers/ycsbbench"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// Benchmark is a benchmark read by ReadBenchmarks
+type Benchmark struct {
+	perfv1alpha1.Benchmark
+	// SpecFields are the fields set in the spec of the document, nil for
+	// the benchmarks converted from an other version (see k8s.SpecFields)
+	SpecFields map[string]interface{}
+}
+
+// ReadBenchmarks reads the benchmarks and the BenchmarkProfiles from YAML
+// or JSON documents. YAML documents are separated by ---. Unknown fields
+// of the benchmarks are rejected, so that typos do not go unnoticed. The
+// benchmarks of the other versions registered in the scheme are converted
+// to v1alpha1.
+func ReadBenchmarks(s *runtime.Scheme, r io.Reader) ([]Benchmark,
+	[]perfv1alpha1.BenchmarkProfile, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	benchmarks := []Benchmark{}
+	profiles := []perfv1alpha1.BenchmarkProfile{}
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err == io.EOF {
+			return benchmarks, profiles, nil
+		} else if err != nil {
+			return nil, nil, err
+		}
+		raw.Raw = bytes.TrimSpace(raw.Raw)
+		if len(raw.Raw) == 0 || bytes.Equal(raw.Raw, []byte("null")) {
+			continue
+		}
+
+		var typeMeta struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		}
+		if err := yaml.Unmarshal(raw.Raw, &typeMeta); err != nil {
+			return nil, nil, err
+		}
+		gv, err := schema.ParseGroupVersion(typeMeta.APIVersion)
+		if err != nil || gv.Group != perfv1alpha1.GroupVersion.Group {
+			return nil, nil, fmt.Errorf("%v %v is not a benchmark", typeMeta.APIVersion, typeMeta.Kind)
+		}
+		object, err := s.New(gv.WithKind(typeMeta.Kind))
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := yaml.UnmarshalStrict(raw.Raw, object); err != nil {
+			return nil, nil, fmt.Errorf("Invalid %v: %v", typeMeta.Kind, err)
+		}
+		var fields map[string]interface{}
+		if err := yaml.Unmarshal(raw.Raw, &fields); err != nil {
+			return nil, nil, err
+		}
+		specFields := k8s.SpecFields(fields)
+		if convertible, ok := object.(conversion.Convertible); ok {
+			hub, err := s.New(perfv1alpha1.GroupVersion.WithKind(typeMeta.Kind))
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := convertible.ConvertTo(hub.(conversion.Hub)); err != nil {
+				return nil, nil, fmt.Errorf("Invalid %v: %v", typeMeta.Kind, err)
+			}
+			hub.GetObjectKind().SetGroupVersionKind(perfv1alpha1.GroupVersion.WithKind(typeMeta.Kind))
+			object = hub
+			specFields = nil
+		}
+		switch object := object.(type) {
+		case perfv1alpha1.Benchmark:
+			benchmarks = append(benchmarks, Benchmark{Benchmark: object, SpecFields: specFields})
+		case *perfv1alpha1.BenchmarkProfile:
+			profiles = append(profiles, *object)
+		default:
+			return nil, nil, fmt.Errorf("%v is not a benchmark", typeMeta.Kind)
+		}
+	}
+}
+
+// ApplyProfile merges the profile referenced by the benchmark, which must
+// be one of the given profiles, into the spec of the benchmark
+func ApplyProfile(s *runtime.Scheme, cr Benchmark, profiles []perfv1alpha1.BenchmarkProfile) error {
+	name := cr.GetRunPolicy().Profile
+	if name == "" {
+		return nil
+	}
+	for i := range profiles {
+		if profiles[i].Name == name {
+			if err := k8s.MergeProfile(cr.Benchmark, cr.SpecFields, &profiles[i], s); err != nil {
+				return fmt.Errorf("Applying profile %v failed: %v", name, err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("Profile %v not found, pass its file with -f", name)
+}
+
+// ResolveVariables resolves the templates in the spec of the benchmark.
+// The given variables take the place of the data of the ConfigMap
+// referenced by the benchmark.
+func ResolveVariables(cr perfv1alpha1.Benchmark, vars map[string]string) error {
+	_, err := k8s.ResolveSpec(cr, k8s.NewTemplateData(cr, k8s.Variables(cr, vars)))
+	return err
+}
+
+// Objects returns the objects the controller creates for the benchmark,
+// in the order of their creation, using the NewObjects function of the
+// controller which builds the objects of the dry-run as well. The network
+// benchmarks reach their server via the name of its service instead of
+// the endpoint address the controller looks up.
+func Objects(cr perfv1alpha1.Benchmark) ([]runtime.Object, error) {
+	objects, err := benchmarkObjects(cr)
+	if err != nil {
+		return nil, err
+	}
+	for _, object := range objects {
+		if object, ok := object.(metav1.Object); ok {
+			k8s.AddDownwardEnv(object)
+		}
+	}
+	return objects, nil
+}
+
+func benchmarkObjects(cr perfv1alpha1.Benchmark) ([]runtime.Object, error) {
+	if cr.GetName() == "" {
+		return nil, fmt.Errorf("The name of the %T is not set", cr)
+	}
+	cr = cr.DeepCopyObject().(perfv1alpha1.Benchmark)
+
+	switch cr := cr.(type) {
+	case *perfv1alpha1.Drill:
+		if valid, err := drill.IsCrValid(cr); !valid {
+			return nil, err
+		}
+		return runtimeObjects(drill.NewObjects(cr))
+	case *perfv1alpha1.Ethr:
+		return runtimeObjects(ethr.NewObjects(cr))
+	case *perfv1alpha1.Fio:
+		if valid, err := fio.IsCrValid(cr); !valid {
+			return nil, err
+		}
+		return runtimeObjects(fio.NewObjects(cr))
+	case *perfv1alpha1.Ioping:
+		if valid, err := ioping.IsCrValid(cr); !valid {
+			return nil, err
+		}
+		return runtimeObjects(ioping.NewObjects(cr))
+	case *perfv1alpha1.Iperf2:
+		return runtimeObjects(iperf2.NewObjects(cr))
+	case *perfv1alpha1.Iperf3:
+		return runtimeObjects(iperf3.NewObjects(cr))
+	case *perfv1alpha1.KafkaBench:
+		return runtimeObjects(kafkabench.NewObjects(cr))
+	case *perfv1alpha1.Ntttcp:
+		return runtimeObjects(ntttcp.NewObjects(cr))
+	case *perfv1alpha1.OcpLogtest:
+		return runtimeObjects(ocplogtest.NewObjects(cr))
+	case *perfv1alpha1.Pgbench:
+		if valid, err := pgbench.IsCrValid(cr); !valid {
+			return nil, err
+		}
+		return runtimeObjects(pgbench.NewObjects(cr))
+	case *perfv1alpha1.Ping:
+		return runtimeObjects(ping.NewObjects(cr))
+	case *perfv1alpha1.Qperf:
+		return runtimeObjects(qperf.NewObjects(cr))
+	case *perfv1alpha1.S3Bench:
+		if valid, err := s3bench.IsCrValid(cr); !valid {
+			return nil, err
+		}
+		return runtimeObjects(s3bench.NewObjects(cr))
+	case *perfv1alpha1.Sysbench:
+		return runtimeObjects(sysbench.NewObjects(cr))
+	case *perfv1alpha1.YcsbBench:
+		if valid, err := ycsbbench.IsCrValid(cr); !valid {
+			return nil, err
+		}
+		return runtimeObjects(ycsbbench.NewObjects(cr))
+	default:
+		return nil, fmt.Errorf("Rendering %T is not supported", cr)
+	}
+}
+
+// runtimeObjects converts the objects returned by the NewObjects function
+// of the controllers
+func runtimeObjects(objects []metav1.Object, err error) ([]runtime.Object, error) {
+	if err != nil {
+		return nil, err
+	}
+	converted := make([]runtime.Object, 0, len(objects))
+	for _, object := range objects {
+		converted = append(converted, object.(runtime.Object))
+	}
+	return converted, nil
+}
+
+// WriteYAML writes the objects as YAML documents separated by ---. The
+// apiVersion and the kind of the objects are set from the scheme.
+func WriteYAML(w io.Writer, s *runtime.Scheme, objects []runtime.Object) error {
+	for i, object := range objects {
+		gvk, err := apiutil.GVKForObject(object, s)
+		if err != nil {
+			return err
+		}
+		object.GetObjectKind().SetGroupVersionKind(gvk)
+		data, err := yaml.Marshal(object)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
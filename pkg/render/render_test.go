@@ -0,0 +1,201 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	perfv1beta1 "github.com/xridge/kubestone/api/v1beta1"
+)
+
+const benchmarks = `
+apiVersion: perf.kubestone.xridge.io/v1alpha1
+kind: Fio
+metadata:
+  name: fio-sample
+  namespace: kubestone
+spec:
+  image:
+    name: xridge/fio:3.13
+  cmdLineArgs: --name=randread --rw=randread
+  volume:
+    volumeSource:
+      persistentVolumeClaim:
+        claimName: GENERATED
+    persistentVolumeClaimSpec:
+      accessModes: [ReadWriteOnce]
+      resources:
+        requests:
+          storage: 1Gi
+---
+apiVersion: perf.kubestone.xridge.io/v1alpha1
+kind: Iperf3
+metadata:
+  name: iperf3-sample
+  namespace: kubestone
+spec:
+  image:
+    name: xridge/iperf3:3.7.0
+`
+
+var _ = Describe("Render", func() {
+	var s *runtime.Scheme
+
+	BeforeEach(func() {
+		s = runtime.NewScheme()
+		Expect(k8sscheme.AddToScheme(s)).To(Succeed())
+		Expect(perfv1alpha1.AddToScheme(s)).To(Succeed())
+		Expect(perfv1beta1.AddToScheme(s)).To(Succeed())
+	})
+
+	Describe("ReadBenchmarks", func() {
+		It("should read every document", func() {
+			crs, profiles, err := ReadBenchmarks(s, strings.NewReader(benchmarks))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profiles).To(BeEmpty())
+			Expect(crs).To(HaveLen(2))
+			Expect(crs[0].Benchmark).To(BeAssignableToTypeOf(&perfv1alpha1.Fio{}))
+			Expect(crs[1].GetName()).To(Equal("iperf3-sample"))
+		})
+
+		It("should convert the v1beta1 benchmarks", func() {
+			crs, _, err := ReadBenchmarks(s, strings.NewReader(
+				"apiVersion: perf.kubestone.xridge.io/v1beta1\nkind: Fio\nmetadata:\n  name: fio\n"+
+					"spec:\n  args: --rw=read\n  iterations:\n    count: 3\n"))
+			Expect(err).NotTo(HaveOccurred())
+			fio := crs[0].Benchmark.(*perfv1alpha1.Fio)
+			Expect(fio.APIVersion).To(Equal("perf.kubestone.xridge.io/v1alpha1"))
+			Expect(fio.Name).To(Equal("fio"))
+			Expect(fio.Spec.CmdLineArgs).To(Equal("--rw=read"))
+			Expect(fio.Spec.Iterations).To(Equal(int32(3)))
+		})
+
+		It("should reject unknown fields and other kinds", func() {
+			_, _, err := ReadBenchmarks(s, strings.NewReader(
+				"apiVersion: perf.kubestone.xridge.io/v1alpha1\nkind: Fio\nspec:\n  cmdLineArg: --rw=read\n"))
+			Expect(err).To(HaveOccurred())
+			_, _, err = ReadBenchmarks(s, strings.NewReader("apiVersion: v1\nkind: Pod\n"))
+			Expect(err).To(MatchError("v1 Pod is not a benchmark"))
+		})
+	})
+
+	Describe("Objects", func() {
+		var crs []Benchmark
+
+		BeforeEach(func() {
+			var err error
+			crs, _, err = ReadBenchmarks(s, strings.NewReader(benchmarks))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should create the generated volume of fio", func() {
+			objects, err := Objects(crs[0].Benchmark)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objects).To(HaveLen(3))
+			Expect(objects[0]).To(BeAssignableToTypeOf(&corev1.ConfigMap{}))
+			Expect(objects[1].(*corev1.PersistentVolumeClaim).Name).To(Equal("fio-sample"))
+
+			job := objects[2].(*batchv1.Job)
+			Expect(job.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+				Name: "data",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "fio-sample"}},
+			}))
+			// The benchmark itself is not modified
+			Expect(crs[0].Benchmark.(*perfv1alpha1.Fio).Spec.Volume.VolumeSource.PersistentVolumeClaim.ClaimName).To(
+				Equal(perfv1alpha1.GeneratedPVC))
+		})
+
+		It("should target the server service of iperf3", func() {
+			objects, err := Objects(crs[1].Benchmark)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objects).To(HaveLen(3))
+			job := objects[2].(*batchv1.Job)
+			Expect(job.Spec.Template.Spec.Containers[0].Args).To(
+				ContainElement(objects[1].(*corev1.Service).Name))
+		})
+
+		It("should resolve the templates", func() {
+			fio := crs[0].Benchmark.(*perfv1alpha1.Fio)
+			fio.Spec.CmdLineArgs = "--name={{ .Vars.job }} --filename={{ .Node.Name }}"
+			Expect(ResolveVariables(fio, map[string]string{"job": "randread"})).To(Succeed())
+			objects, err := Objects(fio)
+			Expect(err).NotTo(HaveOccurred())
+			container := objects[2].(*batchv1.Job).Spec.Template.Spec.Containers[0]
+			Expect(container.Args).To(ContainElement("--name=randread"))
+			Expect(container.Env[0].Name).To(Equal("KUBESTONE_NODE_NAME"))
+
+			Expect(ResolveVariables(fio, map[string]string{})).To(Succeed())
+			fio.Spec.CmdLineArgs = "--name={{ .Vars.job }}"
+			Expect(ResolveVariables(fio, map[string]string{})).NotTo(Succeed())
+		})
+
+		It("should require the name of the benchmark", func() {
+			crs[1].SetName("")
+			_, err := Objects(crs[1].Benchmark)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ApplyProfile", func() {
+		profile := "apiVersion: perf.kubestone.xridge.io/v1alpha1\nkind: BenchmarkProfile\n" +
+			"metadata:\n  name: iperf3-host\nspec:\n  kind: Iperf3\n  version: \"1\"\n" +
+			"  spec:\n    image:\n      name: xridge/iperf3:3.7.0\n" +
+			"    clientConfiguration:\n      hostNetwork: true\n"
+
+		It("should override the profile with the fields set to false", func() {
+			crs, profiles, err := ReadBenchmarks(s, strings.NewReader(profile+
+				"---\napiVersion: perf.kubestone.xridge.io/v1alpha1\nkind: Iperf3\nmetadata:\n  name: iperf3\n"+
+				"spec:\n  profile: iperf3-host\n  clientConfiguration:\n    hostNetwork: false\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ApplyProfile(s, crs[0], profiles)).To(Succeed())
+			iperf3 := crs[0].Benchmark.(*perfv1alpha1.Iperf3)
+			Expect(iperf3.Spec.Image.Name).To(Equal("xridge/iperf3:3.7.0"))
+			Expect(iperf3.Spec.ClientConfiguration.HostNetwork).To(BeFalse())
+		})
+
+		It("should treat the zero values of the converted benchmarks as unset", func() {
+			crs, profiles, err := ReadBenchmarks(s, strings.NewReader(profile+
+				"---\napiVersion: perf.kubestone.xridge.io/v1beta1\nkind: Iperf3\nmetadata:\n  name: iperf3\n"+
+				"spec:\n  profile: iperf3-host\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(crs[0].SpecFields).To(BeNil())
+			Expect(ApplyProfile(s, crs[0], profiles)).To(Succeed())
+			iperf3 := crs[0].Benchmark.(*perfv1alpha1.Iperf3)
+			Expect(iperf3.Spec.Image.Name).To(Equal("xridge/iperf3:3.7.0"))
+			Expect(iperf3.Spec.ClientConfiguration.HostNetwork).To(BeTrue())
+		})
+	})
+
+	Describe("WriteYAML", func() {
+		It("should write the documents with their kinds", func() {
+			var out bytes.Buffer
+			Expect(WriteYAML(&out, s, []runtime.Object{&corev1.ConfigMap{}, &batchv1.Job{}})).To(Succeed())
+			Expect(out.String()).To(MatchRegexp("(?s)^apiVersion: v1\nkind: ConfigMap\n.*---\napiVersion: batch/v1\nkind: Job\n"))
+		})
+	})
+})
@@ -0,0 +1,227 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/stats"
+)
+
+// Significance tells if the difference of a value from the baseline is
+// larger than the noise of the iterations
+type Significance string
+
+const (
+	// Significant means that the means of the iterations differ at the
+	// 95% confidence level
+	Significant Significance = "Significant"
+	// NotSignificant means that the difference is within the noise
+	NotSignificant Significance = "NotSignificant"
+	// Unknown means that the significance cannot be determined, because
+	// either run has less than two iterations
+	Unknown Significance = "Unknown"
+)
+
+// Comparison compares the metrics of runs of the same benchmark kind to
+// the first run, the baseline
+type Comparison struct {
+	Kind     string           `json:"kind"`
+	Baseline string           `json:"baseline"`
+	Runs     []string         `json:"runs"`
+	Metrics  []ComparedMetric `json:"metrics"`
+}
+
+// ComparedMetric is a metric (given by its name and labels) in the runs
+type ComparedMetric struct {
+	Metric string          `json:"metric"`
+	Labels string          `json:"labels,omitempty"`
+	Values []ComparedValue `json:"values"`
+}
+
+// ComparedValue is the value of a metric in a run. The value is missing
+// when the run has not measured the metric.
+type ComparedValue struct {
+	Run   string   `json:"run"`
+	Value *float64 `json:"value,omitempty"`
+	// StdDev and Iterations describe the spread of the iterations
+	StdDev     *float64 `json:"stdDev,omitempty"`
+	Iterations int      `json:"iterations,omitempty"`
+	// Delta and Significance are not set for the baseline
+	Delta        *Delta       `json:"delta,omitempty"`
+	Significance Significance `json:"significance,omitempty"`
+}
+
+// Compare aligns the metrics of the results, which must be of the same
+// benchmark kind. The first result is the baseline, the deltas of the
+// others are computed against it. The significance of the deltas is
+// determined from the iterations of the runs.
+func Compare(results []perfv1alpha1.BenchmarkResult) (*Comparison, error) {
+	if len(results) < 2 {
+		return nil, fmt.Errorf("At least two results are needed for a comparison")
+	}
+	baseline := &results[0]
+	comparison := &Comparison{Kind: baseline.Spec.Benchmark.Kind, Baseline: baseline.Name}
+	for i := range results {
+		if results[i].Spec.Benchmark.Kind != comparison.Kind {
+			return nil, fmt.Errorf("%v is a %v result, but %v is a %v result", results[i].Name,
+				results[i].Spec.Benchmark.Kind, baseline.Name, comparison.Kind)
+		}
+		comparison.Runs = append(comparison.Runs, results[i].Name)
+	}
+
+	metrics := map[string]*ComparedMetric{}
+	var keys []string
+	for i := range results {
+		for _, metric := range results[i].Spec.Metrics {
+			key := metricKey(&metric)
+			if _, ok := metrics[key]; !ok {
+				metrics[key] = &ComparedMetric{Metric: metric.Name, Labels: formatLabels(metric.Labels)}
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		metric := metrics[key]
+		baselineValue, baselineSummary := comparedValue(baseline, key)
+		metric.Values = append(metric.Values, baselineValue)
+		for i := range results[1:] {
+			value, summary := comparedValue(&results[i+1], key)
+			if value.Value != nil && baselineValue.Value != nil {
+				value.Delta = newDelta(*value.Value, *baselineValue.Value)
+				value.Significance = Unknown
+				if significant, ok := stats.Significant(baselineSummary, summary); ok {
+					value.Significance = NotSignificant
+					if significant {
+						value.Significance = Significant
+					}
+				}
+			}
+			metric.Values = append(metric.Values, value)
+		}
+		comparison.Metrics = append(comparison.Metrics, *metric)
+	}
+	return comparison, nil
+}
+
+// comparedValue returns the value of the metric in the result and the
+// summary of its iterations
+func comparedValue(result *perfv1alpha1.BenchmarkResult, key string) (ComparedValue, stats.Summary) {
+	compared := ComparedValue{Run: result.Name}
+	if value, ok := metricValue(result, key); ok {
+		compared.Value = &value
+	}
+
+	var summary stats.Summary
+	for _, aggregate := range result.Spec.Aggregates {
+		if aggregate.Name+"{"+formatLabels(aggregate.Labels)+"}" != key {
+			continue
+		}
+		values := make([]float64, 0, len(aggregate.Values))
+		for _, value := range aggregate.Values {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				values = append(values, parsed)
+			}
+		}
+		summary = stats.Summarize(values)
+		if summary.N > 1 {
+			compared.StdDev = &summary.StdDev
+			compared.Iterations = summary.N
+		}
+	}
+	return compared, summary
+}
+
+// WriteText writes the comparison as a table aligned with spaces
+func (c *Comparison) WriteText(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(table, "%v results compared to %v\n\n", c.Kind, c.Baseline)
+	fmt.Fprintln(table, "METRIC\tLABELS\tRUN\tVALUE\tDELTA\tSIGNIFICANCE")
+	for _, metric := range c.Metrics {
+		for i, value := range metric.Values {
+			fmt.Fprintf(table, "%v\t%v\t%v\t%v\t%v\t%v\n", metric.Metric, metric.Labels, value.Run,
+				formatComparedValue(&value), formatComparedDelta(&value, i == 0),
+				significanceHint(value.Significance))
+		}
+	}
+	return table.Flush()
+}
+
+// WriteMarkdown writes the comparison as a Markdown table, e.g. for
+// pasting it into a review
+func (c *Comparison) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%v** results compared to `%v`\n\n", c.Kind, c.Baseline)
+	b.WriteString("| Metric | Labels | Run | Value | Delta | Significance |\n")
+	b.WriteString("|--------|--------|-----|------:|------:|--------------|\n")
+	for _, metric := range c.Metrics {
+		for i, value := range metric.Values {
+			fmt.Fprintf(&b, "| %v | %v | `%v` | %v | %v | %v |\n", markdownEscape(metric.Metric),
+				markdownEscape(metric.Labels), value.Run, formatComparedValue(&value),
+				formatComparedDelta(&value, i == 0), significanceHint(value.Significance))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func formatComparedValue(value *ComparedValue) string {
+	if value.Value == nil {
+		return "-"
+	}
+	text := strconv.FormatFloat(*value.Value, 'g', -1, 64)
+	if value.StdDev != nil {
+		text += fmt.Sprintf(" ± %.3g (n=%v)", *value.StdDev, value.Iterations)
+	}
+	return text
+}
+
+func formatComparedDelta(value *ComparedValue, baseline bool) string {
+	switch {
+	case baseline:
+		return "baseline"
+	case value.Delta == nil:
+		return "-"
+	default:
+		return formatDelta(value.Delta)
+	}
+}
+
+func significanceHint(significance Significance) string {
+	switch significance {
+	case Significant:
+		return "significant"
+	case NotSignificant:
+		return "within noise"
+	case Unknown:
+		return "unknown, needs iterations"
+	default:
+		return ""
+	}
+}
+
+func markdownEscape(text string) string {
+	return strings.Replace(text, "|", "\\|", -1)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("Compare", func() {
+	day := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	withIterations := func(result perfv1alpha1.BenchmarkResult, values ...string) perfv1alpha1.BenchmarkResult {
+		result.Spec.Aggregates = []perfv1alpha1.MetricAggregate{{
+			Name: "kubestone_fio_iops", Labels: result.Spec.Metrics[0].Labels, Values: values,
+		}}
+		return result
+	}
+
+	It("should compute the deltas and their significance", func() {
+		comparison, err := Compare([]perfv1alpha1.BenchmarkResult{
+			withIterations(newResult("base", "Fio", "slow", day, "1000"), "990", "1000", "1010"),
+			withIterations(newResult("faster", "Fio", "fast", day, "1200"), "1190", "1200", "1210"),
+			withIterations(newResult("noisy", "Fio", "fast", day, "1010"), "800", "1010", "1220"),
+			newResult("single", "Fio", "fast", day, "1100"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comparison.Baseline).To(Equal("base"))
+		Expect(comparison.Runs).To(Equal([]string{"base", "faster", "noisy", "single"}))
+		Expect(comparison.Metrics).To(HaveLen(1))
+
+		values := comparison.Metrics[0].Values
+		Expect(values[0].Delta).To(BeNil())
+		Expect(*values[0].StdDev).To(BeNumerically("~", 10, 1e-9))
+		Expect(values[0].Iterations).To(Equal(3))
+		Expect(values[1].Delta.Absolute).To(Equal(200.0))
+		Expect(*values[1].Delta.Percent).To(BeNumerically("~", 20, 1e-9))
+		Expect(values[1].Significance).To(Equal(Significant))
+		Expect(values[2].Significance).To(Equal(NotSignificant))
+		Expect(values[3].Significance).To(Equal(Unknown))
+	})
+
+	It("should show the metrics missing from a run", func() {
+		other := newResult("other", "Fio", "fast", day, "1200")
+		other.Spec.Metrics[0].Labels = map[string]string{"rw": "write"}
+		comparison, err := Compare([]perfv1alpha1.BenchmarkResult{
+			newResult("base", "Fio", "slow", day, "1000"), other,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comparison.Metrics).To(HaveLen(2))
+		Expect(comparison.Metrics[0].Values[1].Value).To(BeNil())
+		Expect(comparison.Metrics[0].Values[1].Delta).To(BeNil())
+	})
+
+	It("should only compare results of the same kind", func() {
+		_, err := Compare([]perfv1alpha1.BenchmarkResult{
+			newResult("base", "Fio", "slow", day, "1000"), newResult("other", "Ioping", "slow", day, "1000"),
+		})
+		Expect(err).To(MatchError("other is a Ioping result, but base is a Fio result"))
+		_, err = Compare([]perfv1alpha1.BenchmarkResult{newResult("base", "Fio", "slow", day, "1000")})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should write text, markdown and JSON", func() {
+		comparison, err := Compare([]perfv1alpha1.BenchmarkResult{
+			newResult("base", "Fio", "slow", day, "1000"), newResult("other", "Fio", "fast", day, "900"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var text bytes.Buffer
+		Expect(comparison.WriteText(&text)).To(Succeed())
+		Expect(text.String()).To(ContainSubstring("other  900    -100 (-10.0%)  unknown, needs iterations"))
+
+		var markdown bytes.Buffer
+		Expect(comparison.WriteMarkdown(&markdown)).To(Succeed())
+		Expect(markdown.String()).To(ContainSubstring(
+			"| kubestone_fio_iops | job=randread, rw=read | `base` | 1000 | baseline |  |\n"))
+
+		encoded, err := json.Marshal(comparison)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(encoded)).To(ContainSubstring(
+			`{"run":"other","value":900,"delta":{"absolute":-100,"percent":-10},"significance":"Unknown"}`))
+	})
+})
@@ -0,0 +1,150 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// WriteHTML renders the report as a self-contained HTML page. The page
+// has no external dependencies: the charts are inline SVG.
+func (r *Report) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, r)
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"value": formatValue,
+	"time":  formatTime,
+	"delta": formatDelta,
+	"trend": trend,
+	"barWidth": func(scale float64) string {
+		return strconv.FormatFloat(barWidth(scale), 'f', 1, 64)
+	},
+	"valueX": func(scale float64) string {
+		return strconv.FormatFloat(labelWidth+barWidth(scale)+6, 'f', 1, 64)
+	},
+	"labelWidth": func() int {
+		return labelWidth
+	},
+	"svgWidth": func() int {
+		return labelWidth + chartWidth + 120
+	},
+	"barY": func(i int) int {
+		return i * barHeight
+	},
+	"chartHeight": func(rows []Row) int {
+		return len(rows) * barHeight
+	},
+}).Parse(htmlSource))
+
+// Sizes of the bar charts in pixels
+const (
+	labelWidth = 360
+	chartWidth = 480
+	barHeight  = 22
+)
+
+// barWidth returns the length of the bar of a value relative to the
+// largest value of the chart. Zero values are shown as a thin line.
+func barWidth(scale float64) float64 {
+	return math.Max(scale*chartWidth, 1)
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', 6, 64)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatDelta(delta *Delta) string {
+	if delta == nil {
+		return ""
+	}
+	text := fmt.Sprintf("%+g", delta.Absolute)
+	if delta.Percent != nil {
+		text += fmt.Sprintf(" (%+.1f%%)", *delta.Percent)
+	}
+	return text
+}
+
+// trend returns the CSS class of the delta
+func trend(delta *Delta) string {
+	switch {
+	case delta == nil || delta.Absolute == 0:
+		return ""
+	case delta.Absolute > 0:
+		return "up"
+	default:
+		return "down"
+	}
+}
+
+const htmlSource = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin: 0.5em 0 1.5em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+th { background: #f0f0f0; }
+td.number { text-align: right; font-family: monospace; }
+tr.baseline { font-weight: bold; background: #fff8dc; }
+.up { color: #1a7f37; }
+.down { color: #c62828; }
+.labels { color: #666; font-weight: normal; }
+svg text { font-size: 12px; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Generated at {{time .Generated}}.{{if .Baseline}} Deltas are relative to the baseline run <b>{{.Baseline}}</b>.{{end}}</p>
+{{range .Kinds}}
+<h2>{{.Kind}}</h2>
+<table>
+<tr><th>Run</th><th>Benchmark</th><th>Group</th><th>Outcome</th><th>Completed</th></tr>
+{{range .Runs}}<tr{{if .Baseline}} class="baseline"{{end}}><td>{{.Name}}</td><td>{{.Benchmark}}</td><td>{{.Group}}</td><td>{{.Outcome}}</td><td>{{time .CompletionTime}}</td></tr>
+{{end}}</table>
+{{range .Metrics}}
+<h3>{{.Metric}} <span class="labels">{{.Labels}}</span></h3>
+<svg xmlns="http://www.w3.org/2000/svg" width="{{svgWidth}}" height="{{chartHeight .Rows}}">
+{{range $i, $row := .Rows}}<g transform="translate(0,{{barY $i}})">
+<text x="0" y="15">{{$row.Name}}{{if $row.Group}} ({{$row.Group}}){{end}}</text>
+<rect x="{{labelWidth}}" y="3" width="{{barWidth $row.Scale}}" height="16" fill="{{if $row.Baseline}}#f0a830{{else}}#4a78c2{{end}}"></rect>
+<text x="{{valueX $row.Scale}}" y="15">{{value $row.Value}}</text>
+</g>
+{{end}}</svg>
+<table>
+<tr><th>Run</th><th>Group</th><th>Value</th>{{if $.Baseline}}<th>Delta</th>{{end}}</tr>
+{{range .Rows}}<tr{{if .Baseline}} class="baseline"{{end}}><td>{{.Name}}</td><td>{{.Group}}</td><td class="number">{{value .Value}}</td>{{if $.Baseline}}<td class="number {{trend .Delta}}">{{delta .Delta}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}
+{{end}}
+</body>
+</html>
+`
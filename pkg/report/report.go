@@ -0,0 +1,325 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// DefaultGroupBy are the dimensions grouping the runs by default
+const DefaultGroupBy = "storageClass,nodes"
+
+// Dimension extracts a parameter of a run, which is used to group the runs
+type Dimension func(result *perfv1alpha1.BenchmarkResult) string
+
+// ParseDimensions parses the comma separated list of dimensions:
+//   - storageClass: the storage classes of the persistent volumes
+//   - nodes: the nodes of the benchmark pods
+//   - label:<key>: the given label of the result
+//   - spec:<path>: the given field of the benchmark spec, e.g.
+//     spec:serverConfiguration.podScheduling.nodeName
+func ParseDimensions(list string) ([]Dimension, error) {
+	dimensions := []Dimension{}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+			continue
+		case name == "storageClass":
+			dimensions = append(dimensions, storageClasses)
+		case name == "nodes":
+			dimensions = append(dimensions, func(result *perfv1alpha1.BenchmarkResult) string {
+				return strings.Join(result.Spec.Environment.NodeNames, ",")
+			})
+		case strings.HasPrefix(name, "label:"):
+			key := strings.TrimPrefix(name, "label:")
+			dimensions = append(dimensions, func(result *perfv1alpha1.BenchmarkResult) string {
+				return result.Labels[key]
+			})
+		case strings.HasPrefix(name, "spec:"):
+			path := strings.Split(strings.TrimPrefix(name, "spec:"), ".")
+			dimensions = append(dimensions, func(result *perfv1alpha1.BenchmarkResult) string {
+				return specField(result, path)
+			})
+		default:
+			return nil, fmt.Errorf("Unknown dimension: %v", name)
+		}
+	}
+	return dimensions, nil
+}
+
+func storageClasses(result *perfv1alpha1.BenchmarkResult) string {
+	classes := []string{}
+	for _, volume := range result.Spec.Environment.Volumes {
+		if volume.StorageClassName != "" && !contains(classes, volume.StorageClassName) {
+			classes = append(classes, volume.StorageClassName)
+		}
+	}
+	sort.Strings(classes)
+	return strings.Join(classes, ",")
+}
+
+// specField returns the field of the benchmark spec at the given path
+func specField(result *perfv1alpha1.BenchmarkResult, path []string) string {
+	var value interface{}
+	if err := json.Unmarshal(result.Spec.BenchmarkSpec.Raw, &value); err != nil {
+		return ""
+	}
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = object[key]
+	}
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	}
+}
+
+// Report compares the runs of the benchmarks
+type Report struct {
+	Title     string
+	Generated time.Time
+	// Baseline is the name of the BenchmarkResult the other runs are
+	// compared to
+	Baseline string
+	Kinds    []Section
+}
+
+// Section holds the runs of a benchmark kind
+type Section struct {
+	Kind    string
+	Runs    []Run
+	Metrics []Table
+}
+
+// Run is a benchmark run
+type Run struct {
+	Name           string
+	Benchmark      string
+	Group          string
+	Outcome        perfv1alpha1.BenchmarkOutcome
+	CompletionTime *time.Time
+	Baseline       bool
+}
+
+// Table compares a metric (given by its name and labels) across the runs
+type Table struct {
+	Metric string
+	Labels string
+	Rows   []Row
+}
+
+// Row is the value of a metric in a run
+type Row struct {
+	Run
+	Value float64
+	// Scale is the value relative to the largest value of the table
+	Scale float64
+	// Delta is the difference from the baseline, if any
+	Delta *Delta
+}
+
+// Delta is the difference of a value from the baseline
+type Delta struct {
+	Absolute float64 `json:"absolute"`
+	// Percent is the relative difference, it is not set when the
+	// baseline is zero
+	Percent *float64 `json:"percent,omitempty"`
+}
+
+// New creates the report of the given results. The runs are grouped by
+// the dimensions within the sections of the kinds. When a baseline (the
+// name of a result) is given, the deltas of the runs of the same kind
+// are computed against it.
+func New(title string, results []perfv1alpha1.BenchmarkResult, dimensions []Dimension,
+	baseline string) (*Report, error) {
+	report := &Report{Title: title, Generated: time.Now(), Baseline: baseline}
+
+	var baselineResult *perfv1alpha1.BenchmarkResult
+	kinds := map[string][]*perfv1alpha1.BenchmarkResult{}
+	for i := range results {
+		result := &results[i]
+		if result.Name == baseline {
+			baselineResult = result
+		}
+		kinds[result.Spec.Benchmark.Kind] = append(kinds[result.Spec.Benchmark.Kind], result)
+	}
+	if baseline != "" && baselineResult == nil {
+		return nil, fmt.Errorf("Baseline result not found: %v", baseline)
+	}
+
+	kindNames := make([]string, 0, len(kinds))
+	for kind := range kinds {
+		kindNames = append(kindNames, kind)
+	}
+	sort.Strings(kindNames)
+
+	for _, kind := range kindNames {
+		section := Section{Kind: kind}
+		for _, result := range kinds[kind] {
+			section.Runs = append(section.Runs, newRun(result, dimensions, result == baselineResult))
+		}
+		sort.SliceStable(section.Runs, func(i, j int) bool {
+			return section.Runs[i].less(&section.Runs[j])
+		})
+		section.Metrics = metricTables(section.Runs, kinds[kind], baselineResult)
+		report.Kinds = append(report.Kinds, section)
+	}
+	return report, nil
+}
+
+func newRun(result *perfv1alpha1.BenchmarkResult, dimensions []Dimension, baseline bool) Run {
+	values := []string{}
+	for _, dimension := range dimensions {
+		if value := dimension(result); value != "" {
+			values = append(values, value)
+		}
+	}
+	run := Run{
+		Name:      result.Name,
+		Benchmark: result.Spec.Benchmark.Name,
+		Group:     strings.Join(values, " / "),
+		Outcome:   result.Spec.Outcome,
+		Baseline:  baseline,
+	}
+	if result.Spec.CompletionTime != nil {
+		run.CompletionTime = &result.Spec.CompletionTime.Time
+	}
+	return run
+}
+
+// less orders the runs by their group, completion time and name
+func (r *Run) less(other *Run) bool {
+	if r.Group != other.Group {
+		return r.Group < other.Group
+	}
+	if r.CompletionTime != nil && other.CompletionTime != nil && !r.CompletionTime.Equal(*other.CompletionTime) {
+		return r.CompletionTime.Before(*other.CompletionTime)
+	}
+	return r.Name < other.Name
+}
+
+// metricTables creates a table for each metric of the given runs
+func metricTables(runs []Run, results []*perfv1alpha1.BenchmarkResult,
+	baseline *perfv1alpha1.BenchmarkResult) []Table {
+	byName := map[string]*perfv1alpha1.BenchmarkResult{}
+	for _, result := range results {
+		byName[result.Name] = result
+	}
+
+	tables := map[string]*Table{}
+	var keys []string
+	for _, run := range runs {
+		for _, metric := range byName[run.Name].Spec.Metrics {
+			value, err := strconv.ParseFloat(metric.Value, 64)
+			if err != nil {
+				continue
+			}
+			key := metricKey(&metric)
+			table, ok := tables[key]
+			if !ok {
+				table = &Table{Metric: metric.Name, Labels: formatLabels(metric.Labels)}
+				tables[key] = table
+				keys = append(keys, key)
+			}
+			row := Row{Run: run, Value: value}
+			if baseline != nil && !run.Baseline {
+				if baselineValue, ok := metricValue(baseline, key); ok {
+					row.Delta = newDelta(value, baselineValue)
+				}
+			}
+			table.Rows = append(table.Rows, row)
+		}
+	}
+	sort.Strings(keys)
+
+	result := make([]Table, 0, len(keys))
+	for _, key := range keys {
+		table := tables[key]
+		var max float64
+		for _, row := range table.Rows {
+			if row.Value > max {
+				max = row.Value
+			}
+		}
+		for i := range table.Rows {
+			if max > 0 && table.Rows[i].Value > 0 {
+				table.Rows[i].Scale = table.Rows[i].Value / max
+			}
+		}
+		result = append(result, *table)
+	}
+	return result
+}
+
+func newDelta(value, baseline float64) *Delta {
+	delta := &Delta{Absolute: value - baseline}
+	if baseline != 0 {
+		percent := delta.Absolute / baseline * 100
+		delta.Percent = &percent
+	}
+	return delta
+}
+
+// metricValue returns the value of the metric given by its key
+func metricValue(result *perfv1alpha1.BenchmarkResult, key string) (float64, bool) {
+	for _, metric := range result.Spec.Metrics {
+		if metricKey(&metric) == key {
+			value, err := strconv.ParseFloat(metric.Value, 64)
+			return value, err == nil
+		}
+	}
+	return 0, false
+}
+
+func metricKey(metric *perfv1alpha1.BenchmarkMetric) string {
+	return metric.Name + "{" + formatLabels(metric.Labels) + "}"
+}
+
+// formatLabels formats the labels as sorted name=value pairs
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for name, value := range labels {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+func contains(list []string, item string) bool {
+	for _, element := range list {
+		if element == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+func newResult(name, kind, storageClass string, completed time.Time, iops string) perfv1alpha1.BenchmarkResult {
+	completionTime := metav1.NewTime(completed)
+	return perfv1alpha1.BenchmarkResult{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"team": "storage"}},
+		Spec: perfv1alpha1.BenchmarkResultSpec{
+			Benchmark:      perfv1alpha1.BenchmarkReference{Kind: kind, Name: name},
+			BenchmarkSpec:  runtime.RawExtension{Raw: []byte(`{"podConfig":{"podScheduling":{"nodeName":"node-1"}}}`)},
+			Outcome:        perfv1alpha1.BenchmarkSucceeded,
+			CompletionTime: &completionTime,
+			Metrics: []perfv1alpha1.BenchmarkMetric{
+				{Name: "kubestone_fio_iops", Labels: map[string]string{"job": "randread", "rw": "read"}, Value: iops},
+			},
+			Environment: perfv1alpha1.EnvironmentSpec{
+				NodeNames: []string{"node-1"},
+				Volumes:   []perfv1alpha1.VolumeEnvironment{{ClaimName: name, StorageClassName: storageClass}},
+			},
+		},
+	}
+}
+
+var _ = Describe("Report", func() {
+	day := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	results := []perfv1alpha1.BenchmarkResult{
+		newResult("slow-2", "Fio", "slow", day.Add(time.Hour), "1100"),
+		newResult("fast-1", "Fio", "fast", day, "5000"),
+		newResult("slow-1", "Fio", "slow", day, "1000"),
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "iperf3-1"},
+			Spec: perfv1alpha1.BenchmarkResultSpec{
+				Benchmark: perfv1alpha1.BenchmarkReference{Kind: "Iperf3", Name: "iperf3"},
+				Outcome:   perfv1alpha1.BenchmarkFailed,
+			},
+		},
+	}
+
+	Describe("ParseDimensions", func() {
+		It("should extract the parameters of the runs", func() {
+			dimensions, err := ParseDimensions("storageClass,nodes,label:team,spec:podConfig.podScheduling.nodeName")
+			Expect(err).NotTo(HaveOccurred())
+			values := []string{}
+			for _, dimension := range dimensions {
+				values = append(values, dimension(&results[0]))
+			}
+			Expect(values).To(Equal([]string{"slow", "node-1", "storage", "node-1"}))
+		})
+
+		It("should reject unknown dimensions", func() {
+			_, err := ParseDimensions("zone")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("New", func() {
+		dimensions, _ := ParseDimensions("storageClass")
+
+		It("should group the runs by kind and parameters", func() {
+			report, err := New("test", results, dimensions, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Kinds).To(HaveLen(2))
+			Expect(report.Kinds[0].Kind).To(Equal("Fio"))
+			Expect(report.Kinds[1].Kind).To(Equal("Iperf3"))
+
+			names := []string{}
+			for _, run := range report.Kinds[0].Runs {
+				names = append(names, run.Name+" "+run.Group)
+			}
+			Expect(names).To(Equal([]string{"fast-1 fast", "slow-1 slow", "slow-2 slow"}))
+
+			Expect(report.Kinds[0].Metrics).To(HaveLen(1))
+			table := report.Kinds[0].Metrics[0]
+			Expect(table.Metric).To(Equal("kubestone_fio_iops"))
+			Expect(table.Labels).To(Equal("job=randread, rw=read"))
+			Expect(table.Rows[0].Scale).To(Equal(1.0))
+			Expect(table.Rows[1].Scale).To(Equal(0.2))
+			Expect(table.Rows[0].Delta).To(BeNil())
+		})
+
+		It("should compute the deltas against the baseline", func() {
+			report, err := New("test", results, dimensions, "slow-1")
+			Expect(err).NotTo(HaveOccurred())
+			rows := report.Kinds[0].Metrics[0].Rows
+			Expect(rows[1].Baseline).To(BeTrue())
+			Expect(rows[1].Delta).To(BeNil())
+			Expect(rows[0].Delta.Absolute).To(Equal(4000.0))
+			Expect(*rows[0].Delta.Percent).To(BeNumerically("~", 400, 1e-9))
+			Expect(*rows[2].Delta.Percent).To(BeNumerically("~", 10, 1e-9))
+		})
+
+		It("should reject missing baselines", func() {
+			_, err := New("test", results, dimensions, "missing")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("WriteHTML", func() {
+		It("should render a self-contained page", func() {
+			dimensions, _ := ParseDimensions("storageClass")
+			report, err := New("Storage <classes>", results, dimensions, "slow-1")
+			Expect(err).NotTo(HaveOccurred())
+
+			var html bytes.Buffer
+			Expect(report.WriteHTML(&html)).To(Succeed())
+			Expect(html.String()).To(ContainSubstring("<title>Storage &lt;classes&gt;</title>"))
+			Expect(html.String()).To(ContainSubstring("<h2>Iperf3</h2>"))
+			Expect(html.String()).To(ContainSubstring(`<td class="number up">&#43;4000 (&#43;400.0%)</td>`))
+			Expect(html.String()).NotTo(ContainSubstring("<script"))
+			Expect(html.String()).NotTo(ContainSubstring("<link"))
+		})
+	})
+})
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package report compares the results of benchmark runs.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"sigs.k8s.io/yaml"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+const resultKind = "BenchmarkResult"
+
+// ReadResults reads the BenchmarkResults exported as JSON or YAML. The
+// document is either a single result or a list of results under items,
+// e.g. the output of kubectl get -o json or of the results API.
+func ReadResults(r io.Reader) ([]perfv1alpha1.BenchmarkResult, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var document struct {
+		Kind  string            `json:"kind"`
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	items := document.Items
+	if items == nil {
+		items = []json.RawMessage{data}
+	}
+
+	results := make([]perfv1alpha1.BenchmarkResult, 0, len(items))
+	for _, item := range items {
+		var result perfv1alpha1.BenchmarkResult
+		if err := json.Unmarshal(item, &result); err != nil {
+			return nil, err
+		}
+		if result.Kind != "" && result.Kind != resultKind {
+			return nil, fmt.Errorf("Expected %v, found %v %v", resultKind, result.Kind, result.Name)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReadResults", func() {
+	It("should read a single result", func() {
+		results, err := ReadResults(strings.NewReader(`
+apiVersion: perf.kubestone.xridge.io/v1alpha1
+kind: BenchmarkResult
+metadata:
+  name: fio-fast-6f1c2a4e
+spec:
+  benchmark:
+    kind: Fio
+    name: fio-fast
+  outcome: Succeeded
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Name).To(Equal("fio-fast-6f1c2a4e"))
+		Expect(results[0].Spec.Benchmark.Kind).To(Equal("Fio"))
+	})
+
+	It("should read the items of a list", func() {
+		results, err := ReadResults(strings.NewReader(`{"items": [
+			{"kind": "BenchmarkResult", "metadata": {"name": "a"}},
+			{"kind": "BenchmarkResult", "metadata": {"name": "b"}}
+		]}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		Expect(results[1].Name).To(Equal("b"))
+	})
+
+	It("should reject other kinds", func() {
+		_, err := ReadResults(strings.NewReader(`{"kind": "Fio", "metadata": {"name": "fio"}}`))
+		Expect(err).To(HaveOccurred())
+	})
+})
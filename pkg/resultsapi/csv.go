@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resultsapi
+
+import (
+	"encoding/csv"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var (
+	benchmarksHeader = []string{"kind", "namespace", "name", "phase", "completionTime",
+		"metric", "labels", "value"}
+	resultsHeader = []string{"kind", "namespace", "name", "benchmark", "outcome", "startTime",
+		"completionTime", "metric", "labels", "value"}
+)
+
+// writeBenchmarksCSV writes a row for each metric of the benchmarks.
+// Benchmarks without metrics have a single row without metric.
+func writeBenchmarksCSV(w *csv.Writer, objects []runtime.Object) error {
+	if err := w.Write(benchmarksHeader); err != nil {
+		return err
+	}
+	for _, object := range objects {
+		cr := object.(perfv1alpha1.Benchmark)
+		status := cr.GetBenchmarkStatus()
+		prefix := []string{cr.GetObjectKind().GroupVersionKind().Kind, cr.GetNamespace(), cr.GetName(),
+			status.Phase(), formatTime(status.CompletionTime)}
+		if err := writeMetrics(w, prefix, status.Metrics); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeResultsCSV writes a row for each metric of the results. Results
+// without metrics have a single row without metric.
+func writeResultsCSV(w *csv.Writer, objects []runtime.Object) error {
+	if err := w.Write(resultsHeader); err != nil {
+		return err
+	}
+	for _, object := range objects {
+		result := object.(*perfv1alpha1.BenchmarkResult)
+		prefix := []string{result.Spec.Benchmark.Kind, result.Namespace, result.Name,
+			result.Spec.Benchmark.Name, string(result.Spec.Outcome),
+			formatTime(result.Spec.StartTime), formatTime(result.Spec.CompletionTime)}
+		if err := writeMetrics(w, prefix, result.Spec.Metrics); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMetrics(w *csv.Writer, prefix []string, metrics []perfv1alpha1.BenchmarkMetric) error {
+	if len(metrics) == 0 {
+		return w.Write(append(prefix, "", "", ""))
+	}
+	for _, metric := range metrics {
+		row := append(append([]string{}, prefix...), metric.Name, formatLabels(metric.Labels), metric.Value)
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatTime(t *metav1.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatLabels formats the labels as sorted name=value pairs separated by ;
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for name, value := range labels {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ";")
+}
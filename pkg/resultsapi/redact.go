@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resultsapi
+
+import (
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+const (
+	// redacted replaces the values of the credential fields
+	redacted = "REDACTED"
+
+	// lastAppliedAnnotation holds the whole spec applied by kubectl
+	lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// credentialFields are the fields of the benchmark specs which may hold
+// credentials, e.g. the password of pgbench, the keys of s3bench and
+// the properties of ycsbbench. The references to Secrets (the *From
+// fields) are not redacted.
+var credentialFields = map[string]bool{
+	"password":   true,
+	"accessKey":  true,
+	"secretKey":  true,
+	"properties": true,
+}
+
+// redactObject returns the JSON representation of the benchmark or the
+// BenchmarkResult without the values of the credential fields and of the
+// variables. Besides the spec, the benchmark spec of the BenchmarkResults,
+// the variable annotations and the last applied configuration are
+// redacted. The variables are substituted into arbitrary string fields of
+// the resolved specs, so those cannot be redacted by field name: the
+// resolved spec of the benchmarks and the benchmark spec of the results
+// of templated benchmarks are left out.
+func redactObject(object runtime.Object) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, err
+	}
+
+	templated := false
+	if metadata, ok := decoded["metadata"].(map[string]interface{}); ok {
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			for key := range annotations {
+				if key == lastAppliedAnnotation || strings.HasPrefix(key, k8s.VariablesAnnotationPrefix) {
+					annotations[key] = redacted
+				}
+			}
+			templated = annotations[k8s.TemplatedAnnotation] == "true"
+		}
+	}
+	spec, _ := decoded["spec"].(map[string]interface{})
+	redactSpec(spec)
+	if templated {
+		delete(spec, "benchmarkSpec")
+	} else {
+		redactSpec(spec["benchmarkSpec"])
+	}
+	if status, ok := decoded["status"].(map[string]interface{}); ok {
+		delete(status, "resolvedSpec")
+	}
+	return decoded, nil
+}
+
+// redactSpec replaces the values of the credential fields in the given
+// JSON value, recursively
+func redactSpec(value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if key == "variables" {
+				redactVariables(field)
+				continue
+			}
+			if !credentialFields[key] {
+				redactSpec(field)
+				continue
+			}
+			if values, ok := field.(map[string]interface{}); ok {
+				for name := range values {
+					values[name] = redacted
+				}
+			} else {
+				value[key] = redacted
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			redactSpec(item)
+		}
+	}
+}
+
+// redactVariables replaces the values of the inline variables
+func redactVariables(variables interface{}) {
+	if variables, ok := variables.(map[string]interface{}); ok {
+		if values, ok := variables["values"].(map[string]interface{}); ok {
+			for name := range values {
+				values[name] = redacted
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resultsapi
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+var _ = Describe("redactObject", func() {
+	field := func(object map[string]interface{}, path ...string) interface{} {
+		var value interface{} = object
+		for _, key := range path {
+			value = value.(map[string]interface{})[key]
+		}
+		return value
+	}
+
+	It("should redact the password of pgbench", func() {
+		cr := &perfv1alpha1.Pgbench{
+			ObjectMeta: metav1.ObjectMeta{Name: "pgbench", Annotations: map[string]string{
+				lastAppliedAnnotation: `{"spec":{"postgres":{"password":"admin"}}}`,
+			}},
+			Spec: perfv1alpha1.PgbenchSpec{
+				Postgres: perfv1alpha1.PostgresSpec{Host: "db", User: "admin", Password: "admin"},
+			},
+			Status: perfv1alpha1.BenchmarkStatus{
+				ResolvedSpec: `{"postgres":{"host":"db","password":"admin"}}`,
+			},
+		}
+		object, err := redactObject(cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(field(object, "spec", "postgres", "password")).To(Equal(redacted))
+		Expect(field(object, "spec", "postgres", "user")).To(Equal("admin"))
+		Expect(field(object, "metadata", "annotations", lastAppliedAnnotation)).To(Equal(redacted))
+		Expect(field(object, "status")).NotTo(HaveKey("resolvedSpec"))
+	})
+
+	It("should redact the keys of s3bench and keep the Secret references", func() {
+		cr := &perfv1alpha1.S3Bench{
+			Spec: perfv1alpha1.S3BenchSpec{
+				S3BenchOptions: perfv1alpha1.S3BenchOptions{
+					AccessKey: "minio",
+					SecretKeyFrom: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "s3"}, Key: "secretkey"},
+				},
+			},
+		}
+		object, err := redactObject(cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(field(object, "spec", "accessKey")).To(Equal(redacted))
+		Expect(field(object, "spec", "secretKeyFrom", "name")).To(Equal("s3"))
+	})
+
+	It("should redact the values of the ycsbbench properties", func() {
+		cr := &perfv1alpha1.YcsbBench{
+			Spec: perfv1alpha1.YcsbBenchSpec{
+				Properties: map[string]string{"redis.host": "redis", "redis.password": "secret"},
+			},
+		}
+		object, err := redactObject(cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(field(object, "spec", "properties")).To(Equal(map[string]interface{}{
+			"redis.host": redacted, "redis.password": redacted}))
+	})
+
+	It("should redact the benchmark spec of the BenchmarkResults", func() {
+		result := &perfv1alpha1.BenchmarkResult{
+			Spec: perfv1alpha1.BenchmarkResultSpec{
+				BenchmarkSpec: runtime.RawExtension{Raw: []byte(`{"postgres":{"password":"admin"}}`)},
+			},
+		}
+		object, err := redactObject(result)
+		Expect(err).NotTo(HaveOccurred())
+		encoded, _ := json.Marshal(object)
+		Expect(string(encoded)).NotTo(ContainSubstring("admin"))
+	})
+
+	It("should redact the variables given inline and in annotations", func() {
+		cr := &perfv1alpha1.Fio{
+			ObjectMeta: metav1.ObjectMeta{Name: "fio", Annotations: map[string]string{
+				k8s.VariablesAnnotationPrefix + "token": "secret",
+				"team":                                  "storage",
+			}},
+			Spec: perfv1alpha1.FioSpec{
+				RunPolicySpec: perfv1alpha1.RunPolicySpec{
+					Variables: &perfv1alpha1.VariablesSpec{
+						ConfigMap: "fio-vars",
+						Values:    map[string]string{"password": "secret"},
+					},
+				},
+			},
+			Status: perfv1alpha1.BenchmarkStatus{
+				ResolvedSpec: `{"cmdLineArgs":"--password secret"}`,
+			},
+		}
+		object, err := redactObject(cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(field(object, "metadata", "annotations")).To(Equal(map[string]interface{}{
+			k8s.VariablesAnnotationPrefix + "token": redacted, "team": "storage"}))
+		Expect(field(object, "spec", "variables", "values", "password")).To(Equal(redacted))
+		Expect(field(object, "spec", "variables", "configMap")).To(Equal("fio-vars"))
+		encoded, _ := json.Marshal(object)
+		Expect(string(encoded)).NotTo(ContainSubstring("secret"))
+	})
+
+	It("should leave out the benchmark spec of templated BenchmarkResults", func() {
+		result := &perfv1alpha1.BenchmarkResult{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{k8s.TemplatedAnnotation: "true"}},
+			Spec: perfv1alpha1.BenchmarkResultSpec{
+				BenchmarkSpec: runtime.RawExtension{Raw: []byte(`{"cmdLineArgs":"--password secret"}`)},
+				Outcome:       perfv1alpha1.BenchmarkSucceeded,
+			},
+		}
+		object, err := redactObject(result)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(field(object, "spec")).NotTo(HaveKey("benchmarkSpec"))
+		Expect(field(object, "spec", "outcome")).To(Equal(string(perfv1alpha1.BenchmarkSucceeded)))
+	})
+})
@@ -0,0 +1,344 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resultsapi serves the benchmarks and their results over a
+// read-only HTTP API as JSON or CSV documents.
+package resultsapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+const (
+	// BenchmarksPath lists the benchmarks
+	BenchmarksPath = "/api/v1/benchmarks"
+	// ResultsPath lists the BenchmarkResults
+	ResultsPath = "/api/v1/results"
+
+	jsonFormat = "json"
+	csvFormat  = "csv"
+)
+
+// Server serves the benchmarks and the BenchmarkResults read via Reader,
+// which is the informer cache of the manager. Therefore the API sees the
+// objects visible to the manager: its RBAC identity and watched namespaces
+// apply.
+type Server struct {
+	// Reader reads the benchmarks and the results
+	Reader client.Reader
+	// Scheme holds the benchmark types
+	Scheme *runtime.Scheme
+	// Kinds are the served benchmark kinds, e.g. the kinds of the
+	// enabled controllers
+	Kinds []string
+	// Token is the bearer token required by the API. Without token the
+	// API is only served on loopback addresses.
+	Token string
+}
+
+// query holds the filters of a request
+type query struct {
+	kinds     map[string]bool
+	namespace string
+	selector  labels.Selector
+	since     *time.Time
+	until     *time.Time
+	format    string
+}
+
+// Handler returns the HTTP handler of the API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(BenchmarksPath, s.serve(s.listBenchmarks, writeBenchmarksCSV))
+	mux.HandleFunc(ResultsPath, s.serve(s.listResults, writeResultsCSV))
+	return mux
+}
+
+// Start serves the API on the given address until stop is closed. The
+// address must be a loopback address unless a Token is set.
+func (s *Server) Start(addr string, stop <-chan struct{}) error {
+	if s.Token == "" && !isLoopback(addr) {
+		return fmt.Errorf("The results API on %v requires a token, "+
+			"only loopback addresses can be served without token", addr)
+	}
+
+	server := &http.Server{Addr: addr, Handler: s.Handler()}
+	go func() {
+		<-stop
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+	}()
+
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+type listFunc func(ctx context.Context, q *query) ([]runtime.Object, error)
+type csvFunc func(w *csv.Writer, objects []runtime.Object) error
+
+func (s *Server) serve(list listFunc, writeCSV csvFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !s.authorized(r) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="kubestone"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		q, err := s.parseQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		objects, err := list(r.Context(), q)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if q.format == csvFormat {
+			w.Header().Set("Content-Type", "text/csv")
+			csvWriter := csv.NewWriter(w)
+			if err := writeCSV(csvWriter, objects); err != nil {
+				return
+			}
+			csvWriter.Flush()
+			return
+		}
+		items := []map[string]interface{}{}
+		for _, object := range objects {
+			item, err := redactObject(object)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			items = append(items, item)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	}
+}
+
+// isLoopback checks if the host of the address is localhost or a
+// loopback IP address. Addresses without host listen on every interface.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.Token == "" {
+		return true
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) == 1
+}
+
+// parseQuery parses the filters of the request: kind (comma separated,
+// case insensitive), namespace, labelSelector, since and until (RFC 3339)
+// and format (json or csv)
+func (s *Server) parseQuery(r *http.Request) (*query, error) {
+	values := r.URL.Query()
+	q := &query{
+		kinds:     map[string]bool{},
+		namespace: values.Get("namespace"),
+		selector:  labels.Everything(),
+		format:    jsonFormat,
+	}
+
+	for _, kind := range strings.Split(values.Get("kind"), ",") {
+		if kind = strings.TrimSpace(kind); kind == "" {
+			continue
+		}
+		known := s.kind(kind)
+		if known == "" {
+			return nil, fmt.Errorf("Unknown kind: %v", kind)
+		}
+		q.kinds[known] = true
+	}
+
+	if selector := values.Get("labelSelector"); selector != "" {
+		var err error
+		if q.selector, err = labels.Parse(selector); err != nil {
+			return nil, fmt.Errorf("Invalid labelSelector: %v", err)
+		}
+	}
+
+	for name, into := range map[string]**time.Time{"since": &q.since, "until": &q.until} {
+		if value := values.Get(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid %v: %v", name, err)
+			}
+			*into = &parsed
+		}
+	}
+
+	if format := values.Get("format"); format != "" {
+		q.format = format
+	} else if strings.Contains(r.Header.Get("Accept"), "text/csv") {
+		q.format = csvFormat
+	}
+	if q.format != jsonFormat && q.format != csvFormat {
+		return nil, fmt.Errorf("Unknown format: %v", q.format)
+	}
+	return q, nil
+}
+
+// kind returns the served kind matching the given name case insensitively
+func (s *Server) kind(name string) string {
+	for _, kind := range s.Kinds {
+		if strings.EqualFold(kind, name) {
+			return kind
+		}
+	}
+	return ""
+}
+
+// matches checks the labels and the time of an object against the filters
+func (q *query) matches(object metav1.Object, at *metav1.Time) bool {
+	if !q.selector.Matches(labels.Set(object.GetLabels())) {
+		return false
+	}
+	timestamp := object.GetCreationTimestamp().Time
+	if at != nil {
+		timestamp = at.Time
+	}
+	if q.since != nil && timestamp.Before(*q.since) {
+		return false
+	}
+	if q.until != nil && timestamp.After(*q.until) {
+		return false
+	}
+	return true
+}
+
+// listBenchmarks lists the benchmarks of the requested kinds. The time
+// filters apply to the completion time of the finished benchmarks and to
+// the creation time of the others.
+func (s *Server) listBenchmarks(ctx context.Context, q *query) ([]runtime.Object, error) {
+	objects := []runtime.Object{}
+	for _, kind := range s.Kinds {
+		if len(q.kinds) > 0 && !q.kinds[kind] {
+			continue
+		}
+
+		gvk := perfv1alpha1.GroupVersion.WithKind(kind + "List")
+		list, err := s.Scheme.New(gvk)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Reader.List(ctx, list, client.InNamespace(q.namespace)); err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			cr, ok := item.(perfv1alpha1.Benchmark)
+			if !ok || !q.matches(cr, cr.GetBenchmarkStatus().CompletionTime) {
+				continue
+			}
+			cr.GetObjectKind().SetGroupVersionKind(perfv1alpha1.GroupVersion.WithKind(kind))
+			objects = append(objects, cr)
+		}
+	}
+
+	sortObjects(objects, func(object runtime.Object) *metav1.Time {
+		return object.(perfv1alpha1.Benchmark).GetBenchmarkStatus().CompletionTime
+	})
+	return objects, nil
+}
+
+// listResults lists the BenchmarkResults of the requested kinds. The
+// time filters apply to the completion time of the runs.
+func (s *Server) listResults(ctx context.Context, q *query) ([]runtime.Object, error) {
+	var list perfv1alpha1.BenchmarkResultList
+	if err := s.Reader.List(ctx, &list, client.InNamespace(q.namespace)); err != nil {
+		return nil, err
+	}
+
+	objects := []runtime.Object{}
+	for i := range list.Items {
+		result := &list.Items[i]
+		if len(q.kinds) > 0 && !q.kinds[result.Spec.Benchmark.Kind] {
+			continue
+		}
+		if !q.matches(result, result.Spec.CompletionTime) {
+			continue
+		}
+		result.SetGroupVersionKind(perfv1alpha1.GroupVersion.WithKind("BenchmarkResult"))
+		objects = append(objects, result)
+	}
+
+	sortObjects(objects, func(object runtime.Object) *metav1.Time {
+		return object.(*perfv1alpha1.BenchmarkResult).Spec.CompletionTime
+	})
+	return objects, nil
+}
+
+// sortObjects sorts the objects by their time (or creation time) and name
+func sortObjects(objects []runtime.Object, at func(runtime.Object) *metav1.Time) {
+	timestamp := func(i int) time.Time {
+		if t := at(objects[i]); t != nil {
+			return t.Time
+		}
+		return objects[i].(metav1.Object).GetCreationTimestamp().Time
+	}
+	name := func(i int) string {
+		object := objects[i].(metav1.Object)
+		return object.GetNamespace() + "/" + object.GetName()
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		if ti, tj := timestamp(i), timestamp(j); !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return name(i) < name(j)
+	})
+}
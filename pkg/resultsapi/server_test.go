@@ -0,0 +1,214 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resultsapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("Server", func() {
+	var server *Server
+	completed := metav1.NewTime(time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(completed.Add(24 * time.Hour))
+
+	BeforeEach(func() {
+		// The fake client decodes the objects with the client-go scheme
+		Expect(perfv1alpha1.AddToScheme(k8sscheme.Scheme)).To(Succeed())
+
+		objects := []runtime.Object{
+			&perfv1alpha1.Fio{
+				ObjectMeta: metav1.ObjectMeta{Name: "fio-fast", Namespace: "storage",
+					Labels: map[string]string{"storageclass": "fast"}},
+				Status: perfv1alpha1.BenchmarkStatus{
+					Completed:      true,
+					CompletionTime: &completed,
+					Metrics: []perfv1alpha1.BenchmarkMetric{
+						{Name: "kubestone_fio_iops", Labels: map[string]string{"job": "randread", "rw": "read"},
+							Value: "5234.5"},
+					},
+				},
+			},
+			&perfv1alpha1.Iperf3{
+				ObjectMeta: metav1.ObjectMeta{Name: "iperf3", Namespace: "network"},
+				Status:     perfv1alpha1.BenchmarkStatus{Running: true},
+			},
+			&perfv1alpha1.BenchmarkResult{
+				ObjectMeta: metav1.ObjectMeta{Name: "fio-fast-6f1c2a4e", Namespace: "storage",
+					Labels: map[string]string{"kubestone.xridge.io/app": "fio"}},
+				Spec: perfv1alpha1.BenchmarkResultSpec{
+					Benchmark:      perfv1alpha1.BenchmarkReference{Kind: "Fio", Name: "fio-fast"},
+					Outcome:        perfv1alpha1.BenchmarkSucceeded,
+					CompletionTime: &completed,
+					Metrics: []perfv1alpha1.BenchmarkMetric{
+						{Name: "kubestone_fio_iops", Labels: map[string]string{"job": "randread"},
+							Value: "5234.5"},
+					},
+				},
+			},
+			&perfv1alpha1.BenchmarkResult{
+				ObjectMeta: metav1.ObjectMeta{Name: "iperf3-0d6b1f3e", Namespace: "network",
+					Labels: map[string]string{"kubestone.xridge.io/app": "iperf3"}},
+				Spec: perfv1alpha1.BenchmarkResultSpec{
+					Benchmark:      perfv1alpha1.BenchmarkReference{Kind: "Iperf3", Name: "iperf3"},
+					Outcome:        perfv1alpha1.BenchmarkFailed,
+					CompletionTime: &later,
+				},
+			},
+		}
+		server = &Server{
+			Reader: fake.NewFakeClientWithScheme(k8sscheme.Scheme, objects...),
+			Scheme: k8sscheme.Scheme,
+			Kinds:  []string{"Fio", "Iperf3"},
+		}
+	})
+
+	get := func(url string, header ...string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		for i := 0; i+1 < len(header); i += 2 {
+			request.Header.Set(header[i], header[i+1])
+		}
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	names := func(recorder *httptest.ResponseRecorder) []string {
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		var list struct {
+			Items []struct {
+				metav1.TypeMeta   `json:",inline"`
+				metav1.ObjectMeta `json:"metadata"`
+			} `json:"items"`
+		}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &list)).To(Succeed())
+		names := []string{}
+		for _, item := range list.Items {
+			names = append(names, item.Kind+"/"+item.Name)
+		}
+		return names
+	}
+
+	Describe("benchmarks", func() {
+		It("should list the benchmarks of every kind", func() {
+			Expect(names(get(BenchmarksPath))).To(Equal([]string{"Iperf3/iperf3", "Fio/fio-fast"}))
+		})
+
+		It("should filter by kind, namespace and labels", func() {
+			Expect(names(get(BenchmarksPath + "?kind=fio"))).To(Equal([]string{"Fio/fio-fast"}))
+			Expect(names(get(BenchmarksPath + "?namespace=network"))).To(Equal([]string{"Iperf3/iperf3"}))
+			Expect(names(get(BenchmarksPath + "?labelSelector=storageclass%3Dfast"))).To(
+				Equal([]string{"Fio/fio-fast"}))
+		})
+
+		It("should write a row for each metric as CSV", func() {
+			recorder := get(BenchmarksPath+"?kind=Fio,Iperf3", "Accept", "text/csv")
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("text/csv"))
+			Expect(recorder.Body.String()).To(Equal(
+				"kind,namespace,name,phase,completionTime,metric,labels,value\n" +
+					"Iperf3,network,iperf3,Running,,,,\n" +
+					"Fio,storage,fio-fast,Succeeded,2019-10-01T12:00:00Z,kubestone_fio_iops,job=randread;rw=read,5234.5\n"))
+		})
+	})
+
+	Describe("results", func() {
+		It("should list the results of every kind", func() {
+			Expect(names(get(ResultsPath))).To(Equal([]string{
+				"BenchmarkResult/fio-fast-6f1c2a4e", "BenchmarkResult/iperf3-0d6b1f3e"}))
+		})
+
+		It("should filter by the time range of the runs", func() {
+			Expect(names(get(ResultsPath + "?since=2019-10-02T00:00:00Z"))).To(Equal([]string{
+				"BenchmarkResult/iperf3-0d6b1f3e"}))
+			Expect(names(get(ResultsPath + "?until=2019-10-02T00:00:00Z"))).To(Equal([]string{
+				"BenchmarkResult/fio-fast-6f1c2a4e"}))
+		})
+
+		It("should filter by kind and labels", func() {
+			Expect(names(get(ResultsPath + "?kind=Iperf3"))).To(Equal([]string{
+				"BenchmarkResult/iperf3-0d6b1f3e"}))
+			Expect(names(get(ResultsPath + "?labelSelector=kubestone.xridge.io/app%3Dfio"))).To(Equal([]string{
+				"BenchmarkResult/fio-fast-6f1c2a4e"}))
+		})
+
+		It("should write a row for each metric as CSV", func() {
+			recorder := get(ResultsPath + "?format=csv")
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).To(Equal(
+				"kind,namespace,name,benchmark,outcome,startTime,completionTime,metric,labels,value\n" +
+					"Fio,storage,fio-fast-6f1c2a4e,fio-fast,Succeeded,,2019-10-01T12:00:00Z," +
+					"kubestone_fio_iops,job=randread,5234.5\n" +
+					"Iperf3,network,iperf3-0d6b1f3e,iperf3,Failed,,2019-10-02T12:00:00Z,,,\n"))
+		})
+	})
+
+	Describe("invalid requests", func() {
+		It("should reject unknown kinds", func() {
+			Expect(get(ResultsPath + "?kind=Sysbench").Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should reject invalid filters", func() {
+			Expect(get(ResultsPath + "?since=yesterday").Code).To(Equal(http.StatusBadRequest))
+			Expect(get(ResultsPath + "?labelSelector=a%3D%3D%3Db").Code).To(Equal(http.StatusBadRequest))
+			Expect(get(ResultsPath + "?format=xml").Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should only allow reads", func() {
+			request := httptest.NewRequest(http.MethodDelete, ResultsPath, nil)
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Describe("without a token", func() {
+		It("should only be served on loopback addresses", func() {
+			stop := make(chan struct{})
+			close(stop)
+			Expect(server.Start(":8090", stop)).NotTo(Succeed())
+			Expect(server.Start("10.0.0.1:8090", stop)).NotTo(Succeed())
+			Expect(isLoopback("127.0.0.1:8090")).To(BeTrue())
+			Expect(isLoopback("[::1]:8090")).To(BeTrue())
+			Expect(isLoopback("localhost:8090")).To(BeTrue())
+		})
+	})
+
+	Describe("with a token", func() {
+		BeforeEach(func() {
+			server.Token = "s3cr3t"
+		})
+
+		It("should require the bearer token", func() {
+			Expect(get(ResultsPath).Code).To(Equal(http.StatusUnauthorized))
+			Expect(get(ResultsPath, "Authorization", "Bearer wrong").Code).To(Equal(http.StatusUnauthorized))
+			Expect(get(ResultsPath, "Authorization", "Bearer s3cr3t").Code).To(Equal(http.StatusOK))
+		})
+	})
+})
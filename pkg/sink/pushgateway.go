@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xridge/kubestone/pkg/httpclient"
+)
+
+// Pushgateway pushes the results to a Prometheus Pushgateway. Every
+// benchmark run is pushed as a separate group, so pushing the same
+// run again replaces the earlier values.
+type Pushgateway struct {
+	URL    string
+	Client *http.Client
+}
+
+// Push implements Sink
+func (p *Pushgateway) Push(ctx context.Context, group Group) error {
+	request, err := http.NewRequest(http.MethodPut, p.groupURL(group.Key),
+		bytes.NewReader(encodeText(group.Samples, group.Key)))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "text/plain; version=0.0.4")
+	return httpclient.Send(ctx, p.Client, request)
+}
+
+// groupURL returns the URL of the group identified by the given key
+// as /metrics/job/kubestone/<label>/<value>/...
+func (p *Pushgateway) groupURL(key map[string]string) string {
+	path := strings.TrimSuffix(p.URL, "/") + "/metrics/job/kubestone"
+	for _, name := range sortedKeys(key) {
+		path += "/" + url.PathEscape(name) + "/" + url.PathEscape(key[name])
+	}
+	return path
+}
+
+// encodeText encodes the samples in the Prometheus text exposition format.
+// Labels clashing with the grouping labels (or job) would be overwritten
+// by the Pushgateway, so they are renamed to exported_<label> like
+// Prometheus does during scraping.
+func encodeText(samples []Sample, key map[string]string) []byte {
+	sorted := make([]Sample, len(samples))
+	copy(sorted, samples)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var buffer bytes.Buffer
+	for i, sample := range sorted {
+		if i == 0 || sorted[i-1].Name != sample.Name {
+			fmt.Fprintf(&buffer, "# TYPE %s gauge\n", sample.Name)
+		}
+		buffer.WriteString(sample.Name)
+		if len(sample.Labels) > 0 {
+			var labels []string
+			for _, name := range sortedKeys(sample.Labels) {
+				exportedName := name
+				if _, clash := key[name]; clash || name == "job" {
+					exportedName = "exported_" + name
+				}
+				labels = append(labels, exportedName+"="+strconv.Quote(sample.Labels[name]))
+			}
+			buffer.WriteString("{" + strings.Join(labels, ",") + "}")
+		}
+		buffer.WriteString(" " + strconv.FormatFloat(sample.Value, 'g', -1, 64) + "\n")
+	}
+	return buffer.Bytes()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+
+	"github.com/xridge/kubestone/pkg/httpclient"
+)
+
+// RemoteWrite pushes the results to a Prometheus remote write endpoint
+type RemoteWrite struct {
+	URL    string
+	Client *http.Client
+}
+
+// Push implements Sink
+func (r *RemoteWrite) Push(ctx context.Context, group Group) error {
+	body := snappy.Encode(nil, encodeWriteRequest(group))
+	request, err := http.NewRequest(http.MethodPost, r.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Encoding", "snappy")
+	request.Header.Set("Content-Type", "application/x-protobuf")
+	request.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	return httpclient.Send(ctx, r.Client, request)
+}
+
+// Field numbers of the remote write protobuf messages (prompb)
+const (
+	writeRequestTimeseries = 1
+	timeSeriesLabels       = 1
+	timeSeriesSamples      = 2
+	labelName              = 1
+	labelValue             = 2
+	sampleValue            = 1
+	sampleTimestamp        = 2
+)
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+// encodeWriteRequest encodes the samples as a prompb.WriteRequest,
+// with one time series per sample
+func encodeWriteRequest(group Group) []byte {
+	timestamp := group.Timestamp.UnixNano() / 1e6
+
+	request := proto.NewBuffer(nil)
+	for _, sample := range group.Samples {
+		labels := map[string]string{"__name__": sample.Name}
+		for name, value := range group.Key {
+			labels[name] = value
+		}
+		for name, value := range sample.Labels {
+			labels[name] = value
+		}
+
+		timeSeries := proto.NewBuffer(nil)
+		// Remote write requires the labels to be sorted by name
+		for _, name := range sortedKeys(labels) {
+			label := proto.NewBuffer(nil)
+			encodeString(label, labelName, name)
+			encodeString(label, labelValue, labels[name])
+			encodeMessage(timeSeries, timeSeriesLabels, label)
+		}
+
+		protoSample := proto.NewBuffer(nil)
+		_ = protoSample.EncodeVarint(uint64(sampleValue<<3 | wireFixed64))
+		_ = protoSample.EncodeFixed64(math.Float64bits(sample.Value))
+		_ = protoSample.EncodeVarint(uint64(sampleTimestamp<<3 | wireVarint))
+		_ = protoSample.EncodeVarint(uint64(timestamp))
+		encodeMessage(timeSeries, timeSeriesSamples, protoSample)
+
+		encodeMessage(request, writeRequestTimeseries, timeSeries)
+	}
+	return request.Bytes()
+}
+
+func encodeString(buffer *proto.Buffer, field int, value string) {
+	_ = buffer.EncodeVarint(uint64(field<<3 | wireBytes))
+	_ = buffer.EncodeStringBytes(value)
+}
+
+func encodeMessage(buffer *proto.Buffer, field int, message *proto.Buffer) {
+	_ = buffer.EncodeVarint(uint64(field<<3 | wireBytes))
+	_ = buffer.EncodeRawBytes(message.Bytes())
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sink pushes the results of the finished benchmarks to
+// external metric storages.
+package sink
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// PushgatewayType pushes the results to a Prometheus Pushgateway
+	PushgatewayType = "pushgateway"
+	// RemoteWriteType pushes the results to a Prometheus remote write endpoint
+	RemoteWriteType = "remote-write"
+)
+
+// Sample is a single value of a metric
+type Sample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// Group contains the samples of a single benchmark run
+type Group struct {
+	// Key identifies the benchmark run, e.g. kind, namespace and
+	// name of the benchmark. It is added to the labels of the samples.
+	Key map[string]string
+	// Timestamp is the time of the samples
+	Timestamp time.Time
+	Samples   []Sample
+}
+
+// Sink pushes the results of benchmarks
+type Sink interface {
+	Push(ctx context.Context, group Group) error
+}
+
+// New creates the sink of the given type pushing to url
+func New(sinkType, url string) (Sink, error) {
+	switch sinkType {
+	case PushgatewayType:
+		return &Pushgateway{URL: url, Client: http.DefaultClient}, nil
+	case RemoteWriteType:
+		return &RemoteWrite{URL: url, Client: http.DefaultClient}, nil
+	default:
+		return nil, fmt.Errorf("Unknown result sink type: %v", sinkType)
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type receivedRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   []byte
+}
+
+// newStub starts an HTTP server recording the received requests
+// and responding with the given status code
+func newStub(statusCode int) (*httptest.Server, *[]receivedRequest) {
+	requests := &[]receivedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*requests = append(*requests, receivedRequest{
+			method: r.Method,
+			path:   r.URL.EscapedPath(),
+			header: r.Header,
+			body:   body,
+		})
+		w.WriteHeader(statusCode)
+	}))
+	return server, requests
+}
+
+var group = Group{
+	Key:       map[string]string{"kind": "Fio", "namespace": "kubestone", "cr": "fio-sample"},
+	Timestamp: time.Unix(1570000000, 0),
+	Samples: []Sample{
+		{Name: "kubestone_fio_iops", Labels: map[string]string{"job": "randread", "rw": "read"}, Value: 1500},
+		{Name: "kubestone_benchmark_succeeded", Labels: map[string]string{"node": "node-1"}, Value: 1},
+		{Name: "kubestone_fio_iops", Labels: map[string]string{"job": "randwrite", "rw": "write"}, Value: 2.5e6},
+	},
+}
+
+var _ = Describe("New", func() {
+	It("should reject unknown sink types", func() {
+		_, err := New("influxdb", "http://localhost")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Pushgateway", func() {
+	It("should put the samples to the group of the benchmark", func() {
+		server, requests := newStub(http.StatusOK)
+		defer server.Close()
+
+		sink, err := New(PushgatewayType, server.URL+"/")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sink.Push(context.Background(), group)).To(Succeed())
+
+		Expect(*requests).To(HaveLen(1))
+		request := (*requests)[0]
+		Expect(request.method).To(Equal(http.MethodPut))
+		Expect(request.path).To(Equal("/metrics/job/kubestone/cr/fio-sample/kind/Fio/namespace/kubestone"))
+		Expect(string(request.body)).To(Equal(
+			"# TYPE kubestone_benchmark_succeeded gauge\n" +
+				"kubestone_benchmark_succeeded{node=\"node-1\"} 1\n" +
+				"# TYPE kubestone_fio_iops gauge\n" +
+				"kubestone_fio_iops{exported_job=\"randread\",rw=\"read\"} 1500\n" +
+				"kubestone_fio_iops{exported_job=\"randwrite\",rw=\"write\"} 2.5e+06\n"))
+	})
+
+	It("should escape the label values", func() {
+		body := encodeText([]Sample{{Name: "m", Labels: map[string]string{"l": "a\"b\\c\n"}, Value: 1}}, nil)
+		Expect(string(body)).To(Equal("# TYPE m gauge\nm{l=\"a\\\"b\\\\c\\n\"} 1\n"))
+	})
+
+	It("should return the error responses", func() {
+		server, _ := newStub(http.StatusBadRequest)
+		defer server.Close()
+
+		sink, _ := New(PushgatewayType, server.URL)
+		Expect(sink.Push(context.Background(), group)).NotTo(Succeed())
+	})
+})
+
+type decodedSeries struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+}
+
+// decodeWriteRequest decodes the time series of a prompb.WriteRequest
+func decodeWriteRequest(data []byte) []decodedSeries {
+	var result []decodedSeries
+	request := proto.NewBuffer(data)
+	// DecodeVarint fails at the end of the buffer
+	for tag, err := request.DecodeVarint(); err == nil; tag, err = request.DecodeVarint() {
+		Expect(tag).To(BeEquivalentTo(writeRequestTimeseries<<3 | wireBytes))
+		raw, err := request.DecodeRawBytes(false)
+		Expect(err).NotTo(HaveOccurred())
+
+		series := decodedSeries{labels: map[string]string{}}
+		timeSeries := proto.NewBuffer(raw)
+		for tag, err := timeSeries.DecodeVarint(); err == nil; tag, err = timeSeries.DecodeVarint() {
+			raw, err := timeSeries.DecodeRawBytes(false)
+			Expect(err).NotTo(HaveOccurred())
+			message := proto.NewBuffer(raw)
+			switch tag {
+			case timeSeriesLabels<<3 | wireBytes:
+				_, _ = message.DecodeVarint()
+				name, _ := message.DecodeStringBytes()
+				_, _ = message.DecodeVarint()
+				value, _ := message.DecodeStringBytes()
+				series.labels[name] = value
+			case timeSeriesSamples<<3 | wireBytes:
+				_, _ = message.DecodeVarint()
+				bits, _ := message.DecodeFixed64()
+				series.value = math.Float64frombits(bits)
+				_, _ = message.DecodeVarint()
+				timestamp, _ := message.DecodeVarint()
+				series.timestamp = int64(timestamp)
+			default:
+				Fail("unexpected field in time series")
+			}
+		}
+		result = append(result, series)
+	}
+	return result
+}
+
+var _ = Describe("RemoteWrite", func() {
+	It("should post the samples as snappy compressed protobuf", func() {
+		server, requests := newStub(http.StatusNoContent)
+		defer server.Close()
+
+		sink, err := New(RemoteWriteType, server.URL+"/api/v1/write")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sink.Push(context.Background(), group)).To(Succeed())
+
+		Expect(*requests).To(HaveLen(1))
+		request := (*requests)[0]
+		Expect(request.method).To(Equal(http.MethodPost))
+		Expect(request.path).To(Equal("/api/v1/write"))
+		Expect(request.header.Get("Content-Encoding")).To(Equal("snappy"))
+		Expect(request.header.Get("Content-Type")).To(Equal("application/x-protobuf"))
+		Expect(request.header.Get("X-Prometheus-Remote-Write-Version")).To(Equal("0.1.0"))
+
+		data, err := snappy.Decode(nil, request.body)
+		Expect(err).NotTo(HaveOccurred())
+		series := decodeWriteRequest(data)
+		Expect(series).To(HaveLen(3))
+		Expect(series[0]).To(Equal(decodedSeries{
+			labels: map[string]string{
+				"__name__": "kubestone_fio_iops", "kind": "Fio", "namespace": "kubestone",
+				"cr": "fio-sample", "job": "randread", "rw": "read",
+			},
+			value:     1500,
+			timestamp: 1570000000000,
+		}))
+		Expect(series[2].value).To(Equal(2.5e6))
+	})
+
+	It("should sort the labels by name", func() {
+		data := encodeWriteRequest(Group{
+			Key:     map[string]string{"z": "1", "a": "2"},
+			Samples: []Sample{{Name: "m", Value: 1}},
+		})
+		Expect(string(data)).To(MatchRegexp("(?s)__name__.*a.*z"))
+	})
+
+	It("should return the error responses", func() {
+		server, _ := newStub(http.StatusInternalServerError)
+		defer server.Close()
+
+		sink, _ := New(RemoteWriteType, server.URL)
+		Expect(sink.Push(context.Background(), group)).NotTo(Succeed())
+	})
+})
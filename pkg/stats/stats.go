@@ -0,0 +1,138 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stats summarizes the results of repeated benchmark iterations.
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// tValues are the two-sided 95% critical values of Student's
+// t-distribution by degrees of freedom (1-30)
+var tValues = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// zValue is used instead of the t-distribution above 30 degrees of freedom
+const zValue = 1.96
+
+// Summary describes the distribution of the values of a metric
+type Summary struct {
+	N      int
+	Mean   float64
+	Median float64
+	// StdDev is the sample standard deviation
+	StdDev float64
+	Min    float64
+	Max    float64
+	// CILower and CIUpper bound the 95% confidence interval of the mean.
+	// They are only valid when N > 1.
+	CILower float64
+	CIUpper float64
+	// Outliers are the indexes of the values outside of the Tukey fences
+	// (1.5 interquartile ranges below the first or above the third
+	// quartile). Outliers are only detected when N >= 4.
+	Outliers []int
+}
+
+// Summarize computes the summary of the given values. The values are
+// not modified.
+func Summarize(values []float64) Summary {
+	summary := Summary{N: len(values)}
+	if summary.N == 0 {
+		return summary
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	summary.Mean = sum / float64(summary.N)
+	summary.Median = quantile(sorted, 0.5)
+	summary.Min = sorted[0]
+	summary.Max = sorted[summary.N-1]
+	summary.CILower = summary.Mean
+	summary.CIUpper = summary.Mean
+
+	if summary.N > 1 {
+		squares := 0.0
+		for _, value := range values {
+			squares += (value - summary.Mean) * (value - summary.Mean)
+		}
+		summary.StdDev = math.Sqrt(squares / float64(summary.N-1))
+
+		margin := tValue(summary.N-1) * summary.StdDev / math.Sqrt(float64(summary.N))
+		summary.CILower = summary.Mean - margin
+		summary.CIUpper = summary.Mean + margin
+	}
+
+	if summary.N >= 4 {
+		q1, q3 := quantile(sorted, 0.25), quantile(sorted, 0.75)
+		low, high := q1-1.5*(q3-q1), q3+1.5*(q3-q1)
+		for i, value := range values {
+			if value < low || value > high {
+				summary.Outliers = append(summary.Outliers, i)
+			}
+		}
+	}
+
+	return summary
+}
+
+// quantile returns the q-quantile of the sorted values using
+// linear interpolation between the closest ranks
+func quantile(sorted []float64, q float64) float64 {
+	position := q * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(position-float64(lower))
+}
+
+func tValue(degreesOfFreedom int) float64 {
+	if degreesOfFreedom <= len(tValues) {
+		return tValues[degreesOfFreedom-1]
+	}
+	return zValue
+}
+
+// Significant tells if the means of the two samples differ at the 95%
+// confidence level according to Welch's t-test. ok is false when either
+// sample has less than two values, the test is not possible then.
+func Significant(a, b Summary) (significant, ok bool) {
+	if a.N < 2 || b.N < 2 {
+		return false, false
+	}
+
+	varianceA := a.StdDev * a.StdDev / float64(a.N)
+	varianceB := b.StdDev * b.StdDev / float64(b.N)
+	if varianceA+varianceB == 0 {
+		return a.Mean != b.Mean, true
+	}
+	t := math.Abs(a.Mean-b.Mean) / math.Sqrt(varianceA+varianceB)
+
+	// Welch-Satterthwaite approximation of the degrees of freedom
+	degreesOfFreedom := (varianceA + varianceB) * (varianceA + varianceB) /
+		(varianceA*varianceA/float64(a.N-1) + varianceB*varianceB/float64(b.N-1))
+	return t > tValue(int(math.Max(1, math.Floor(degreesOfFreedom)))), true
+}
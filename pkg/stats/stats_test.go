@@ -0,0 +1,97 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Summarize", func() {
+	It("should summarize the values", func() {
+		values := []float64{12, 10, 11, 13, 9}
+		summary := Summarize(values)
+
+		Expect(summary.N).To(Equal(5))
+		Expect(summary.Mean).To(BeNumerically("~", 11, 1e-9))
+		Expect(summary.Median).To(BeNumerically("~", 11, 1e-9))
+		Expect(summary.Min).To(Equal(9.0))
+		Expect(summary.Max).To(Equal(13.0))
+		Expect(summary.StdDev).To(BeNumerically("~", 1.5811, 1e-4))
+		// 11 -/+ 2.776 * 1.5811 / sqrt(5)
+		Expect(summary.CILower).To(BeNumerically("~", 9.0371, 1e-4))
+		Expect(summary.CIUpper).To(BeNumerically("~", 12.9629, 1e-4))
+		Expect(summary.Outliers).To(BeEmpty())
+		Expect(values).To(Equal([]float64{12, 10, 11, 13, 9}))
+	})
+
+	It("should interpolate the median of even number of values", func() {
+		Expect(Summarize([]float64{4, 1, 3, 2}).Median).To(Equal(2.5))
+	})
+
+	It("should flag the outliers by their index", func() {
+		summary := Summarize([]float64{100, 101, 99, 100, 160, 102, 98, 40})
+		Expect(summary.Outliers).To(Equal([]int{4, 7}))
+	})
+
+	It("should not compute spread for a single value", func() {
+		summary := Summarize([]float64{42})
+		Expect(summary.StdDev).To(BeZero())
+		Expect(summary.CILower).To(Equal(42.0))
+		Expect(summary.CIUpper).To(Equal(42.0))
+	})
+
+	It("should use the normal distribution above 30 values", func() {
+		values := make([]float64, 100)
+		for i := range values {
+			values[i] = float64(i % 2)
+		}
+		summary := Summarize(values)
+		Expect(summary.CIUpper - summary.Mean).To(BeNumerically("~", 1.96*summary.StdDev/10, 1e-9))
+	})
+
+	It("should handle empty input", func() {
+		Expect(Summarize(nil)).To(Equal(Summary{}))
+	})
+})
+
+var _ = Describe("Significant", func() {
+	It("should detect the difference of the means", func() {
+		significant, ok := Significant(Summarize([]float64{100, 102, 98, 101, 99}),
+			Summarize([]float64{90, 92, 88, 91, 89}))
+		Expect(ok).To(BeTrue())
+		Expect(significant).To(BeTrue())
+	})
+
+	It("should treat differences within the noise as insignificant", func() {
+		significant, ok := Significant(Summarize([]float64{100, 120, 80, 110, 90}),
+			Summarize([]float64{105, 125, 85, 95, 100}))
+		Expect(ok).To(BeTrue())
+		Expect(significant).To(BeFalse())
+	})
+
+	It("should compare the means of constant values", func() {
+		significant, ok := Significant(Summarize([]float64{5, 5}), Summarize([]float64{6, 6}))
+		Expect(ok).To(BeTrue())
+		Expect(significant).To(BeTrue())
+	})
+
+	It("should need at least two values of each sample", func() {
+		_, ok := Significant(Summarize([]float64{100}), Summarize([]float64{90, 92}))
+		Expect(ok).To(BeFalse())
+	})
+})
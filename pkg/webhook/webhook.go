@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook notifies HTTP endpoints about the lifecycle
+// events of benchmarks.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/httpclient"
+)
+
+const (
+	// ConfigKey is the key of the webhooks in the ConfigMap
+	// holding the global webhooks
+	ConfigKey = "webhooks.yaml"
+
+	// EventHeader holds the event of the payload
+	EventHeader = "X-Kubestone-Event"
+	// SignatureHeader holds the HMAC-SHA256 signature of the payload
+	SignatureHeader = "X-Kubestone-Signature"
+)
+
+// Payload is the JSON document posted to the webhooks
+type Payload struct {
+	Event     perfv1alpha1.WebhookEvent `json:"event"`
+	Kind      string                    `json:"kind"`
+	Name      string                    `json:"name"`
+	Namespace string                    `json:"namespace"`
+	Timestamp time.Time                 `json:"timestamp"`
+	// Results are the results of the finished benchmark
+	Results []perfv1alpha1.BenchmarkMetric `json:"results,omitempty"`
+	// Comparisons are the regression checks of the results
+	Comparisons []perfv1alpha1.MetricComparison `json:"comparisons,omitempty"`
+	// Links point to the objects related to the benchmark, e.g. the
+	// BenchmarkResult of the run or the archived output
+	Links map[string]string `json:"links,omitempty"`
+}
+
+// ParseConfig parses the global webhooks stored in a ConfigMap
+func ParseConfig(data map[string]string) ([]perfv1alpha1.WebhookSpec, error) {
+	var webhooks []perfv1alpha1.WebhookSpec
+	if err := yaml.Unmarshal([]byte(data[ConfigKey]), &webhooks); err != nil {
+		return nil, fmt.Errorf("Invalid %v: %v", ConfigKey, err)
+	}
+	return webhooks, nil
+}
+
+// Subscribed checks if the webhook should receive the given event
+func Subscribed(webhook *perfv1alpha1.WebhookSpec, event perfv1alpha1.WebhookEvent) bool {
+	if len(webhook.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range webhook.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign returns the value of the SignatureHeader for the given body
+func Sign(body, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts the encoded payload of the given event to the url. The
+// body is signed when a key is given.
+func Send(ctx context.Context, client *http.Client, url string, event perfv1alpha1.WebhookEvent,
+	body, key []byte) error {
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, string(event))
+	if len(key) > 0 {
+		request.Header.Set(SignatureHeader, Sign(body, key))
+	}
+
+	return httpclient.Send(ctx, client, request)
+}
+
+// Allowed checks if the url may be notified by the webhooks of the
+// benchmarks. The url must be http or https and its host (either with
+// or without the port) must be listed in allowedHosts.
+func Allowed(webhookURL string, allowedHosts []string) bool {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return false
+	}
+	for _, host := range allowedHosts {
+		if host == parsed.Host || host == parsed.Hostname() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("ParseConfig", func() {
+	It("should parse the webhooks", func() {
+		webhooks, err := ParseConfig(map[string]string{ConfigKey: `
+- url: https://chat.example.com/hooks/benchmarks
+  events: [Failed, Regressed]
+  signingSecret:
+    name: webhook-secret
+    key: hmac
+`})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(webhooks).To(HaveLen(1))
+		Expect(webhooks[0].URL).To(Equal("https://chat.example.com/hooks/benchmarks"))
+		Expect(webhooks[0].Events).To(Equal([]perfv1alpha1.WebhookEvent{
+			perfv1alpha1.FailedEvent, perfv1alpha1.RegressedEvent}))
+		Expect(webhooks[0].SigningSecret.Name).To(Equal("webhook-secret"))
+		Expect(webhooks[0].SigningSecret.Key).To(Equal("hmac"))
+	})
+
+	It("should reject invalid config", func() {
+		_, err := ParseConfig(map[string]string{ConfigKey: "url: [x"})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Subscribed", func() {
+	It("should send every event without explicit events", func() {
+		Expect(Subscribed(&perfv1alpha1.WebhookSpec{}, perfv1alpha1.StartedEvent)).To(BeTrue())
+	})
+
+	It("should only send the listed events", func() {
+		webhook := perfv1alpha1.WebhookSpec{Events: []perfv1alpha1.WebhookEvent{perfv1alpha1.FailedEvent}}
+		Expect(Subscribed(&webhook, perfv1alpha1.FailedEvent)).To(BeTrue())
+		Expect(Subscribed(&webhook, perfv1alpha1.SucceededEvent)).To(BeFalse())
+	})
+})
+
+var _ = Describe("Send", func() {
+	var server *httptest.Server
+	var header http.Header
+	var body []byte
+	statusCode := http.StatusOK
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+			body, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(statusCode)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should post the signed payload", func() {
+		payload := []byte(`{"event":"Failed"}`)
+		Expect(Send(context.Background(), http.DefaultClient, server.URL,
+			perfv1alpha1.FailedEvent, payload, []byte("secret"))).To(Succeed())
+
+		Expect(body).To(Equal(payload))
+		Expect(header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(header.Get(EventHeader)).To(Equal("Failed"))
+		// echo -n '{"event":"Failed"}' | openssl dgst -sha256 -hmac secret
+		Expect(header.Get(SignatureHeader)).To(Equal(
+			"sha256=79736f5639470bcdc3f2a665cf742c7458cdc19b89c85cc4e972a7f81c870d22"))
+	})
+
+	It("should not sign without key", func() {
+		Expect(Send(context.Background(), http.DefaultClient, server.URL,
+			perfv1alpha1.StartedEvent, []byte("{}"), nil)).To(Succeed())
+		Expect(header.Get(SignatureHeader)).To(BeEmpty())
+	})
+
+	It("should return the error responses", func() {
+		statusCode = http.StatusBadGateway
+		defer func() { statusCode = http.StatusOK }()
+		Expect(Send(context.Background(), http.DefaultClient, server.URL,
+			perfv1alpha1.StartedEvent, []byte("{}"), nil)).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("Allowed", func() {
+	allowedHosts := []string{"hooks.example.com", "ci.example.com:8443"}
+
+	It("should allow the listed hosts", func() {
+		Expect(Allowed("https://hooks.example.com/benchmarks", allowedHosts)).To(BeTrue())
+		Expect(Allowed("http://hooks.example.com:8080/benchmarks", allowedHosts)).To(BeTrue())
+		Expect(Allowed("https://ci.example.com:8443/notify", allowedHosts)).To(BeTrue())
+	})
+
+	It("should reject the other hosts and ports", func() {
+		Expect(Allowed("http://169.254.169.254/latest/meta-data", allowedHosts)).To(BeFalse())
+		Expect(Allowed("https://ci.example.com/notify", allowedHosts)).To(BeFalse())
+		Expect(Allowed("https://hooks.example.com.evil.com/", allowedHosts)).To(BeFalse())
+	})
+
+	It("should reject other schemes", func() {
+		Expect(Allowed("file://hooks.example.com/etc/passwd", allowedHosts)).To(BeFalse())
+	})
+
+	It("should reject every url without allowed hosts", func() {
+		Expect(Allowed("https://hooks.example.com/benchmarks", nil)).To(BeFalse())
+	})
+})